@@ -2,6 +2,9 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -17,51 +20,123 @@ import (
 	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/festival"
 	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
 	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/mirror"
 	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/schedule"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/sim"
 	"github.com/lancekrogers/agent-coordinator-ethden-2026/pkg/creclient"
 	"github.com/lancekrogers/agent-coordinator-ethden-2026/pkg/daemon"
 )
 
 func main() {
+	networkName := flag.String("network", "testnet",
+		"Hedera network to run against: testnet, or sim for an in-memory network with simulated agents")
+	flag.Parse()
+
 	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
 
-	cfg, err := config.Load()
-	if err != nil {
-		log.Error("failed to load config", "error", err)
-		os.Exit(1)
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	var (
+		cfg        *config.Env
+		services   hederaServices
+		simNetwork *sim.Network
+		simAgents  []simAgent
+		err        error
+		simulated  = *networkName == "sim"
+	)
+	switch *networkName {
+	case "testnet":
+		cfg, err = config.Load()
+		if err != nil {
+			log.Error("failed to load config", "error", err)
+			os.Exit(1)
+		}
+		services, err = testnetServices(cfg)
+		if err != nil {
+			log.Error("failed to connect to testnet", "error", err)
+			os.Exit(1)
+		}
+	case "sim":
+		cfg, simNetwork, simAgents, err = setupSim(ctx, envBool("SIM_PAYMENT_ESCROW", false))
+		if err != nil {
+			log.Error("failed to set up simulated network", "error", err)
+			os.Exit(1)
+		}
+		services = hederaServices{
+			publisher:  simNetwork,
+			subscriber: simNetwork,
+			transfer:   simNetwork,
+			balances:   simNetwork,
+			history:    simNetwork,
+			scheduler:  simNetwork,
+		}
+		log.Info("running against simulated Hedera network",
+			"coordinator_account", cfg.CoordinatorAccountID,
+			"payment_token", cfg.Coordinator.PaymentTokenID)
+	default:
+		log.Error("unknown network", "network", *networkName)
+		os.Exit(2)
 	}
 
+	// Task deadline and retry tuning.
+	cfg.Coordinator.MaxReassignments = envInt("TASK_MAX_REASSIGNMENTS", cfg.Coordinator.MaxReassignments)
+	retry := &cfg.Coordinator.Retry
+	retry.MaxAttempts = envInt("RETRY_MAX_ATTEMPTS", retry.MaxAttempts)
+	retry.Backoff = envDurationSeconds("RETRY_BACKOFF_SECONDS", retry.Backoff)
+	retry.MaxBackoff = envDurationSeconds("RETRY_MAX_BACKOFF_SECONDS", retry.MaxBackoff)
+	retry.RetryOn = envList("RETRY_ON", retry.RetryOn)
+	retry.SwitchAgent = envBool("RETRY_SWITCH_AGENT", retry.SwitchAgent)
+	cfg.Coordinator.QualityGateWorkers = envInt("QUALITY_GATE_WORKERS", cfg.Coordinator.QualityGateWorkers)
+	cfg.Coordinator.QualityGateTimeout = envDurationSeconds("QUALITY_GATE_TIMEOUT_SECONDS", cfg.Coordinator.QualityGateTimeout)
+	cfg.Coordinator.PaymentBatchSize = envInt("PAYMENT_BATCH_SIZE", cfg.Coordinator.PaymentBatchSize)
+	cfg.Coordinator.PaymentBatchWindow = envDurationSeconds("PAYMENT_BATCH_WINDOW_SECONDS", cfg.Coordinator.PaymentBatchWindow)
+	paymentRetry := &cfg.Coordinator.PaymentRetry
+	paymentRetry.MaxAttempts = envInt("PAYMENT_RETRY_MAX_ATTEMPTS", paymentRetry.MaxAttempts)
+	paymentRetry.Backoff = envDurationSeconds("PAYMENT_RETRY_BACKOFF_SECONDS", paymentRetry.Backoff)
+	paymentRetry.MaxBackoff = envDurationSeconds("PAYMENT_RETRY_MAX_BACKOFF_SECONDS", paymentRetry.MaxBackoff)
+	// Zero disables reconciliation, so envDurationSeconds does not fit here.
+	reconcileSeconds := envInt("PAYMENT_RECONCILE_INTERVAL_SECONDS", int(cfg.Coordinator.ReconcileInterval.Seconds()))
+	cfg.Coordinator.ReconcileInterval = time.Duration(reconcileSeconds) * time.Second
+
 	if err := cfg.Coordinator.Validate(); err != nil {
 		log.Error("invalid coordinator config", "error", err)
 		os.Exit(1)
 	}
 
-	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
-	defer cancel()
-
 	// Connect to daemon runtime (optional — agent works standalone if unavailable).
 	daemonClient := connectDaemon(ctx, log, cfg.CoordinatorAccountID.String())
 	defer daemonClient.Close()
 
-	// Create Hedera client with coordinator credentials.
-	hederaClient := hiero.ClientForTestnet()
-	hederaClient.SetOperator(cfg.CoordinatorAccountID, cfg.CoordinatorKey)
-
-	// Initialize HCS publisher and subscriber.
-	publisher := hcs.NewPublisher(hederaClient, hcs.DefaultPublishConfig())
-	subscriber := hcs.NewSubscriber(hederaClient, hcs.DefaultSubscribeConfig())
+	// Outgoing envelopes are numbered in one sequence per topic, whichever
+	// component sends them, and signed with the coordinator key; incoming
+	// signatures are checked per HCS_SIGNATURE_POLICY.
+	signer := hcs.NewEnvelopeSigner(cfg.CoordinatorAccountID, cfg.CoordinatorKey)
+	publisher := hcs.NewSequencedPublisher(hcs.NewSigningPublisher(services.publisher, signer))
+	subscriber, err := newSubscriber(services.subscriber, cfg, os.Getenv("HCS_SIGNATURE_POLICY"))
+	if err != nil {
+		log.Error("failed to configure HCS subscriber", "error", err)
+		os.Exit(1)
+	}
 
-	// Initialize HTS transfer service.
-	transferSvc := hts.NewTransferService(hederaClient)
+	// One status topic subscription is shared by every consumer; the router
+	// hands each the message types it handles. Underneath it, each message is
+	// delivered once and sender sequence gaps are reported.
+	sequenced := hcs.NewSequencedSubscriber(subscriber)
+	router := hcs.NewRouter(sequenced,
+		hcs.RouterConfig{RouteBuffer: envInt("HCS_ROUTE_BUFFER", 100)})
+	statusRoute := func(name string, types ...hcs.MessageType) hcs.MessageSubscriber {
+		return router.Route(cfg.Coordinator.StatusTopicID, hcs.Route{Name: name, Types: types})
+	}
 
-	// Schedule service — 4th Hedera native service.
-	scheduleSvc := schedule.NewScheduleService(hederaClient)
+	transferSvc := services.transfer
+	scheduleSvc := services.scheduler
 
 	heartbeatCfg := schedule.DefaultHeartbeatConfig()
 	heartbeatCfg.AgentID = "coordinator"
 	heartbeatCfg.AccountID = cfg.CoordinatorAccountID
 
-	heartbeat, err := schedule.NewHeartbeat(hederaClient, scheduleSvc, heartbeatCfg)
+	heartbeat, err := schedule.NewHeartbeat(services.client, scheduleSvc, heartbeatCfg)
 	if err != nil {
 		log.Error("failed to create heartbeat runner", "error", err)
 		os.Exit(1)
@@ -74,10 +149,25 @@ func main() {
 	}()
 
 	// Create coordinator components.
-	inferenceAgentID := "inference-001"
-	defiAgentID := "defi-001"
-	agentIDs := []string{inferenceAgentID, defiAgentID}
-	assigner := coordinator.NewAssigner(publisher, cfg.Coordinator.TaskTopicID, agentIDs)
+	agentInfos := make([]coordinator.AgentInfo, 0, len(cfg.Agents))
+	for _, agent := range cfg.Agents {
+		agentInfos = append(agentInfos, agent.AgentInfo())
+	}
+	registry, err := coordinator.NewAgentRegistry(agentInfos)
+	if err != nil {
+		log.Error("failed to build agent registry", "error", err)
+		os.Exit(1)
+	}
+	registry.SetStaleAfter(envDurationSeconds("AGENT_STALE_AFTER_SECONDS", 90*time.Second))
+	heartbeats := statusRoute("registry", hcs.MessageTypeHeartbeat)
+	go func() {
+		if err := registry.Start(ctx, heartbeats, cfg.Coordinator.StatusTopicID); err != nil {
+			log.Error("agent registry stopped", "error", err)
+		}
+	}()
+
+	assigner := coordinator.NewAssigner(publisher, cfg.Coordinator.TaskTopicID, nil)
+	assigner.SetRegistry(registry)
 
 	// Wire CRE Risk Router client (optional — skipped if CRE_ENDPOINT not set).
 	if creEndpoint := os.Getenv("CRE_ENDPOINT"); creEndpoint != "" {
@@ -85,28 +175,200 @@ func main() {
 		creClient := creclient.New(creEndpoint, creTimeout)
 		assigner.SetCREClient(creClient)
 		log.Info("CRE Risk Router enabled", "endpoint", creEndpoint)
+	} else if simulated {
+		assigner.SetCREClient(simRiskRouter{})
+		log.Info("CRE Risk Router simulated, DeFi tasks are approved")
 	} else {
 		log.Warn("CRE Risk Router not configured, DeFi tasks will be denied (fail-closed)")
 	}
-	monitor := coordinator.NewMonitor(subscriber, cfg.Coordinator.StatusTopicID, nil)
+	// Per-task-type output validators (optional — outputs are accepted as-is if unset).
+	var validation coordinator.OutputValidation
+	if raw := os.Getenv("OUTPUT_VALIDATION"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &validation); err != nil {
+			log.Error("invalid OUTPUT_VALIDATION", "error", err)
+			os.Exit(1)
+		}
+	}
+	validators, err := validation.Validators()
+	if err != nil {
+		log.Error("invalid OUTPUT_VALIDATION", "error", err)
+		os.Exit(1)
+	}
+	outputGate := coordinator.NewOutputGate(validators)
+
+	// Lifecycle events connect the components: payment waits for the monitor
+	// to report a task complete, and settled payments mark tasks paid.
+	events := coordinator.NewEventBus()
+	assigner.SetEvents(events)
+
+	monitor := coordinator.NewMonitor(statusRoute("monitor", hcs.MessageTypeStatusUpdate),
+		cfg.Coordinator.StatusTopicID, outputGate)
+	monitor.SetGateLimits(cfg.Coordinator.QualityGateWorkers, cfg.Coordinator.QualityGateTimeout)
+	monitor.SetEvents(events)
+	assigner.SetMonitor(monitor, cfg.Coordinator.MonitorPollInterval)
+
+	// Lease assigned tasks to their agents and reassign those that go silent.
+	watchdog := coordinator.NewWatchdog(assigner, monitor, cfg.Coordinator)
+	assigner.SetWatchdog(watchdog)
+	monitor.SetWatchdog(watchdog)
+
+	// Hold tasks behind sequence gates until the gates pass.
+	gates := coordinator.NewSequenceGates(monitor, publisher, cfg.Coordinator.TaskTopicID)
+	assigner.SetGates(gates)
+
+	// Quality gate mode: "auto" accepts validated outputs; "review" also has a
+	// second agent verify inference outputs before tasks complete.
+	var peerReview *coordinator.PeerReviewGate
+	var reviewResults hcs.MessageSubscriber
+	switch mode := strings.TrimSpace(os.Getenv("QUALITY_GATE_MODE")); mode {
+	case "", "auto":
+	case "review":
+		peerReview = coordinator.NewPeerReviewGate(assigner, monitor, publisher,
+			cfg.Coordinator.TaskTopicID, []string{coordinator.TaskTypeInference})
+		monitor.SetGate(coordinator.GateChain{outputGate, peerReview})
+		reviewResults = statusRoute("peer-review", hcs.MessageTypeTaskResult)
+	default:
+		log.Error("invalid QUALITY_GATE_MODE, want auto or review", "mode", mode)
+		os.Exit(1)
+	}
+
 	payment := coordinator.NewPayment(transferSvc, publisher, cfg.Coordinator)
+	payment.SetEvents(events)
+	payment.SetBalances(services.balances)
+	if batcher, ok := transferSvc.(hts.BatchTokenTransfer); ok {
+		payment.SetBatchTransfer(batcher)
+	}
+	// Escrow payments at assignment (optional — only with an escrow account).
+	var escrow coordinator.PaymentEscrow
+	if cfg.Coordinator.EscrowAccountID.Account != 0 {
+		creator, canExpire := services.scheduler.(schedule.ExpiringScheduleCreator)
+		signer, canSign := services.scheduler.(schedule.ScheduleSigner)
+		if !canExpire || !canSign {
+			log.Error("schedule service cannot hold escrowed payments, escrow unavailable")
+			os.Exit(1)
+		}
+		payment.SetEscrow(creator, signer)
+		escrow = payment
+		log.Info("payment escrow enabled", "escrow_account", cfg.Coordinator.EscrowAccountID)
+	}
+	reconciler := coordinator.NewReconciler(payment, services.history, cfg.Coordinator)
+	paymentRetrier := coordinator.NewPaymentRetrier(payment, services.history, cfg.Coordinator.PaymentRetry)
+	retrier := coordinator.NewRetrier(assigner, monitor, cfg.Coordinator.Retry)
+	assigner.SetRetrier(retrier)
 
 	// Agent ID → Hedera account ID for payments.
-	agentAccounts := map[string]string{
-		"inference-001": cfg.Agent1AccountID,
-		"defi-001":      cfg.Agent2AccountID,
+	agentAccounts := registry.Accounts()
+
+	// Per-task-type pricing rules (optional — plan PaymentAmount applies if unset).
+	var pricing coordinator.PaymentPricing
+	if raw := os.Getenv("PAYMENT_PRICING"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &pricing); err != nil {
+			log.Error("invalid PAYMENT_PRICING", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Persistent task state (optional — state is in-memory only if unset).
+	var stateStore coordinator.StateStore
+	if statePath := os.Getenv("COORDINATOR_STATE_PATH"); statePath != "" {
+		fileStore, err := coordinator.NewFileStore(statePath)
+		if err != nil {
+			log.Error("failed to open state store", "path", statePath, "error", err)
+			os.Exit(1)
+		}
+		defer fileStore.Close()
+		stateStore = fileStore
+		monitor.SetStore(stateStore)
+		assigner.SetStore(stateStore)
+		payment.SetStore(stateStore)
+		retrier.SetStore(stateStore)
+	} else {
+		log.Warn("COORDINATOR_STATE_PATH not set, task state will not survive restarts")
 	}
 
 	resultHandler := coordinator.NewResultHandler(coordinator.ResultHandlerConfig{
-		Subscriber:    subscriber,
+		Subscriber:    statusRoute("results", hcs.MessageTypeTaskResult, hcs.MessageTypePnLReport),
+		Publisher:     publisher,
 		TopicID:       cfg.Coordinator.StatusTopicID,
 		Payment:       payment,
 		Config:        cfg.Coordinator,
 		Log:           log,
 		AgentAccounts: agentAccounts,
+		Assignments:   assigner,
+		Pricing:       pricing,
+		Store:         stateStore,
+		Retrier:       retrier,
+		OutputGate:    outputGate,
+		Monitor:       monitor,
+		Events:        events,
+		Escrow:        escrow,
 	})
+	outputGate.SetResults(resultHandler)
+	if escrow != nil {
+		assigner.SetEscrow(resultHandler)
+	}
+
+	// Decide sequence gates on the results of the tasks they cover.
+	resultCheck := coordinator.NewResultGateCheck(resultHandler, outputGate)
+	for _, kind := range []string{
+		coordinator.GateKindTesting, coordinator.GateKindReview,
+		coordinator.GateKindIterate, coordinator.GateKindFestCommit,
+	} {
+		gates.SetCheck(kind, resultCheck)
+	}
 
-	// Start monitor, result handler, and daemon heartbeat in background.
+	// Rehydrate state from a previous run before anything consumes it.
+	if stateStore != nil {
+		snap, err := coordinator.Recover(stateStore, monitor, assigner, payment, resultHandler, retrier)
+		if err != nil {
+			log.Error("failed to recover coordinator state", "error", err)
+			os.Exit(1)
+		}
+		log.Info("coordinator state recovered",
+			"tasks", len(snap.TaskStatuses),
+			"assignments", len(snap.Assignments),
+			"payments", len(snap.Payments),
+			"results", len(snap.Results))
+	}
+
+	// Rebuild state from HCS topic history (optional — skipped if COORDINATOR_REPLAY_FROM not set).
+	if replayFrom := os.Getenv("COORDINATOR_REPLAY_FROM"); replayFrom != "" {
+		from, err := parseReplayFrom(replayFrom)
+		if err != nil {
+			log.Error("invalid COORDINATOR_REPLAY_FROM", "value", replayFrom, "error", err)
+			os.Exit(1)
+		}
+		stats, err := coordinator.Rehydrate(ctx, coordinator.RehydrateConfig{
+			Replayer: subscriber,
+			Config:   cfg.Coordinator,
+			From:     from,
+			Monitor:  monitor,
+			Assigner: assigner,
+			Payment:  payment,
+			Results:  resultHandler,
+			Log:      log,
+			// Live subscriptions resume after the replayed history.
+			Checkpoints: sequenced,
+		})
+		if err != nil {
+			log.Error("failed to rehydrate from HCS history", "error", err)
+			os.Exit(1)
+		}
+		log.Info("coordinator state rehydrated from HCS",
+			"from", replayFrom,
+			"assignments", stats.Assignments,
+			"status_updates", stats.StatusUpdates,
+			"results", stats.Results,
+			"settlements", stats.Settlements,
+			"skipped", stats.Skipped)
+	}
+
+	// Start the router, monitor, result handler, and daemon heartbeat in background.
+	go func() {
+		if err := router.Start(ctx); err != nil {
+			log.Error("HCS router stopped", "error", err)
+		}
+	}()
 	go func() {
 		if err := monitor.Start(ctx); err != nil {
 			log.Error("monitor stopped", "error", err)
@@ -117,7 +379,37 @@ func main() {
 			log.Error("result handler stopped", "error", err)
 		}
 	}()
+	go func() {
+		if err := watchdog.Start(ctx); err != nil {
+			log.Error("watchdog stopped", "error", err)
+		}
+	}()
+	go func() {
+		if err := reconciler.Start(ctx); err != nil {
+			log.Error("payment reconciler stopped", "error", err)
+		}
+	}()
+	go func() {
+		if err := paymentRetrier.Start(ctx); err != nil {
+			log.Error("payment retrier stopped", "error", err)
+		}
+	}()
+	go func() {
+		if err := gates.Start(ctx, cfg.Coordinator.MonitorPollInterval); err != nil {
+			log.Error("sequence gates stopped", "error", err)
+		}
+	}()
+	if peerReview != nil {
+		go func() {
+			if err := peerReview.Start(ctx, reviewResults, cfg.Coordinator.StatusTopicID); err != nil {
+				log.Error("peer review gate stopped", "error", err)
+			}
+		}()
+	}
 	go daemonHeartbeatLoop(ctx, log, daemonClient)
+	for _, agent := range simAgents {
+		go agent.run(ctx, simNetwork, cfg.Coordinator, log)
+	}
 
 	// Build runtime fest adapter and derive an execution plan.
 	festReader := festival.NewReader(festival.ReaderConfig{
@@ -128,13 +420,11 @@ func main() {
 	}, log)
 	festRuntime := coordinator.NewFestRuntime(
 		festReader,
-		inferenceAgentID,
-		defiAgentID,
 		envInt("FEST_STALE_AFTER_SECONDS", 30),
 		log,
 	)
 
-	allowSynthetic := envBool("FEST_FALLBACK_ALLOW_SYNTHETIC", false)
+	allowSynthetic := envBool("FEST_FALLBACK_ALLOW_SYNTHETIC", simulated)
 	pollInterval := envDurationSeconds("FEST_POLL_INTERVAL_SECONDS", 10*time.Second)
 	planSource := "fest"
 	initialSource := "fest"
@@ -148,7 +438,7 @@ func main() {
 		}
 		planSource = "synthetic_fallback_static_plan"
 		initialSource = "synthetic"
-		plan = coordinator.IntegrationCyclePlan(inferenceAgentID, defiAgentID)
+		plan = coordinator.IntegrationCyclePlan()
 		log.Warn("fest runtime unavailable, using static fallback plan", "error", err)
 	} else {
 		festSelector = selectedSelector
@@ -158,6 +448,25 @@ func main() {
 			"progress_pct", snapshot.FestivalProgress.OverallCompletionPercent)
 	}
 
+	if err := plan.ValidateAgents(registry); err != nil {
+		log.Error("refusing to start with invalid plan",
+			"plan_source", planSource,
+			"error", err)
+		os.Exit(1)
+	}
+
+	resultHandler.SetPlan(plan)
+	outputGate.SetPlan(plan)
+	if peerReview != nil {
+		peerReview.SetPlan(plan)
+	}
+	retrier.SetPlan(plan)
+
+	// Pay for tasks that completed before a restart but were not paid.
+	if n := resultHandler.PayRecovered(ctx); n > 0 {
+		log.Info("paid recovered task results", "tasks", n)
+	}
+
 	// Publish periodic festival progress updates for dashboard consumption.
 	progressPublisher := coordinator.NewFestProgressPublisher(
 		festRuntime,
@@ -185,8 +494,10 @@ func main() {
 		"poll_interval_seconds", int(pollInterval.Seconds()),
 		"tasks", plan.TaskCount())
 
+	// Dispatch blocks until every task is released or blocked, holding
+	// dependent tasks until the monitor sees their dependencies complete.
 	assignedIDs, err := assigner.AssignTasks(ctx, plan)
-	if err != nil {
+	if err != nil && ctx.Err() == nil {
 		log.Error("failed to assign tasks", "error", err)
 		os.Exit(1)
 	}
@@ -195,6 +506,12 @@ func main() {
 	// Block until shutdown signal.
 	<-ctx.Done()
 	log.Info("coordinator shutting down")
+
+	// Settle payments still waiting for their batch rather than leave them
+	// pending.
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancelFlush()
+	payment.Flush(flushCtx)
 }
 
 func connectDaemon(ctx context.Context, log *slog.Logger, hederaAccountID string) daemon.DaemonClient {
@@ -248,6 +565,102 @@ func daemonHeartbeatLoop(ctx context.Context, log *slog.Logger, client daemon.Da
 	}
 }
 
+// topicSubscriber is an HCS subscriber that can also replay topic history.
+type topicSubscriber interface {
+	hcs.MessageSubscriber
+	hcs.MessageReplayer
+}
+
+// hederaServices are the network-facing services the coordinator runs on,
+// backed either by a testnet client or by a simulated network.
+type hederaServices struct {
+	client     *hiero.Client // nil on the simulated network
+	publisher  hcs.MessagePublisher
+	subscriber topicSubscriber
+	transfer   hts.TokenTransfer
+	balances   hts.TokenBalances
+	history    hts.TransferHistory
+	scheduler  schedule.ScheduleCreator
+}
+
+// testnetServices connects to Hedera testnet with the coordinator's
+// credentials. Balance and transfer history queries go to MIRROR_NODE_URL, or the public testnet
+// mirror node if unset.
+func testnetServices(cfg *config.Env) (hederaServices, error) {
+	client := hiero.ClientForTestnet()
+	client.SetOperator(cfg.CoordinatorAccountID, cfg.CoordinatorKey)
+
+	mirrorURL := strings.TrimSpace(os.Getenv("MIRROR_NODE_URL"))
+	if mirrorURL == "" {
+		mirrorURL = mirror.NetworkURL("testnet")
+	}
+	mirrorClient, err := mirror.NewClient(mirrorURL, mirror.DefaultConfig())
+	if err != nil {
+		return hederaServices{}, fmt.Errorf("configure mirror node: %w", err)
+	}
+	transfer := hts.NewTransferService(client)
+	transfer.SetMirror(mirrorClient)
+
+	// Escrow releases are signed with the escrow account's key.
+	scheduler := schedule.NewScheduleService(client)
+	if cfg.EscrowKey != nil {
+		scheduler.SetSigningKey(*cfg.EscrowKey)
+	}
+
+	return hederaServices{
+		client:     client,
+		publisher:  hcs.NewPublisher(client, hcs.DefaultPublishConfig()),
+		subscriber: hcs.NewSubscriber(client, hcs.DefaultSubscribeConfig()),
+		transfer:   transfer,
+		balances:   transfer,
+		history:    transfer,
+		scheduler:  scheduler,
+	}, nil
+}
+
+// newSubscriber wraps the HCS subscriber with signature verification unless
+// policy is empty or "off". Policy "flag" delivers unverified envelopes and
+// reports them; "drop" discards them.
+func newSubscriber(subscriber topicSubscriber, cfg *config.Env, policy string) (topicSubscriber, error) {
+	var verifyPolicy hcs.VerifyPolicy
+	switch strings.ToLower(strings.TrimSpace(policy)) {
+	case "", "off":
+		return subscriber, nil
+	case "flag":
+		verifyPolicy = hcs.VerifyFlag
+	case "drop":
+		verifyPolicy = hcs.VerifyDrop
+	default:
+		return nil, fmt.Errorf("unknown HCS_SIGNATURE_POLICY %q (want off, flag or drop)", policy)
+	}
+
+	registry := hcs.NewKeyRegistry()
+	registry.Register("coordinator", cfg.CoordinatorKey.PublicKey())
+	for _, agent := range cfg.Agents {
+		if agent.Key != nil {
+			registry.Register(agent.ID, *agent.Key)
+		}
+	}
+	return hcs.NewVerifyingSubscriber(subscriber, registry, verifyPolicy), nil
+}
+
+// parseReplayFrom parses a replay starting point: "genesis" for the start of
+// the topic, an RFC 3339 consensus timestamp, or a topic sequence number.
+func parseReplayFrom(v string) (hcs.ReplayOptions, error) {
+	v = strings.TrimSpace(v)
+	if strings.EqualFold(v, "genesis") {
+		return hcs.ReplayOptions{}, nil
+	}
+	if seq, err := strconv.ParseUint(v, 10, 64); err == nil {
+		return hcs.ReplayOptions{StartSequence: seq}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return hcs.ReplayOptions{}, fmt.Errorf("want \"genesis\", a sequence number or an RFC 3339 timestamp: %w", err)
+	}
+	return hcs.ReplayOptions{StartTime: t}, nil
+}
+
 func envBool(name string, defaultVal bool) bool {
 	v := strings.TrimSpace(os.Getenv(name))
 	if v == "" {
@@ -275,6 +688,24 @@ func envInt(name string, defaultVal int) int {
 	return n
 }
 
+// envList reads a comma-separated list. "all" yields an empty list.
+func envList(name string, defaultVal []string) []string {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return defaultVal
+	}
+	if strings.EqualFold(v, "all") {
+		return nil
+	}
+	var list []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 func envDurationSeconds(name string, defaultVal time.Duration) time.Duration {
 	seconds := envInt(name, int(defaultVal.Seconds()))
 	if seconds <= 0 {
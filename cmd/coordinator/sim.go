@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/config"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/coordinator"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/sim"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/pkg/creclient"
+)
+
+// simAgent is a simulated worker agent that completes every task assigned to it.
+type simAgent struct {
+	id        string
+	taskTypes []string
+	signer    *hcs.EnvelopeSigner
+}
+
+// simHeartbeatInterval is how often simulated agents announce themselves.
+const simHeartbeatInterval = 30 * time.Second
+
+// simEscrowFunding is how many payment tokens the simulated escrow account
+// is funded with from the treasury.
+const simEscrowFunding = 100000
+
+// simRiskRouter stands in for the CRE Risk Router on the simulated network,
+// where there is no router to call. It approves every trade up to the
+// requested position, so DeFi tasks are dispatched rather than denied.
+type simRiskRouter struct{}
+
+// EvaluateRisk implements coordinator.RiskEvaluator.
+func (simRiskRouter) EvaluateRisk(ctx context.Context, req creclient.RiskRequest) (creclient.RiskDecision, error) {
+	if err := ctx.Err(); err != nil {
+		return creclient.RiskDecision{}, fmt.Errorf("sim risk check for task %s: %w", req.TaskID, err)
+	}
+	return creclient.RiskDecision{
+		Approved:       true,
+		MaxPositionUSD: uint64(req.RequestedPosition),
+		MaxSlippageBps: 50,
+		TTLSeconds:     300,
+		Reason:         "approved by simulated risk router",
+		Timestamp:      time.Now().Unix(),
+	}, nil
+}
+
+// setupSim creates an in-memory network with the coordinator and agent
+// accounts, both topics and the payment token, and returns a config that
+// points at them along with the simulated agents. With escrow set, it also
+// creates an escrow account funded from the treasury.
+func setupSim(ctx context.Context, escrow bool) (*config.Env, *sim.Network, []simAgent, error) {
+	network := sim.NewNetwork()
+
+	coordKey, err := hiero.PrivateKeyGenerateEd25519()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("sim: generate coordinator key: %w", err)
+	}
+	coordAcct := network.CreateAccount()
+
+	taskTopic, err := network.CreateTopic(ctx, "festival-tasks")
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("sim: %w", err)
+	}
+	statusTopic, err := network.CreateTopic(ctx, "agent-status")
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("sim: %w", err)
+	}
+
+	tokenCfg := hts.DefaultTokenConfig()
+	tokenCfg.TreasuryAccountID = coordAcct
+	paymentToken, err := network.CreateFungibleToken(ctx, tokenCfg)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("sim: %w", err)
+	}
+
+	agentSpecs := []config.AgentConfig{
+		{ID: "inference-001", TaskTypes: []string{coordinator.TaskTypeInference}},
+		{ID: "defi-001", TaskTypes: []string{coordinator.TaskTypeTrade, "trade", "defi"}},
+	}
+	agents := make([]simAgent, len(agentSpecs))
+	for i := range agentSpecs {
+		spec := &agentSpecs[i]
+		key, err := hiero.PrivateKeyGenerateEd25519()
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("sim: generate key for %s: %w", spec.ID, err)
+		}
+		account := network.CreateAccount()
+		if err := network.AssociateToken(ctx, paymentToken, account); err != nil {
+			return nil, nil, nil, fmt.Errorf("sim: %w", err)
+		}
+		pub := key.PublicKey()
+		spec.AccountID = account.String()
+		spec.PublicKey = pub.String()
+		spec.Key = &pub
+		agents[i] = simAgent{id: spec.ID, taskTypes: spec.TaskTypes, signer: hcs.NewEnvelopeSigner(account, key)}
+	}
+
+	cfg := coordinator.DefaultConfig()
+	cfg.TaskTopicID = taskTopic
+	cfg.StatusTopicID = statusTopic
+	cfg.PaymentTokenID = paymentToken
+	cfg.TreasuryAccountID = coordAcct
+
+	if escrow {
+		escrowAcct := network.CreateAccount()
+		if err := network.AssociateToken(ctx, paymentToken, escrowAcct); err != nil {
+			return nil, nil, nil, fmt.Errorf("sim: %w", err)
+		}
+		if _, err := network.Transfer(ctx, hts.TransferRequest{
+			TokenID:       paymentToken,
+			FromAccountID: coordAcct,
+			ToAccountID:   escrowAcct,
+			Amount:        simEscrowFunding,
+			Memo:          "fund escrow",
+		}); err != nil {
+			return nil, nil, nil, fmt.Errorf("sim: fund escrow: %w", err)
+		}
+		cfg.EscrowAccountID = escrowAcct
+	}
+
+	return &config.Env{
+		CoordinatorAccountID: coordAcct,
+		CoordinatorKey:       coordKey,
+		Agents:               agentSpecs,
+		Coordinator:          cfg,
+	}, network, agents, nil
+}
+
+// run announces the agent with periodic heartbeats and answers task
+// assignments addressed to it by reporting the task in progress, in review
+// and complete, then publishing a completed result.
+func (a simAgent) run(ctx context.Context, network *sim.Network, cfg coordinator.Config, log *slog.Logger) {
+	publisher := hcs.NewSigningPublisher(network, a.signer)
+	assignments, errs := network.Subscribe(ctx, cfg.TaskTopicID)
+	go func() {
+		for err := range errs {
+			log.Warn("sim agent subscription error", "agent_id", a.id, "error", err)
+		}
+	}()
+
+	var seq uint64
+	publish := func(msgType hcs.MessageType, taskID string, payload any) error {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("marshal %s payload: %w", msgType, err)
+		}
+		seq++
+		return publisher.Publish(ctx, cfg.StatusTopicID, hcs.Envelope{
+			Type:        msgType,
+			Sender:      a.id,
+			Recipient:   "coordinator",
+			TaskID:      taskID,
+			SequenceNum: seq,
+			Timestamp:   time.Now(),
+			Payload:     data,
+		})
+	}
+
+	// Publishes share the sequence counter, so heartbeats are sent from the
+	// assignment loop rather than a separate goroutine.
+	beat := func() {
+		if err := publish(hcs.MessageTypeHeartbeat, "", coordinator.HeartbeatPayload{
+			AgentID:   a.id,
+			Status:    coordinator.AgentStatusOnline,
+			TaskTypes: a.taskTypes,
+		}); err != nil {
+			log.Warn("sim agent failed to publish heartbeat", "agent_id", a.id, "error", err)
+		}
+	}
+	beat()
+	ticker := time.NewTicker(simHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		var env hcs.Envelope
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			beat()
+			continue
+		case msg, ok := <-assignments:
+			if !ok {
+				return
+			}
+			env = msg
+		}
+
+		if env.Type != hcs.MessageTypeTaskAssignment || env.Recipient != a.id {
+			continue
+		}
+		var task coordinator.TaskAssignmentPayload
+		if err := json.Unmarshal(env.Payload, &task); err != nil {
+			log.Warn("sim agent received malformed assignment", "agent_id", a.id, "error", err)
+			continue
+		}
+
+		start := time.Now()
+		for _, status := range []coordinator.TaskStatus{
+			coordinator.StatusInProgress,
+			coordinator.StatusReview,
+			coordinator.StatusComplete,
+		} {
+			if err := publish(hcs.MessageTypeStatusUpdate, task.TaskID, coordinator.StatusUpdatePayload{
+				TaskID:    task.TaskID,
+				AgentID:   a.id,
+				NewStatus: status,
+			}); err != nil {
+				log.Warn("sim agent failed to publish status", "agent_id", a.id, "task_id", task.TaskID, "error", err)
+			}
+		}
+
+		if err := publish(hcs.MessageTypeTaskResult, task.TaskID, coordinator.TaskResultPayload{
+			TaskID:     task.TaskID,
+			Status:     "completed",
+			Output:     fmt.Sprintf("simulated output for %s", task.TaskName),
+			DurationMs: time.Since(start).Milliseconds(),
+		}); err != nil {
+			log.Warn("sim agent failed to publish result", "agent_id", a.id, "task_id", task.TaskID, "error", err)
+			continue
+		}
+		log.Info("sim agent completed task", "agent_id", a.id, "task_id", task.TaskID)
+	}
+}
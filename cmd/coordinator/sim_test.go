@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/coordinator"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+)
+
+// TestSim_EveryTaskPaid runs the static integration plan against the
+// simulated network and agents, and expects every task, the DeFi trade
+// included, to be paid.
+func TestSim_EveryTaskPaid(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cfg, network, agents, err := setupSim(ctx, false)
+	if err != nil {
+		t.Fatalf("setupSim: %v", err)
+	}
+	ccfg := cfg.Coordinator
+	ccfg.MonitorPollInterval = 10 * time.Millisecond
+
+	router := hcs.NewRouter(network, hcs.RouterConfig{RouteBuffer: 100})
+	statusRoute := func(name string, types ...hcs.MessageType) hcs.MessageSubscriber {
+		return router.Route(ccfg.StatusTopicID, hcs.Route{Name: name, Types: types})
+	}
+
+	agentInfos := make([]coordinator.AgentInfo, 0, len(cfg.Agents))
+	for _, agent := range cfg.Agents {
+		agentInfos = append(agentInfos, agent.AgentInfo())
+	}
+	registry, err := coordinator.NewAgentRegistry(agentInfos)
+	if err != nil {
+		t.Fatalf("NewAgentRegistry: %v", err)
+	}
+
+	events := coordinator.NewEventBus()
+	assigner := coordinator.NewAssigner(network, ccfg.TaskTopicID, nil)
+	assigner.SetRegistry(registry)
+	assigner.SetEvents(events)
+	assigner.SetCREClient(simRiskRouter{})
+
+	outputGate := coordinator.NewOutputGate(nil)
+	monitor := coordinator.NewMonitor(statusRoute("monitor", hcs.MessageTypeStatusUpdate), ccfg.StatusTopicID, outputGate)
+	monitor.SetEvents(events)
+	assigner.SetMonitor(monitor, ccfg.MonitorPollInterval)
+
+	payment := coordinator.NewPayment(network, network, ccfg)
+	payment.SetEvents(events)
+	payment.SetBalances(network)
+
+	resultHandler := coordinator.NewResultHandler(coordinator.ResultHandlerConfig{
+		Subscriber:    statusRoute("results", hcs.MessageTypeTaskResult, hcs.MessageTypePnLReport),
+		Publisher:     network,
+		TopicID:       ccfg.StatusTopicID,
+		Payment:       payment,
+		Config:        ccfg,
+		Log:           log,
+		AgentAccounts: registry.Accounts(),
+		Assignments:   assigner,
+		OutputGate:    outputGate,
+		Monitor:       monitor,
+		Events:        events,
+	})
+	outputGate.SetResults(resultHandler)
+
+	plan := coordinator.IntegrationCyclePlan()
+	resultHandler.SetPlan(plan)
+	outputGate.SetPlan(plan)
+
+	go func() { _ = router.Start(ctx) }()
+	go func() { _ = monitor.Start(ctx) }()
+	go func() { _ = resultHandler.Start(ctx) }()
+	for _, agent := range agents {
+		go agent.run(ctx, network, ccfg, log)
+	}
+
+	assigned, err := assigner.AssignTasks(ctx, plan)
+	if err != nil {
+		t.Fatalf("AssignTasks: %v", err)
+	}
+	if len(assigned) != plan.TaskCount() {
+		t.Fatalf("assigned %v, want all %d tasks", assigned, plan.TaskCount())
+	}
+
+	for {
+		states := monitor.AllTaskStates()
+		paid := 0
+		for _, status := range states {
+			if status == coordinator.StatusPaid {
+				paid++
+			}
+		}
+		if paid == plan.TaskCount() {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("task states = %v, want every task paid", states)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
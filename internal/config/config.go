@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -13,9 +14,34 @@ import (
 type Env struct {
 	CoordinatorAccountID hiero.AccountID
 	CoordinatorKey       hiero.PrivateKey
-	Agent1AccountID      string
-	Agent2AccountID      string
+	Agents               []AgentConfig
 	Coordinator          coordinator.Config
+
+	// EscrowKey signs releases of escrowed payments from
+	// Coordinator.EscrowAccountID; nil when escrow is not configured.
+	EscrowKey *hiero.PrivateKey
+}
+
+// AgentConfig describes a worker agent the coordinator may assign tasks to.
+type AgentConfig struct {
+	ID        string   `json:"id"`
+	AccountID string   `json:"account_id"`
+	PublicKey string   `json:"public_key,omitempty"`
+	TaskTypes []string `json:"task_types,omitempty"`
+	Models    []string `json:"models,omitempty"`
+
+	// Key is the parsed PublicKey; nil when no key is configured.
+	Key *hiero.PublicKey `json:"-"`
+}
+
+// AgentInfo converts the configuration into a registry entry.
+func (a AgentConfig) AgentInfo() coordinator.AgentInfo {
+	return coordinator.AgentInfo{
+		ID:        a.ID,
+		AccountID: a.AccountID,
+		TaskTypes: a.TaskTypes,
+		Models:    a.Models,
+	}
 }
 
 // Load reads coordinator configuration from environment variables.
@@ -65,9 +91,106 @@ func Load() (*Env, error) {
 		return nil, fmt.Errorf("config: parse HTS_PAYMENT_TOKEN_ID: %w", err)
 	}
 
+	agents, err := loadAgents()
+	if err != nil {
+		return nil, err
+	}
+
+	escrowAcct, escrowKey, err := loadEscrow()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := coordinator.DefaultConfig()
+	cfg.TaskTopicID = taskTopic
+	cfg.StatusTopicID = statusTopic
+	cfg.PaymentTokenID = paymentToken
+	cfg.TreasuryAccountID = coordAcct
+	cfg.EscrowAccountID = escrowAcct
+
+	return &Env{
+		CoordinatorAccountID: coordAcct,
+		CoordinatorKey:       coordKey,
+		Agents:               agents,
+		Coordinator:          cfg,
+		EscrowKey:            escrowKey,
+	}, nil
+}
+
+// loadEscrow reads the optional escrow account from HTS_ESCROW_ACCOUNT_ID
+// and its key from HTS_ESCROW_PRIVATE_KEY, which is required with it.
+func loadEscrow() (hiero.AccountID, *hiero.PrivateKey, error) {
+	acctStr := os.Getenv("HTS_ESCROW_ACCOUNT_ID")
+	if acctStr == "" {
+		return hiero.AccountID{}, nil, nil
+	}
+	acct, err := hiero.AccountIDFromString(acctStr)
+	if err != nil {
+		return hiero.AccountID{}, nil, fmt.Errorf("config: parse HTS_ESCROW_ACCOUNT_ID: %w", err)
+	}
+
+	keyStr := os.Getenv("HTS_ESCROW_PRIVATE_KEY")
+	if keyStr == "" {
+		return hiero.AccountID{}, nil, fmt.Errorf("config: HTS_ESCROW_PRIVATE_KEY is required with HTS_ESCROW_ACCOUNT_ID")
+	}
+	key, err := hiero.PrivateKeyFromString(keyStr)
+	if err != nil {
+		return hiero.AccountID{}, nil, fmt.Errorf("config: parse HTS_ESCROW_PRIVATE_KEY: %w", err)
+	}
+	return acct, &key, nil
+}
+
+// loadAgents reads worker agents from the JSON file named by AGENTS_CONFIG.
+// Without it, the two legacy agents are built from the HEDERA_AGENT1_* and
+// HEDERA_AGENT2_* variables.
+func loadAgents() ([]AgentConfig, error) {
+	path := os.Getenv("AGENTS_CONFIG")
+	if path == "" {
+		return legacyAgents()
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: read AGENTS_CONFIG: %w", err)
+	}
+	var agents []AgentConfig
+	if err := json.Unmarshal(b, &agents); err != nil {
+		return nil, fmt.Errorf("config: parse AGENTS_CONFIG %s: %w", path, err)
+	}
+	if len(agents) == 0 {
+		return nil, fmt.Errorf("config: AGENTS_CONFIG %s defines no agents", path)
+	}
+
+	seen := make(map[string]bool, len(agents))
+	for i := range agents {
+		agent := &agents[i]
+		if agent.ID == "" {
+			return nil, fmt.Errorf("config: AGENTS_CONFIG agent %d: id is required", i)
+		}
+		if seen[agent.ID] {
+			return nil, fmt.Errorf("config: AGENTS_CONFIG agent %s: duplicate id", agent.ID)
+		}
+		seen[agent.ID] = true
+		if _, err := hiero.AccountIDFromString(agent.AccountID); err != nil {
+			return nil, fmt.Errorf("config: AGENTS_CONFIG agent %s: parse account_id: %w", agent.ID, err)
+		}
+		if agent.PublicKey != "" {
+			key, err := hiero.PublicKeyFromString(agent.PublicKey)
+			if err != nil {
+				return nil, fmt.Errorf("config: AGENTS_CONFIG agent %s: parse public_key: %w", agent.ID, err)
+			}
+			agent.Key = &key
+		}
+	}
+	return agents, nil
+}
+
+// legacyAgents builds the inference and DeFi agents from the per-agent
+// environment variables used before AGENTS_CONFIG existed.
+func legacyAgents() ([]AgentConfig, error) {
 	agent1 := os.Getenv("HEDERA_AGENT1_ACCOUNT_ID")
 	if agent1 == "" {
-		return nil, fmt.Errorf("config: HEDERA_AGENT1_ACCOUNT_ID is required")
+		return nil, fmt.Errorf("config: HEDERA_AGENT1_ACCOUNT_ID is required when AGENTS_CONFIG is not set")
 	}
 	if _, err := hiero.AccountIDFromString(agent1); err != nil {
 		return nil, fmt.Errorf("config: parse HEDERA_AGENT1_ACCOUNT_ID: %w", err)
@@ -75,23 +198,47 @@ func Load() (*Env, error) {
 
 	agent2 := os.Getenv("HEDERA_AGENT2_ACCOUNT_ID")
 	if agent2 == "" {
-		return nil, fmt.Errorf("config: HEDERA_AGENT2_ACCOUNT_ID is required")
+		return nil, fmt.Errorf("config: HEDERA_AGENT2_ACCOUNT_ID is required when AGENTS_CONFIG is not set")
 	}
 	if _, err := hiero.AccountIDFromString(agent2); err != nil {
 		return nil, fmt.Errorf("config: parse HEDERA_AGENT2_ACCOUNT_ID: %w", err)
 	}
 
-	cfg := coordinator.DefaultConfig()
-	cfg.TaskTopicID = taskTopic
-	cfg.StatusTopicID = statusTopic
-	cfg.PaymentTokenID = paymentToken
-	cfg.TreasuryAccountID = coordAcct
+	agent1Key, err := optionalPublicKey("HEDERA_AGENT1_PUBLIC_KEY")
+	if err != nil {
+		return nil, err
+	}
+	agent2Key, err := optionalPublicKey("HEDERA_AGENT2_PUBLIC_KEY")
+	if err != nil {
+		return nil, err
+	}
 
-	return &Env{
-		CoordinatorAccountID: coordAcct,
-		CoordinatorKey:       coordKey,
-		Agent1AccountID:      agent1,
-		Agent2AccountID:      agent2,
-		Coordinator:          cfg,
+	return []AgentConfig{
+		{
+			ID:        "inference-001",
+			AccountID: agent1,
+			TaskTypes: []string{coordinator.TaskTypeInference},
+			Key:       agent1Key,
+		},
+		{
+			ID:        "defi-001",
+			AccountID: agent2,
+			TaskTypes: []string{coordinator.TaskTypeTrade, "trade", "defi"},
+			Key:       agent2Key,
+		},
 	}, nil
 }
+
+// optionalPublicKey parses a public key from an environment variable,
+// returning nil if the variable is unset.
+func optionalPublicKey(name string) (*hiero.PublicKey, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil, nil
+	}
+	key, err := hiero.PublicKeyFromString(v)
+	if err != nil {
+		return nil, fmt.Errorf("config: parse %s: %w", name, err)
+	}
+	return &key, nil
+}
@@ -46,64 +46,295 @@ type Assigner struct {
 	publisher hcs.MessagePublisher
 	topicID   hiero.TopicID
 	agentIDs  []string
-	creClient *creclient.Client // optional CRE Risk Router client
+	registry  *AgentRegistry // optional; routes tasks by capability
+	creClient RiskEvaluator  // optional CRE Risk Router client
 	logger    *slog.Logger
 
+	// monitor gates dependent tasks on their dependencies' progress.
+	monitor      *Monitor
+	pollInterval time.Duration
+	store        StateStore
+	watchdog     *Watchdog        // optional; enforces task deadlines
+	retrier      *Retrier         // optional; decides whether failed tasks run again
+	gates        *SequenceGates   // enforces sequence gates; required for plans with gates
+	events       *EventBus        // optional; receives TaskAssigned events
+	escrow       AssignmentEscrow // optional; escrows payment before each assignment is published
+
 	mu          sync.RWMutex
 	assignments map[string]string // taskID -> agentID
 	seqNum      uint64
+	nextAgent   int // round-robin position in agentIDs
 }
 
 // NewAssigner creates a new task assigner.
 func NewAssigner(publisher hcs.MessagePublisher, topicID hiero.TopicID, agentIDs []string) *Assigner {
 	return &Assigner{
-		publisher:   publisher,
-		topicID:     topicID,
-		agentIDs:    agentIDs,
-		assignments: make(map[string]string),
-		logger:      slog.Default(),
+		publisher:    publisher,
+		topicID:      topicID,
+		agentIDs:     agentIDs,
+		assignments:  make(map[string]string),
+		logger:       slog.Default(),
+		pollInterval: DefaultConfig().MonitorPollInterval,
 	}
 }
 
 // SetCREClient configures the optional CRE Risk Router client for DeFi task risk checks.
-func (a *Assigner) SetCREClient(client *creclient.Client) {
+func (a *Assigner) SetCREClient(client RiskEvaluator) {
 	a.creClient = client
 }
 
-// AssignTasks publishes task assignments for all tasks in the plan.
+// SetRegistry configures the agent registry used to route tasks without an
+// explicit AssignTo by TaskType and ModelID. Without a registry, such tasks
+// are assigned round-robin across the assigner's agent IDs.
+func (a *Assigner) SetRegistry(registry *AgentRegistry) {
+	a.registry = registry
+}
+
+// SetMonitor configures the progress monitor used to release dependent tasks.
+// Tasks are dispatched only once every dependency reaches StatusComplete or
+// StatusPaid; pollInterval controls how often waiting tasks are re-checked.
+func (a *Assigner) SetMonitor(monitor *Monitor, pollInterval time.Duration) {
+	a.monitor = monitor
+	if pollInterval > 0 {
+		a.pollInterval = pollInterval
+	}
+}
+
+// SetStore configures a state store that every assignment is written to.
+func (a *Assigner) SetStore(store StateStore) {
+	a.store = store
+}
+
+// SetWatchdog configures the watchdog that leases assigned tasks to their
+// agents. Tasks with a timeout in the plan are tracked from assignment on.
+func (a *Assigner) SetWatchdog(watchdog *Watchdog) {
+	a.watchdog = watchdog
+}
+
+// SetRetrier configures the retrier that failed tasks may be re-queued by.
+// With it set, a failed dependency holds its dependents until the retrier or
+// watchdog gives up on it, instead of blocking them at once.
+func (a *Assigner) SetRetrier(retrier *Retrier) {
+	a.retrier = retrier
+}
+
+// SetGates configures the gate keeper that holds tasks depending on a
+// sequence gate, or on a task in another gated sequence, until the gates pass.
+func (a *Assigner) SetGates(gates *SequenceGates) {
+	a.gates = gates
+}
+
+// SetEscrow configures the escrow that commits each task's payment before
+// its assignment is published, so the escrow notice reaches the agent no
+// later than the assignment.
+func (a *Assigner) SetEscrow(escrow AssignmentEscrow) {
+	a.escrow = escrow
+}
+
+// SetEvents configures the event bus that assignments are published to.
+func (a *Assigner) SetEvents(events *EventBus) {
+	a.events = events
+}
+
+// AssignTasks publishes task assignments for the plan in dependency order.
+// Tasks without pending dependencies are dispatched immediately; the rest are
+// held until the monitor reports their dependencies complete. Tasks whose
+// dependencies failed or were denied are never dispatched. AssignTasks returns
+// once every task has been dispatched or blocked.
 func (a *Assigner) AssignTasks(ctx context.Context, plan Plan) ([]string, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, fmt.Errorf("assign tasks for plan %s: %w", plan.FestivalID, err)
 	}
 
+	graph := newTaskGraph(plan)
+	if a.monitor == nil && graph.hasDependencies() {
+		return nil, fmt.Errorf("assign tasks for plan %s: plan has dependencies but no progress monitor is configured", plan.FestivalID)
+	}
+	if plan.hasGates() {
+		if a.gates == nil {
+			return nil, fmt.Errorf("assign tasks for plan %s: plan has gates but no gate keeper is configured", plan.FestivalID)
+		}
+		a.gates.SetPlan(plan)
+	}
+	for _, id := range graph.order {
+		// Tasks recovered from a previous run keep their state and are not
+		// dispatched again.
+		if agentID := a.Assignment(id); agentID != "" {
+			a.logger.Info("task already assigned, not dispatching again",
+				"plan", plan.FestivalID, "task_id", id, "agent_id", agentID)
+			graph.mark(id, dispatchReleased)
+			a.rearmLease(plan, graph.tasks[id], agentID)
+			continue
+		}
+		if a.monitor != nil {
+			if _, err := a.monitor.TaskState(id); err != nil {
+				a.monitor.InitTask(id)
+			}
+		}
+	}
+
 	var assignedIDs []string
-	agentIdx := 0
 
-	for _, seq := range plan.Sequences {
-		for _, task := range seq.Tasks {
+	for graph.remaining() > 0 {
+		ready, blocked := graph.release(func(taskID, dep string) dependencyState {
+			return a.dependencyState(ctx, graph, taskID, dep)
+		})
+
+		for _, id := range blocked {
+			a.logger.Warn("task blocked by failed dependency, not dispatching",
+				"plan", plan.FestivalID, "task_id", id)
+		}
+
+		for _, task := range ready {
 			if err := ctx.Err(); err != nil {
 				return assignedIDs, fmt.Errorf("assign tasks: cancelled during assignment: %w", err)
 			}
 
-			agentID := task.AssignTo
-			if agentID == "" && len(a.agentIDs) > 0 {
-				agentID = a.agentIDs[agentIdx%len(a.agentIDs)]
-				agentIdx++
+			agentID, err := a.selectAgent(task)
+			if err != nil {
+				a.logger.Warn("no agent can run task, not dispatching",
+					"plan", plan.FestivalID, "task_id", task.ID, "error", err)
+				graph.mark(task.ID, dispatchSkipped)
+				continue
 			}
 
 			assigned, err := a.assignPlanTask(ctx, task, agentID)
 			if err != nil {
 				return assignedIDs, fmt.Errorf("assign tasks: task %s: %w", task.ID, err)
 			}
-			if assigned {
-				assignedIDs = append(assignedIDs, task.ID)
+			if !assigned {
+				graph.mark(task.ID, dispatchSkipped)
+				continue
+			}
+			graph.mark(task.ID, dispatchReleased)
+			assignedIDs = append(assignedIDs, task.ID)
+			if a.watchdog != nil {
+				a.watchdog.Track(task, agentID, plan.TaskTimeout(task))
 			}
 		}
+
+		if len(ready) > 0 || len(blocked) > 0 || graph.remaining() == 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return assignedIDs, fmt.Errorf("assign tasks: cancelled while waiting on dependencies: %w", ctx.Err())
+		case <-time.After(a.pollInterval):
+		}
 	}
 
 	return assignedIDs, nil
 }
 
+// rearmLease leases a recovered task that its agent is still working on, so
+// a task whose agent went silent across a restart still expires. The lease
+// starts afresh from now.
+func (a *Assigner) rearmLease(plan Plan, task PlanTask, agentID string) {
+	if a.watchdog == nil || a.monitor == nil {
+		return
+	}
+	status, err := a.monitor.TaskState(task.ID)
+	if err != nil {
+		return
+	}
+	switch status {
+	case StatusAssigned, StatusInProgress:
+		a.watchdog.Track(task, agentID, plan.TaskTimeout(task))
+	}
+}
+
+// selectAgent picks the agent for a task: its AssignTo if set, else the
+// registry's choice, else the next configured agent round-robin. Agents in
+// exclude are never picked.
+func (a *Assigner) selectAgent(task PlanTask, exclude ...string) (string, error) {
+	if task.AssignTo != "" {
+		if containsString(exclude, task.AssignTo) {
+			return "", fmt.Errorf("select agent for task %s: pinned agent %s excluded: %w",
+				task.ID, task.AssignTo, ErrNoCapableAgent)
+		}
+		return task.AssignTo, nil
+	}
+	if a.registry != nil {
+		return a.registry.Select(task, exclude...)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.agentIDs) == 0 && len(exclude) == 0 {
+		return "", nil
+	}
+	for range a.agentIDs {
+		agentID := a.agentIDs[a.nextAgent%len(a.agentIDs)]
+		a.nextAgent++
+		if !containsString(exclude, agentID) {
+			return agentID, nil
+		}
+	}
+	return "", fmt.Errorf("select agent for task %s: %w", task.ID, ErrNoCapableAgent)
+}
+
+// dependencyState reports whether dependency dep allows taskID to run. A dep
+// naming a sequence gate waits on the gate's decision; a task in another
+// sequence additionally waits on that sequence's gates. Dependencies outside
+// the plan are treated as already satisfied.
+func (a *Assigner) dependencyState(ctx context.Context, graph *taskGraph, taskID, dep string) dependencyState {
+	if a.gates != nil {
+		if state, ok := a.gates.gateState(ctx, dep); ok {
+			return state
+		}
+	}
+	if !graph.contains(dep) {
+		return dependencySatisfied
+	}
+
+	state := a.taskDependencyState(graph, dep)
+	if state == dependencySatisfied && a.gates != nil {
+		return a.gates.sequenceState(ctx, taskID, dep)
+	}
+	return state
+}
+
+// taskDependencyState reports the dispatch and monitor state of a plan task.
+func (a *Assigner) taskDependencyState(graph *taskGraph, taskID string) dependencyState {
+	switch graph.state[taskID] {
+	case dispatchSkipped, dispatchBlocked:
+		return dependencyFailed
+	case dispatchWaiting:
+		return dependencyWaiting
+	}
+
+	status, err := a.monitor.TaskState(taskID)
+	if err != nil {
+		return dependencyWaiting
+	}
+	switch status {
+	case StatusComplete, StatusPaid:
+		return dependencySatisfied
+	case StatusFailed:
+		if a.failedForGood(taskID) {
+			return dependencyFailed
+		}
+		return dependencyWaiting
+	default:
+		return dependencyWaiting
+	}
+}
+
+// failedForGood reports whether a failed task will not run again. Without a
+// retrier nothing re-queues a failed task; with one, the task may be failed
+// only until its result reaches the retrier, so it has failed for good once
+// the retrier or the watchdog has given up on it.
+func (a *Assigner) failedForGood(taskID string) bool {
+	if a.retrier == nil {
+		return true
+	}
+	if a.watchdog != nil && a.watchdog.Abandoned(taskID) {
+		return true
+	}
+	return a.retrier.Exhausted(taskID)
+}
+
 // AssignTask assigns a single task to a specific agent via HCS.
 func (a *Assigner) AssignTask(ctx context.Context, taskID string, agentID string) error {
 	_, err := a.assignPlanTask(ctx, PlanTask{ID: taskID}, agentID)
@@ -201,6 +432,14 @@ func (a *Assigner) assignPlanTask(ctx context.Context, task PlanTask, agentID st
 		Payload:     payloadBytes,
 	}
 
+	if a.escrow != nil {
+		if err := a.escrow.EscrowAssignment(ctx, task.ID, agentID); err != nil {
+			// The task is still assigned; it is paid from the treasury.
+			a.logger.Error("payment escrow failed, assigning without escrow",
+				"task_id", task.ID, "agent_id", agentID, "error", err)
+		}
+	}
+
 	if err := a.publisher.Publish(ctx, a.topicID, env); err != nil {
 		return false, fmt.Errorf("assign task %s to %s: publish: %w", task.ID, agentID, err)
 	}
@@ -209,6 +448,17 @@ func (a *Assigner) assignPlanTask(ctx context.Context, task PlanTask, agentID st
 	a.assignments[task.ID] = agentID
 	a.mu.Unlock()
 
+	if a.store != nil {
+		if err := a.store.SaveAssignment(task.ID, agentID); err != nil {
+			a.logger.Warn("failed to persist assignment", "task_id", task.ID, "agent_id", agentID, "error", err)
+		}
+	}
+
+	if a.monitor != nil {
+		a.monitor.MarkAssigned(task.ID)
+	}
+	a.events.Publish(TaskAssigned{TaskID: task.ID, AgentID: agentID})
+
 	return true, nil
 }
 
@@ -219,6 +469,15 @@ func (a *Assigner) Assignment(taskID string) string {
 	return a.assignments[taskID]
 }
 
+// restore replaces tracked assignments with those recovered from a store.
+func (a *Assigner) restore(assignments map[string]string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for taskID, agentID := range assignments {
+		a.assignments[taskID] = agentID
+	}
+}
+
 // AssignmentCount returns the number of tasks that have been assigned.
 func (a *Assigner) AssignmentCount() int {
 	a.mu.RLock()
@@ -248,9 +507,36 @@ func (a *Assigner) publishRiskEvent(ctx context.Context, taskID, agentID string,
 	}
 }
 
+// publishRevocation tells an agent it no longer holds a task.
+func (a *Assigner) publishRevocation(ctx context.Context, revocation TaskRevokedPayload) error {
+	payload, err := json.Marshal(revocation)
+	if err != nil {
+		return fmt.Errorf("revoke task %s from %s: marshal payload: %w", revocation.TaskID, revocation.AgentID, err)
+	}
+
+	a.mu.Lock()
+	a.seqNum++
+	seqNum := a.seqNum
+	a.mu.Unlock()
+
+	env := hcs.Envelope{
+		Type:        hcs.MessageTypeTaskRevoked,
+		Sender:      "coordinator",
+		Recipient:   revocation.AgentID,
+		TaskID:      revocation.TaskID,
+		SequenceNum: seqNum,
+		Timestamp:   time.Now(),
+		Payload:     payload,
+	}
+	if err := a.publisher.Publish(ctx, a.topicID, env); err != nil {
+		return fmt.Errorf("revoke task %s from %s: publish: %w", revocation.TaskID, revocation.AgentID, err)
+	}
+	return nil
+}
+
 // isDeFiTask returns true if the task involves DeFi trade execution.
 func isDeFiTask(task PlanTask) bool {
-	return task.TaskType == "defi" || task.TaskType == "trade" || task.TaskType == "execute_trade"
+	return task.TaskType == "defi" || task.TaskType == "trade" || task.TaskType == TaskTypeTrade
 }
 
 func classifyCREError(err error) string {
@@ -264,5 +550,8 @@ func classifyCREError(err error) string {
 	}
 }
 
-// Compile-time interface compliance check.
-var _ TaskAssigner = (*Assigner)(nil)
+// Compile-time interface compliance checks.
+var (
+	_ TaskAssigner     = (*Assigner)(nil)
+	_ AssignmentLookup = (*Assigner)(nil)
+)
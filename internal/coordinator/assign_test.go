@@ -3,6 +3,7 @@ package coordinator
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -122,6 +123,141 @@ func TestPlan_TaskByID(t *testing.T) {
 	}
 }
 
+func TestPlan_Validate(t *testing.T) {
+	agents := testRegistry(t)
+
+	tests := []struct {
+		name   string
+		plan   Plan
+		agents *AgentRegistry
+		kinds  []PlanIssueKind
+	}{
+		{
+			name:   "integration cycle plan is valid",
+			plan:   IntegrationCyclePlan(),
+			agents: agents,
+		},
+		{
+			name:  "unpinned defi task without registry",
+			plan:  IntegrationCyclePlan(),
+			kinds: []PlanIssueKind{PlanIssueUnassignedDeFi},
+		},
+		{
+			name: "routing problems against registry",
+			plan: Plan{Sequences: []PlanSequence{
+				{ID: "seq-1", Tasks: []PlanTask{
+					{ID: "t1", TaskType: TaskTypeInference, AssignTo: "ghost-001", PaymentAmount: 1},
+					{ID: "t2", TaskType: "audit", PaymentAmount: 1},
+				}},
+			}},
+			agents: agents,
+			kinds:  []PlanIssueKind{PlanIssueUnknownAgent, PlanIssueNoCapableAgent},
+		},
+		{
+			name: "duplicate id across sequences",
+			plan: Plan{Sequences: []PlanSequence{
+				{ID: "seq-1", Tasks: []PlanTask{{ID: "t1", TaskType: TaskTypeInference, PaymentAmount: 1}}},
+				{ID: "seq-2", Tasks: []PlanTask{{ID: "t1", TaskType: TaskTypeInference, PaymentAmount: 1}}},
+			}},
+			kinds: []PlanIssueKind{PlanIssueDuplicateID},
+		},
+		{
+			name: "dangling dependency",
+			plan: Plan{Sequences: []PlanSequence{
+				{ID: "seq-1", Tasks: []PlanTask{{ID: "t1", TaskType: TaskTypeInference, PaymentAmount: 1, Dependencies: []string{"gone"}}}},
+			}},
+			kinds: []PlanIssueKind{PlanIssueUnknownDependency},
+		},
+		{
+			name: "dependency cycle",
+			plan: Plan{Sequences: []PlanSequence{
+				{ID: "seq-1", Tasks: []PlanTask{
+					{ID: "t1", TaskType: TaskTypeInference, PaymentAmount: 1, Dependencies: []string{"t3"}},
+					{ID: "t2", TaskType: TaskTypeInference, PaymentAmount: 1, Dependencies: []string{"t1"}},
+					{ID: "t3", TaskType: TaskTypeInference, PaymentAmount: 1, Dependencies: []string{"t2"}},
+				}},
+			}},
+			kinds: []PlanIssueKind{PlanIssueCycle},
+		},
+		{
+			name: "gate problems",
+			plan: Plan{Sequences: []PlanSequence{
+				{ID: "seq-1", Tasks: []PlanTask{{ID: "t1", TaskType: TaskTypeInference, PaymentAmount: 1}}, Gates: []PlanGate{
+					{ID: "t1", Kind: GateKindTesting},
+					{ID: "g1", Kind: GateKindReview, Dependencies: []string{"gone"}},
+				}},
+			}},
+			kinds: []PlanIssueKind{PlanIssueDuplicateID, PlanIssueUnknownDependency},
+		},
+		{
+			name: "task depends on the gate covering it",
+			plan: Plan{Sequences: []PlanSequence{
+				{ID: "seq-1", Tasks: []PlanTask{{ID: "t1", TaskType: TaskTypeInference, PaymentAmount: 1, Dependencies: []string{"g1"}}}, Gates: []PlanGate{
+					{ID: "g1", Kind: GateKindReview},
+				}},
+			}},
+			kinds: []PlanIssueKind{PlanIssueCycle},
+		},
+		{
+			name: "negative timeouts",
+			plan: Plan{
+				DefaultTimeoutSeconds: -1,
+				Sequences: []PlanSequence{
+					{ID: "seq-1", Tasks: []PlanTask{
+						{ID: "t1", TaskType: TaskTypeInference, PaymentAmount: 1, TimeoutSeconds: -5},
+					}},
+				},
+			},
+			kinds: []PlanIssueKind{PlanIssueInvalidTimeout, PlanIssueInvalidTimeout},
+		},
+		{
+			name: "every problem reported",
+			plan: Plan{Sequences: []PlanSequence{
+				{ID: "seq-1", Tasks: []PlanTask{
+					{ID: "t1", TaskType: "execute_trade", PaymentAmount: 0},
+					{Name: "no id", PaymentAmount: 1},
+				}},
+			}},
+			kinds: []PlanIssueKind{PlanIssueInvalidPayment, PlanIssueUnassignedDeFi, PlanIssueMissingID},
+		},
+		{
+			name: "task without a task type",
+			plan: Plan{Sequences: []PlanSequence{
+				{ID: "seq-1", Tasks: []PlanTask{{ID: "t1", PaymentAmount: 1}}},
+			}},
+			kinds: []PlanIssueKind{PlanIssueMissingTaskType},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.plan.Validate()
+			if tt.agents != nil {
+				err = tt.plan.ValidateAgents(tt.agents)
+			}
+			if len(tt.kinds) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var verr *PlanValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() error = %v, want *PlanValidationError", err)
+			}
+			if len(verr.Issues) != len(tt.kinds) {
+				t.Fatalf("issues = %v, want %d", verr.Issues, len(tt.kinds))
+			}
+			for _, kind := range tt.kinds {
+				if !verr.HasKind(kind) {
+					t.Errorf("missing issue kind %s in %v", kind, verr.Issues)
+				}
+			}
+		})
+	}
+}
+
 func TestCanTransition(t *testing.T) {
 	tests := []struct {
 		from, to TaskStatus
@@ -466,3 +602,216 @@ func TestAssignTasks_ApprovedIncludesCREDecisionPayload(t *testing.T) {
 		t.Fatalf("decision_timestamp = %d, want 1700000000", payload.CREDecision.DecisionTimestamp)
 	}
 }
+
+// advanceTask drives a task through the monitor's status updates up to status.
+func advanceTask(t *testing.T, m *Monitor, taskID string, statuses ...TaskStatus) {
+	t.Helper()
+	for _, status := range statuses {
+		reportStatus(t, m, taskID, status)
+		m.gateWG.Wait()
+	}
+}
+
+// reportStatus delivers one status update without waiting for the quality
+// gate evaluations it starts.
+func reportStatus(t *testing.T, m *Monitor, taskID string, status TaskStatus) {
+	t.Helper()
+	payload, err := json.Marshal(StatusUpdatePayload{TaskID: taskID, NewStatus: status})
+	if err != nil {
+		t.Fatalf("marshal status update: %v", err)
+	}
+	m.processMessage(context.Background(), hcs.Envelope{
+		Type:    hcs.MessageTypeStatusUpdate,
+		TaskID:  taskID,
+		Payload: payload,
+	})
+}
+
+func waitForAssignment(t *testing.T, a *Assigner, taskID string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for a.Assignment(taskID) == "" {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s to be assigned", taskID)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestAssignTasks_HoldsDependentsUntilDependenciesComplete(t *testing.T) {
+	pub := &mockPublisher{}
+	m := NewMonitor(nil, hiero.TopicID{}, nil)
+	a := NewAssigner(pub, hiero.TopicID{Topic: 1}, []string{"agent-1"})
+	a.SetMonitor(m, 5*time.Millisecond)
+
+	plan := Plan{
+		FestivalID: "test-fest",
+		Sequences: []PlanSequence{
+			{
+				ID: "seq-1",
+				Tasks: []PlanTask{
+					{ID: "task-b", Name: "second", Dependencies: []string{"task-a"}},
+					{ID: "task-a", Name: "first"},
+				},
+			},
+		},
+	}
+
+	type result struct {
+		ids []string
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		ids, err := a.AssignTasks(context.Background(), plan)
+		done <- result{ids, err}
+	}()
+
+	waitForAssignment(t, a, "task-a")
+	if status, _ := m.TaskState("task-a"); status != StatusAssigned {
+		t.Fatalf("task-a state = %s, want assigned", status)
+	}
+
+	advanceTask(t, m, "task-a", StatusInProgress, StatusReview)
+	time.Sleep(25 * time.Millisecond)
+	if got := a.Assignment("task-b"); got != "" {
+		t.Fatalf("task-b dispatched before task-a completed, assigned to %q", got)
+	}
+
+	advanceTask(t, m, "task-a", StatusComplete)
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			t.Fatalf("AssignTasks returned error: %v", res.err)
+		}
+		if len(res.ids) != 2 || res.ids[0] != "task-a" || res.ids[1] != "task-b" {
+			t.Fatalf("assigned IDs = %v, want [task-a task-b]", res.ids)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("AssignTasks did not return after dependencies completed")
+	}
+}
+
+func TestAssignTasks_BlocksDependentsOfDeniedTask(t *testing.T) {
+	pub := &mockPublisher{}
+	m := NewMonitor(nil, hiero.TopicID{}, nil)
+	a := NewAssigner(pub, hiero.TopicID{Topic: 1}, []string{"agent-1"})
+	a.SetMonitor(m, 5*time.Millisecond)
+
+	plan := Plan{
+		FestivalID: "test-fest",
+		Sequences: []PlanSequence{
+			{
+				ID: "seq-1",
+				Tasks: []PlanTask{
+					{ID: "task-defi-1", TaskType: "execute_trade", Name: "trade without cre"},
+					{ID: "task-report", Name: "report", Dependencies: []string{"task-defi-1"}},
+					{ID: "task-summary", Name: "summary", Dependencies: []string{"task-report"}},
+				},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	assignedIDs, err := a.AssignTasks(ctx, plan)
+	if err != nil {
+		t.Fatalf("AssignTasks returned error: %v", err)
+	}
+	if len(assignedIDs) != 0 {
+		t.Fatalf("expected no assigned IDs, got %v", assignedIDs)
+	}
+	if a.AssignmentCount() != 0 {
+		t.Fatalf("expected AssignmentCount() == 0, got %d", a.AssignmentCount())
+	}
+}
+
+func TestAssignTasks_DependenciesRequireMonitor(t *testing.T) {
+	a := NewAssigner(&mockPublisher{}, hiero.TopicID{Topic: 1}, []string{"agent-1"})
+
+	plan := IntegrationCyclePlan()
+	if _, err := a.AssignTasks(context.Background(), plan); err == nil {
+		t.Fatal("expected error for dependent plan without monitor")
+	}
+}
+
+func TestAssignTasks_ExternalDependencySatisfied(t *testing.T) {
+	pub := &mockPublisher{}
+	a := NewAssigner(pub, hiero.TopicID{Topic: 1}, []string{"agent-1"})
+
+	plan := Plan{
+		FestivalID: "test-fest",
+		Sequences: []PlanSequence{
+			{ID: "seq-1", Tasks: []PlanTask{{ID: "task-a", Dependencies: []string{"already-done"}}}},
+		},
+	}
+
+	assignedIDs, err := a.AssignTasks(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("AssignTasks returned error: %v", err)
+	}
+	if len(assignedIDs) != 1 || assignedIDs[0] != "task-a" {
+		t.Fatalf("assigned IDs = %v, want [task-a]", assignedIDs)
+	}
+}
+
+func TestAssignTasks_FailedDependencyHeldUntilRetriesExhausted(t *testing.T) {
+	pub := &mockPublisher{}
+	m := NewMonitor(nil, hiero.TopicID{}, nil)
+	a := NewAssigner(pub, hiero.TopicID{Topic: 1}, []string{"agent-1"})
+	a.SetMonitor(m, 5*time.Millisecond)
+	retrier := NewRetrier(a, m, RetryPolicy{MaxAttempts: 1})
+	a.SetRetrier(retrier)
+
+	plan := Plan{
+		FestivalID: "test-fest",
+		Sequences: []PlanSequence{
+			{
+				ID: "seq-1",
+				Tasks: []PlanTask{
+					{ID: "task-a", Name: "first"},
+					{ID: "task-b", Name: "second", Dependencies: []string{"task-a"}},
+				},
+			},
+		},
+	}
+	retrier.SetPlan(plan)
+
+	type result struct {
+		ids []string
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		ids, err := a.AssignTasks(context.Background(), plan)
+		done <- result{ids, err}
+	}()
+
+	// The agent reports the failure before its result reaches the retrier,
+	// which may still re-queue the task.
+	waitForAssignment(t, a, "task-a")
+	advanceTask(t, m, "task-a", StatusInProgress, StatusFailed)
+	select {
+	case res := <-done:
+		t.Fatalf("AssignTasks returned %v before the retrier decided task-a", res.ids)
+	case <-time.After(25 * time.Millisecond):
+	}
+
+	retrier.HandleResult(context.Background(), "agent-1", TaskResultPayload{
+		TaskID: "task-a", Status: "failed", Error: "invalid_input: bad prompt",
+	})
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			t.Fatalf("AssignTasks returned error: %v", res.err)
+		}
+		if len(res.ids) != 1 || res.ids[0] != "task-a" {
+			t.Fatalf("assigned IDs = %v, want [task-a] with task-b blocked", res.ids)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("AssignTasks did not return once task-a's retries were exhausted")
+	}
+}
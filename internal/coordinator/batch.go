@@ -0,0 +1,179 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+)
+
+// paymentBatchMemoPrefix starts the memo of a batched payment transfer. One
+// memo cannot name every task a batch pays, so it gives the task count and
+// the ledger maps the shared transaction ID back to each task.
+const paymentBatchMemoPrefix = "payment:batch:"
+
+// queuedPayment is a checked payment waiting for its batch to settle.
+type queuedPayment struct {
+	record  PaymentRecord
+	account hiero.AccountID
+}
+
+// SetBatchTransfer configures the multi-recipient transfer used to settle
+// payments in batches. Batching is enabled when Config.PaymentBatchSize is
+// greater than one: payments queue until the batch is full or the oldest
+// has waited Config.PaymentBatchWindow, then settle together.
+func (p *Payment) SetBatchTransfer(batcher hts.BatchTokenTransfer) {
+	p.batcher = batcher
+}
+
+func (p *Payment) batching() bool {
+	return p.batcher != nil && p.config.PaymentBatchSize > 1
+}
+
+// enqueue prechecks a payment and adds it to the open batch, settling the
+// batch when it is full. The treasury must cover the payment on top of every
+// payment already queued, since none of them has been debited yet. The first
+// payment of a batch starts its window; the window flush uses that payment's
+// context.
+func (p *Payment) enqueue(ctx context.Context, payment queuedPayment) error {
+	p.batchMu.Lock()
+	var queuedTotal int64
+	for _, q := range p.batch {
+		queuedTotal += q.record.Amount
+	}
+	if err := p.precheck(ctx, payment.account, queuedTotal+payment.record.Amount); err != nil {
+		p.batchMu.Unlock()
+		return err
+	}
+	p.batch = append(p.batch, payment)
+	if len(p.batch) < p.config.PaymentBatchSize {
+		if len(p.batch) == 1 {
+			p.batchTimer = time.AfterFunc(p.config.PaymentBatchWindow, func() { p.Flush(ctx) })
+		}
+		p.batchMu.Unlock()
+		return nil
+	}
+	queued := p.takeBatchLocked()
+	p.batchMu.Unlock()
+
+	p.settleBatch(ctx, queued)
+	return nil
+}
+
+// Flush settles every queued payment now, without waiting for the batch to
+// fill. Call it at shutdown so queued payments are not left pending.
+func (p *Payment) Flush(ctx context.Context) {
+	p.batchMu.Lock()
+	queued := p.takeBatchLocked()
+	p.batchMu.Unlock()
+
+	p.settleBatch(ctx, queued)
+}
+
+// takeBatchLocked empties the open batch. p.batchMu must be held.
+func (p *Payment) takeBatchLocked() []queuedPayment {
+	if p.batchTimer != nil {
+		p.batchTimer.Stop()
+		p.batchTimer = nil
+	}
+	queued := p.batch
+	p.batch = nil
+	return queued
+}
+
+// settleBatch pays queued payments in as few transfers as the network's
+// per-transaction transfer limit allows. Payments to the same recipient
+// share one credit, so each transfer pays at most MaxTokenTransfers-1
+// distinct recipients.
+func (p *Payment) settleBatch(ctx context.Context, queued []queuedPayment) {
+	var chunk []queuedPayment
+	recipients := make(map[hiero.AccountID]bool)
+	for _, payment := range queued {
+		if !recipients[payment.account] && len(recipients) == hts.MaxTokenTransfers-1 {
+			p.transferChunk(ctx, chunk)
+			chunk = nil
+			clear(recipients)
+		}
+		chunk = append(chunk, payment)
+		recipients[payment.account] = true
+	}
+	if len(chunk) > 0 {
+		p.transferChunk(ctx, chunk)
+	}
+}
+
+// transferChunk pays a chunk of queued payments in one transfer and settles
+// or fails each of them with it. A chunk rejected for a permanent reason,
+// such as one recipient not being associated with the token, never reached
+// the ledger, so it is split by recipient and each part is paid on its own:
+// only the payments to the recipient at fault fail permanently.
+func (p *Payment) transferChunk(ctx context.Context, chunk []queuedPayment) {
+	memo := fmt.Sprintf("%s%d", paymentBatchMemoPrefix, len(chunk))
+	req := hts.BatchTransferRequest{
+		TokenID:       p.config.PaymentTokenID,
+		FromAccountID: p.config.TreasuryAccountID,
+		Memo:          memo,
+	}
+	credit := make(map[hiero.AccountID]int)
+	for _, payment := range chunk {
+		i, ok := credit[payment.account]
+		if !ok {
+			i = len(req.Credits)
+			credit[payment.account] = i
+			req.Credits = append(req.Credits, hts.Credit{ToAccountID: payment.account})
+		}
+		req.Credits[i].Amount += payment.record.Amount
+	}
+
+	receipt, err := p.batcher.TransferBatch(ctx, req)
+	if err != nil && len(req.Credits) > 1 && permanentPaymentError(err) {
+		p.logger.Warn("batch payment rejected, paying recipients separately",
+			"tasks", len(chunk), "recipients", len(req.Credits), "error", err)
+		for _, part := range splitByRecipient(chunk) {
+			p.transferChunk(ctx, part)
+		}
+		return
+	}
+	if err != nil {
+		p.logger.Error("batch payment failed", "tasks", len(chunk), "total", req.Total(), "error", err)
+		for _, payment := range chunk {
+			payment.record.Memo = memo
+			if receipt != nil {
+				// A batch can reach consensus despite the error; the shared
+				// transaction ID lets a retry find each task's credit.
+				payment.record.TransactionID = receipt.TransactionID.String()
+			}
+			p.failPayment(payment.record, fmt.Errorf("batch transfer: %w", err))
+		}
+		return
+	}
+
+	txID := receipt.TransactionID.String()
+	p.logger.Info("batch payment settled", "tasks", len(chunk), "total", req.Total(), "transaction_id", txID)
+	for _, payment := range chunk {
+		payment.record.Memo = memo
+		if err := p.settle(ctx, payment.record, txID, receipt.Status); err != nil {
+			p.logger.Warn("failed to publish settlement", "task_id", payment.record.TaskID, "error", err)
+		}
+	}
+}
+
+// splitByRecipient groups a chunk's payments by recipient, in the order each
+// recipient first appears.
+func splitByRecipient(chunk []queuedPayment) [][]queuedPayment {
+	index := make(map[hiero.AccountID]int)
+	var parts [][]queuedPayment
+	for _, payment := range chunk {
+		i, ok := index[payment.account]
+		if !ok {
+			i = len(parts)
+			index[payment.account] = i
+			parts = append(parts, nil)
+		}
+		parts[i] = append(parts[i], payment)
+	}
+	return parts
+}
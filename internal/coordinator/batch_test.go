@@ -0,0 +1,250 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/sim"
+)
+
+func TestPayment_BatchSettlesWhenFull(t *testing.T) {
+	ctx := context.Background()
+	p, network, pub, agents := newTestPayment(t, testPaymentOptions{agents: 2, batchSize: 3, batchWindow: time.Hour})
+
+	payees := []hiero.AccountID{agents[0], agents[1], agents[0]}
+	for i, agent := range payees[:2] {
+		if err := p.PayForTask(ctx, taskName(i), agent.String(), 100); err != nil {
+			t.Fatalf("PayForTask() error = %v", err)
+		}
+	}
+	if state, _ := p.PaymentStatus(taskName(0)); state != PaymentPending || len(network.Transfers()) != 0 {
+		t.Fatalf("before the batch fills: state = %s, transfers = %d; want pending, 0", state, len(network.Transfers()))
+	}
+	if err := p.PayForTask(ctx, taskName(0), agents[0].String(), 100); err == nil {
+		t.Error("queued payment paid twice")
+	}
+	if err := p.PayForTask(ctx, taskName(2), payees[2].String(), 50); err != nil {
+		t.Fatalf("PayForTask() error = %v", err)
+	}
+
+	// One transaction, one credit per distinct recipient.
+	transfers := network.Transfers()
+	if len(transfers) != 2 {
+		t.Fatalf("transfers = %+v, want one credit per recipient", transfers)
+	}
+	txID := transfers[0].TransactionID.String()
+	if transfers[0].Amount != 150 || transfers[1].TransactionID.String() != txID || transfers[0].Memo != "payment:batch:3" {
+		t.Errorf("transfers = %+v, want 150 and 100 in one transaction", transfers)
+	}
+
+	if len(pub.calls) != 3 {
+		t.Fatalf("settlements published = %d, want one per task", len(pub.calls))
+	}
+	for i, env := range pub.calls {
+		var payload PaymentSettledPayload
+		if err := json.Unmarshal(env.Payload, &payload); err != nil {
+			t.Fatalf("unmarshal settlement: %v", err)
+		}
+		if payload.TaskID != taskName(i) || payload.TransactionID != txID {
+			t.Errorf("settlement %d = %+v, want task %s in %s", i, payload, taskName(i), txID)
+		}
+		if state, _ := p.PaymentStatus(taskName(i)); state != PaymentProcessed {
+			t.Errorf("task %s payment = %s, want processed", taskName(i), state)
+		}
+	}
+
+	report, err := NewReconciler(p, network, p.config).Reconcile(ctx)
+	if err != nil || !report.OK() || report.Settled != 3 {
+		t.Errorf("Reconcile() = %+v, %v; want 3 settled and no discrepancies", report, err)
+	}
+}
+
+func TestPayment_BatchChecksTreasuryForQueuedTotal(t *testing.T) {
+	ctx := context.Background()
+	p, network, _, agents := newTestPayment(t, testPaymentOptions{agents: 2, batchSize: 3, batchWindow: time.Hour})
+	p.SetBalances(network)
+
+	// The treasury holds 10,000: enough for either payment, not both.
+	if err := p.PayForTask(ctx, "task-0", agents[0].String(), 6_000); err != nil {
+		t.Fatalf("PayForTask() error = %v", err)
+	}
+	err := p.PayForTask(ctx, "task-1", agents[1].String(), 6_000)
+	if !errors.Is(err, ErrInsufficientTreasury) {
+		t.Fatalf("PayForTask() over the queued total error = %v, want ErrInsufficientTreasury", err)
+	}
+	if state, _ := p.PaymentStatus("task-1"); state != PaymentFailed {
+		t.Errorf("task-1 state = %s, want failed", state)
+	}
+
+	p.Flush(ctx)
+	if state, _ := p.PaymentStatus("task-0"); state != PaymentProcessed {
+		t.Errorf("task-0 state = %s, want processed", state)
+	}
+}
+
+func TestPayment_BatchSettlesAfterWindow(t *testing.T) {
+	p, network, _, agents := newTestPayment(t, testPaymentOptions{agents: 1, batchSize: 10, batchWindow: 20 * time.Millisecond})
+
+	if err := p.PayForTask(context.Background(), "task-1", agents[0].String(), 100); err != nil {
+		t.Fatalf("PayForTask() error = %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	state, _ := p.PaymentStatus("task-1")
+	for state == PaymentPending && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+		state, _ = p.PaymentStatus("task-1")
+	}
+	if state != PaymentProcessed || len(network.Transfers()) != 1 {
+		t.Errorf("payment after window = %s with %d transfers, want processed in one", state, len(network.Transfers()))
+	}
+}
+
+func TestPayment_BatchSplitsAtTransferLimit(t *testing.T) {
+	recipients := hts.MaxTokenTransfers + 2
+	p, network, _, agents := newTestPayment(t, testPaymentOptions{agents: recipients, batchSize: 100, batchWindow: time.Hour})
+
+	for i, agent := range agents {
+		if err := p.PayForTask(context.Background(), taskName(i), agent.String(), 10); err != nil {
+			t.Fatalf("PayForTask() error = %v", err)
+		}
+	}
+	p.Flush(context.Background())
+
+	txs := make(map[string]int)
+	for _, tr := range network.Transfers() {
+		txs[tr.TransactionID.String()]++
+	}
+	if len(txs) != 2 {
+		t.Fatalf("transactions = %v, want two", txs)
+	}
+	for txID, credits := range txs {
+		if credits > hts.MaxTokenTransfers-1 {
+			t.Errorf("transaction %s credits %d accounts, limit %d", txID, credits, hts.MaxTokenTransfers-1)
+		}
+	}
+}
+
+func TestPayment_BatchFailureFailsEveryPayment(t *testing.T) {
+	p, network, pub, agents := newTestPayment(t, testPaymentOptions{agents: 1, batchSize: 2, batchWindow: time.Hour})
+	unassociated := network.CreateAccount()
+
+	_ = p.PayForTask(context.Background(), "task-1", agents[0].String(), 100)
+	_ = p.PayForTask(context.Background(), "task-2", unassociated.String(), 100)
+
+	for _, taskID := range []string{"task-1", "task-2"} {
+		if state, _ := p.PaymentStatus(taskID); state != PaymentFailed {
+			t.Errorf("task %s payment = %s, want failed", taskID, state)
+		}
+		if records := p.PaymentRecords(taskID); len(records) != 1 || records[0].Error == "" {
+			t.Errorf("task %s records = %+v, want one failed attempt", taskID, records)
+		}
+	}
+	if len(network.Transfers()) != 0 || len(pub.calls) != 0 {
+		t.Errorf("transfers = %d, settlements = %d; want none", len(network.Transfers()), len(pub.calls))
+	}
+}
+
+// rejectingBatch executes batches on the network, reporting a batch that
+// credits an unassociated account as the consensus node does: with a
+// TOKEN_NOT_ASSOCIATED_TO_ACCOUNT receipt status.
+type rejectingBatch struct {
+	network *sim.Network
+}
+
+func (b rejectingBatch) TransferBatch(ctx context.Context, req hts.BatchTransferRequest) (*hts.BatchTransferReceipt, error) {
+	receipt, err := b.network.TransferBatch(ctx, req)
+	if errors.Is(err, sim.ErrTokenNotAssociated) {
+		return nil, fmt.Errorf("receipt: %w", hiero.ErrHederaReceiptStatus{Status: hiero.StatusTokenNotAssociatedToAccount})
+	}
+	return receipt, err
+}
+
+func TestPayment_BatchRejectionFailsOnlyTheBadRecipient(t *testing.T) {
+	p, network, pub, agents := newTestPayment(t, testPaymentOptions{agents: 2, batchSize: 3, batchWindow: time.Hour})
+	p.SetBatchTransfer(rejectingBatch{network: network})
+	unassociated := network.CreateAccount()
+
+	_ = p.PayForTask(context.Background(), "task-1", agents[0].String(), 100)
+	_ = p.PayForTask(context.Background(), "task-2", unassociated.String(), 100)
+	_ = p.PayForTask(context.Background(), "task-3", agents[1].String(), 50)
+
+	for _, taskID := range []string{"task-1", "task-3"} {
+		if state, _ := p.PaymentStatus(taskID); state != PaymentProcessed {
+			t.Errorf("task %s payment = %s, want processed", taskID, state)
+		}
+	}
+	if state, _ := p.PaymentStatus("task-2"); state != PaymentFailed {
+		t.Errorf("task-2 payment = %s, want failed", state)
+	}
+	records := p.PaymentRecords("task-2")
+	if len(records) != 1 || !records[0].Permanent {
+		t.Errorf("task-2 records = %+v, want one permanent failure", records)
+	}
+	if n := len(network.Transfers()); n != 2 {
+		t.Errorf("transfers = %d, want the two associated recipients paid", n)
+	}
+	if len(pub.calls) != 2 {
+		t.Errorf("settlements = %d, want 2", len(pub.calls))
+	}
+}
+
+// receiptLostBatch executes batches on the network but reports the receipt
+// as lost, returning the transaction ID with the error as TransferService
+// does.
+type receiptLostBatch struct {
+	network *sim.Network
+}
+
+func (b receiptLostBatch) TransferBatch(ctx context.Context, req hts.BatchTransferRequest) (*hts.BatchTransferReceipt, error) {
+	receipt, err := b.network.TransferBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	receipt.Status = ""
+	return receipt, errors.New("receipt: timed out")
+}
+
+func TestPayment_BatchReceiptErrorNotPaidTwice(t *testing.T) {
+	ctx := context.Background()
+	p, network, _, agents := newTestPayment(t, testPaymentOptions{agents: 2, batchSize: 2, batchWindow: time.Hour})
+	p.SetBatchTransfer(receiptLostBatch{network: network})
+
+	_ = p.PayForTask(ctx, "task-1", agents[0].String(), 100)
+	_ = p.PayForTask(ctx, "task-2", agents[1].String(), 50)
+
+	transfers := network.Transfers()
+	if len(transfers) != 2 {
+		t.Fatalf("transfers = %d, want the batch that executed despite the error", len(transfers))
+	}
+	txID := transfers[0].TransactionID.String()
+	for _, taskID := range []string{"task-1", "task-2"} {
+		records := p.PaymentRecords(taskID)
+		if len(records) != 1 || records[0].State != PaymentFailed || records[0].TransactionID != txID {
+			t.Fatalf("task %s records = %+v, want a failed attempt carrying %s", taskID, records, txID)
+		}
+	}
+
+	// The retry finds the batch on chain instead of paying again.
+	if n := newTestPaymentRetrier(p, network).RetryDue(ctx); n != 2 {
+		t.Fatalf("RetryDue() = %d, want 2", n)
+	}
+	if n := len(network.Transfers()); n != 2 {
+		t.Errorf("transfers after retry = %d, want no second payment", n)
+	}
+	for _, taskID := range []string{"task-1", "task-2"} {
+		if state, _ := p.PaymentStatus(taskID); state != PaymentProcessed {
+			t.Errorf("task %s payment = %s, want processed", taskID, state)
+		}
+	}
+}
+
+func taskName(i int) string {
+	return "task-" + string(rune('a'+i))
+}
@@ -29,6 +29,45 @@ type Config struct {
 
 	// QualityGateTimeout is the max time to wait for quality gate evaluation.
 	QualityGateTimeout time.Duration
+
+	// QualityGateWorkers bounds how many quality gate evaluations run at once.
+	QualityGateWorkers int
+
+	// MaxReassignments is how many times a task whose lease expires is
+	// reassigned to another agent before it is left failed.
+	MaxReassignments int
+
+	// Retry decides whether tasks whose agent reports a failed result are
+	// assigned again.
+	Retry RetryPolicy
+
+	// PaymentBatchSize is how many payments are queued before they are
+	// settled together in one transfer transaction. Zero or one pays each
+	// task in its own transaction.
+	PaymentBatchSize int
+
+	// PaymentBatchWindow is the longest a queued payment waits for its batch
+	// to fill before the batch is settled anyway.
+	PaymentBatchWindow time.Duration
+
+	// PaymentRetry decides whether failed payments are attempted again.
+	PaymentRetry PaymentRetryPolicy
+
+	// ReconcileInterval is how often the payment ledger is reconciled with
+	// on-chain transfers. Zero disables the reconciliation job.
+	ReconcileInterval time.Duration
+
+	// ReconcileGrace is how long a settled payment may be missing on chain
+	// before it is reported, allowing for mirror node indexing delay.
+	ReconcileGrace time.Duration
+
+	// EscrowAccountID holds escrowed task payments. When set, and the
+	// payment manager has a schedule service, each task's payment is
+	// committed at assignment as a scheduled transfer from this account and
+	// released when the task passes its quality gate. It must differ from
+	// the treasury: the account paying for the schedule counts as a signer,
+	// so a schedule debiting it would execute at once.
+	EscrowAccountID hiero.AccountID
 }
 
 // DefaultConfig returns sensible defaults for testnet usage.
@@ -37,6 +76,13 @@ func DefaultConfig() Config {
 		DefaultPaymentAmount: 100,
 		MonitorPollInterval:  5 * time.Second,
 		QualityGateTimeout:   30 * time.Second,
+		QualityGateWorkers:   4,
+		MaxReassignments:     2,
+		Retry:                DefaultRetryPolicy(),
+		PaymentBatchWindow:   10 * time.Second,
+		PaymentRetry:         DefaultPaymentRetryPolicy(),
+		ReconcileInterval:    5 * time.Minute,
+		ReconcileGrace:       time.Minute,
 	}
 }
 
@@ -57,5 +103,32 @@ func (c Config) Validate() error {
 	if c.DefaultPaymentAmount <= 0 {
 		return fmt.Errorf("coordinator config: default payment amount must be positive")
 	}
+	if c.QualityGateTimeout < 0 {
+		return fmt.Errorf("coordinator config: quality gate timeout must not be negative")
+	}
+	if c.QualityGateWorkers < 0 {
+		return fmt.Errorf("coordinator config: quality gate workers must not be negative")
+	}
+	if c.MaxReassignments < 0 {
+		return fmt.Errorf("coordinator config: max reassignments must not be negative")
+	}
+	if c.PaymentBatchSize < 0 {
+		return fmt.Errorf("coordinator config: payment batch size must not be negative")
+	}
+	if c.PaymentBatchSize > 1 && c.PaymentBatchWindow <= 0 {
+		return fmt.Errorf("coordinator config: payment batch window must be positive when batching")
+	}
+	if c.ReconcileInterval < 0 || c.ReconcileGrace < 0 {
+		return fmt.Errorf("coordinator config: reconcile interval and grace must not be negative")
+	}
+	if c.EscrowAccountID.Account != 0 && c.EscrowAccountID == c.TreasuryAccountID {
+		return fmt.Errorf("coordinator config: escrow account must differ from the treasury account")
+	}
+	if err := c.Retry.Validate(); err != nil {
+		return fmt.Errorf("coordinator config: %w", err)
+	}
+	if err := c.PaymentRetry.Validate(); err != nil {
+		return fmt.Errorf("coordinator config: %w", err)
+	}
 	return nil
 }
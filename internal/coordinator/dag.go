@@ -0,0 +1,118 @@
+package coordinator
+
+// dispatchState tracks where a plan task is in the dispatch lifecycle.
+type dispatchState int
+
+const (
+	dispatchWaiting dispatchState = iota
+	dispatchReleased
+	dispatchSkipped
+	dispatchBlocked
+)
+
+// dependencyState summarizes whether a dependency allows its dependents to run.
+type dependencyState int
+
+const (
+	dependencyWaiting dependencyState = iota
+	dependencySatisfied
+	dependencyFailed
+)
+
+// taskGraph is a dependency DAG built from a Plan. Nodes are kept in plan
+// order so dispatch is deterministic when several tasks become ready at once.
+type taskGraph struct {
+	order []string
+	tasks map[string]PlanTask
+	state map[string]dispatchState
+}
+
+// newTaskGraph builds a dependency graph from the tasks in a plan.
+func newTaskGraph(plan Plan) *taskGraph {
+	g := &taskGraph{
+		tasks: make(map[string]PlanTask, plan.TaskCount()),
+		state: make(map[string]dispatchState, plan.TaskCount()),
+	}
+	for _, seq := range plan.Sequences {
+		for _, task := range seq.Tasks {
+			if _, exists := g.tasks[task.ID]; exists {
+				continue
+			}
+			g.order = append(g.order, task.ID)
+			g.tasks[task.ID] = task
+			g.state[task.ID] = dispatchWaiting
+		}
+	}
+	return g
+}
+
+// contains reports whether the task is a node in the graph.
+func (g *taskGraph) contains(taskID string) bool {
+	_, ok := g.tasks[taskID]
+	return ok
+}
+
+// hasDependencies reports whether any task in the graph depends on another
+// task in the graph.
+func (g *taskGraph) hasDependencies() bool {
+	for _, id := range g.order {
+		for _, dep := range g.tasks[id].Dependencies {
+			if g.contains(dep) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// remaining returns the number of tasks that have not yet been released,
+// skipped or blocked.
+func (g *taskGraph) remaining() int {
+	n := 0
+	for _, id := range g.order {
+		if g.state[id] == dispatchWaiting {
+			n++
+		}
+	}
+	return n
+}
+
+// release walks the waiting tasks and returns those whose dependencies are all
+// satisfied, plus those that can never run because a dependency failed. Blocked
+// tasks are marked immediately; ready tasks are left waiting until the caller
+// records the dispatch outcome with mark. depState is asked about each
+// dependency dep of the waiting task taskID.
+func (g *taskGraph) release(depState func(taskID, dep string) dependencyState) (ready []PlanTask, blocked []string) {
+	for _, id := range g.order {
+		if g.state[id] != dispatchWaiting {
+			continue
+		}
+
+		satisfied := true
+		failed := false
+		for _, dep := range g.tasks[id].Dependencies {
+			switch depState(id, dep) {
+			case dependencyFailed:
+				failed = true
+			case dependencyWaiting:
+				satisfied = false
+			}
+		}
+
+		switch {
+		case failed:
+			g.state[id] = dispatchBlocked
+			blocked = append(blocked, id)
+		case satisfied:
+			ready = append(ready, g.tasks[id])
+		}
+	}
+	return ready, blocked
+}
+
+// mark records the dispatch outcome for a task.
+func (g *taskGraph) mark(taskID string, state dispatchState) {
+	if g.contains(taskID) {
+		g.state[taskID] = state
+	}
+}
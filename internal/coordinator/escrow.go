@@ -0,0 +1,359 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/schedule"
+)
+
+// escrowMemoPrefix starts the schedule memo of every escrowed payment; the
+// task ID follows it.
+const escrowMemoPrefix = "escrow:task:"
+
+// ErrNoEscrow is returned when escrow is used without an escrow account and
+// schedule service configured.
+var ErrNoEscrow = errors.New("payment escrow not configured")
+
+// EscrowState is the lifecycle state of an escrowed payment.
+type EscrowState string
+
+const (
+	// EscrowHeld is a scheduled payment waiting for its release signature.
+	EscrowHeld EscrowState = "held"
+
+	// EscrowReleased is a scheduled payment that was signed and executed.
+	EscrowReleased EscrowState = "released"
+
+	// EscrowCancelled is a scheduled payment that was deleted unexecuted.
+	EscrowCancelled EscrowState = "cancelled"
+
+	// EscrowExpired is a scheduled payment that expired, or was deleted
+	// outside the coordinator, before its release. The task is paid from
+	// the treasury instead.
+	EscrowExpired EscrowState = "expired"
+)
+
+// errEscrowGone is returned by release when the task's schedule no longer
+// exists on chain.
+var errEscrowGone = errors.New("escrow schedule gone")
+
+// TaskEscrow is a task payment committed on chain as a scheduled transfer
+// from the escrow account to the assigned agent.
+type TaskEscrow struct {
+	TaskID string `json:"task_id"`
+
+	// AgentID is the recipient's Hedera account ID.
+	AgentID    string      `json:"agent_id"`
+	Amount     int64       `json:"amount"`
+	ScheduleID string      `json:"schedule_id"`
+	State      EscrowState `json:"state"`
+	Time       time.Time   `json:"time"`
+
+	// ExpiresAt is when the network removes the schedule unexecuted; zero
+	// means the network default.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+// PaymentEscrowedPayload is the HCS message payload announcing an escrowed
+// payment. Agents can look the schedule up on chain to verify the payment is
+// committed before starting work.
+type PaymentEscrowedPayload struct {
+	TaskID          string `json:"task_id"`
+	AgentID         string `json:"agent_id"`
+	Amount          int64  `json:"amount"`
+	TokenID         string `json:"token_id"`
+	ScheduleID      string `json:"schedule_id"`
+	EscrowAccountID string `json:"escrow_account_id"`
+}
+
+// SetEscrow configures the schedule service used to escrow payments from
+// Config.EscrowAccountID. The signer must hold the escrow account's key.
+func (p *Payment) SetEscrow(creator schedule.ExpiringScheduleCreator, signer schedule.ScheduleSigner) {
+	p.scheduler = creator
+	p.scheduleSigner = signer
+}
+
+// escrowing reports whether payments are escrowed at assignment.
+func (p *Payment) escrowing() bool {
+	return p.scheduler != nil && p.scheduleSigner != nil && p.config.EscrowAccountID.Account != 0
+}
+
+// Escrow returns the latest escrow for a task, if any.
+func (p *Payment) Escrow(taskID string) (TaskEscrow, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	e, ok := p.escrows[taskID]
+	return e, ok
+}
+
+// EscrowForTask creates a scheduled transfer of amount from the escrow
+// account to the agent, expiring after expiry, and announces its schedule ID
+// on the task topic. An escrow already held for the same agent is kept; one
+// held for another agent, after a reassignment, is cancelled first.
+func (p *Payment) EscrowForTask(ctx context.Context, taskID string, agentID string, amount int64, expiry time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("escrow payment for task %s to %s: %w", taskID, agentID, err)
+	}
+	if !p.escrowing() {
+		return fmt.Errorf("escrow payment for task %s to %s: %w", taskID, agentID, ErrNoEscrow)
+	}
+	if amount <= 0 {
+		return fmt.Errorf("escrow payment for task %s to %s: amount must be positive, got %d", taskID, agentID, amount)
+	}
+
+	p.mu.RLock()
+	state := p.payments[taskID]
+	held, isHeld := p.heldEscrowLocked(taskID)
+	p.mu.RUnlock()
+	if state == PaymentProcessed || state == PaymentPending {
+		return fmt.Errorf("escrow payment for task %s to %s: payment already %s", taskID, agentID, state)
+	}
+	if isHeld {
+		if held.AgentID == agentID {
+			return nil
+		}
+		if err := p.CancelEscrow(ctx, taskID); err != nil {
+			return fmt.Errorf("escrow payment for task %s to %s: %w", taskID, agentID, err)
+		}
+	}
+
+	agentAccountID, err := hiero.AccountIDFromString(agentID)
+	if err != nil {
+		return fmt.Errorf("escrow payment for task %s to %s: parse agent account: %w", taskID, agentID, err)
+	}
+
+	// The escrow account must cover every payment it already holds.
+	escrowAccount := p.config.EscrowAccountID
+	if err := p.precheckFrom(ctx, escrowAccount, agentAccountID, p.heldEscrowTotal()+amount); err != nil {
+		return fmt.Errorf("escrow payment for task %s to %s: %w", taskID, agentID, err)
+	}
+
+	tokenID := p.config.PaymentTokenID
+	transfer := hiero.NewTransferTransaction().
+		AddTokenTransfer(tokenID, escrowAccount, -amount).
+		AddTokenTransfer(tokenID, agentAccountID, amount).
+		SetTransactionMemo(paymentMemo(taskID))
+	now := time.Now().UTC()
+	var expiresAt time.Time
+	if expiry > 0 {
+		expiresAt = now.Add(expiry)
+	}
+	scheduleID, err := p.scheduler.CreateScheduleExpiring(ctx, transfer, escrowMemoPrefix+taskID, expiresAt)
+	if err != nil {
+		return fmt.Errorf("escrow payment for task %s to %s: %w", taskID, agentID, err)
+	}
+
+	escrow := TaskEscrow{
+		TaskID:     taskID,
+		AgentID:    agentID,
+		Amount:     amount,
+		ScheduleID: scheduleID.String(),
+		State:      EscrowHeld,
+		Time:       now,
+		ExpiresAt:  expiresAt,
+	}
+	p.saveEscrow(escrow)
+
+	if err := p.publishEscrow(ctx, escrow); err != nil {
+		return fmt.Errorf("escrow payment for task %s to %s: publish escrow notice: %w", taskID, agentID, err)
+	}
+	return nil
+}
+
+// CancelEscrow deletes the task's held scheduled payment. It is a no-op when
+// no escrow is held, and a schedule already gone from the network counts as
+// deleted.
+func (p *Payment) CancelEscrow(ctx context.Context, taskID string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("cancel escrow for task %s: %w", taskID, err)
+	}
+
+	p.mu.RLock()
+	escrow, ok := p.heldEscrowLocked(taskID)
+	p.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	if p.scheduleSigner == nil {
+		return fmt.Errorf("cancel escrow for task %s: %w", taskID, ErrNoEscrow)
+	}
+
+	scheduleID, err := hiero.ScheduleIDFromString(escrow.ScheduleID)
+	if err != nil {
+		return fmt.Errorf("cancel escrow for task %s: parse schedule ID: %w", taskID, err)
+	}
+	if err := p.scheduleSigner.DeleteSchedule(ctx, scheduleID); err != nil && !errors.Is(err, schedule.ErrScheduleGone) {
+		return fmt.Errorf("cancel escrow for task %s: %w", taskID, err)
+	}
+
+	escrow.State = EscrowCancelled
+	escrow.Time = time.Now().UTC()
+	p.saveEscrow(escrow)
+	return nil
+}
+
+// release pays a task by signing its escrowed transfer. The schedule cannot
+// change, so a request above the escrowed amount, such as one including a
+// result-dependent bonus, has the difference paid from the treasury once the
+// escrow is released (see payShortfall); a request below it is paid the
+// escrowed amount. If the schedule has expired or was deleted, the escrow is marked expired
+// and errEscrowGone is returned with the record left open, for the caller
+// to pay from the treasury.
+func (p *Payment) release(ctx context.Context, record PaymentRecord, escrow TaskEscrow) error {
+	if escrow.AgentID != record.AgentID {
+		err := fmt.Errorf("escrow schedule %s pays %s, not %s", escrow.ScheduleID, escrow.AgentID, record.AgentID)
+		p.failPayment(record, err)
+		return err
+	}
+	released := record
+	shortfall := record.Amount - escrow.Amount
+	if escrow.Amount != record.Amount {
+		p.logger.Info("paying escrowed amount",
+			"task_id", record.TaskID, "escrowed", escrow.Amount, "requested", record.Amount)
+		released.Amount = escrow.Amount
+	}
+	released.ScheduleID = escrow.ScheduleID
+	p.updateRecord(released)
+
+	scheduleID, err := hiero.ScheduleIDFromString(escrow.ScheduleID)
+	if err != nil {
+		err = fmt.Errorf("parse schedule ID: %w", err)
+		p.failPayment(released, err)
+		return err
+	}
+
+	txID, err := p.scheduleSigner.SignSchedule(ctx, scheduleID)
+	if errors.Is(err, schedule.ErrScheduleGone) {
+		escrow.State = EscrowExpired
+		escrow.Time = time.Now().UTC()
+		p.saveEscrow(escrow)
+		p.updateRecord(record)
+		return fmt.Errorf("release escrow: %w: %w", errEscrowGone, err)
+	}
+	if err != nil {
+		err = fmt.Errorf("release escrow: %w", err)
+		p.failPayment(released, err)
+		return err
+	}
+	record = released
+
+	escrow.State = EscrowReleased
+	escrow.Time = time.Now().UTC()
+	p.saveEscrow(escrow)
+
+	err = p.settle(ctx, record, txID.String(), hiero.StatusSuccess.String())
+	if shortfall > 0 {
+		p.payShortfall(ctx, record, shortfall)
+	}
+	if err != nil {
+		return fmt.Errorf("publish settlement: %w", err)
+	}
+	return nil
+}
+
+// shortfallSuffix marks the payment ID of an escrow shortfall; the task ID
+// precedes it.
+const shortfallSuffix = ":shortfall"
+
+// shortfallTaskID returns the payment ID under which a task's escrow
+// shortfall is paid and recorded.
+func shortfallTaskID(taskID string) string {
+	return taskID + shortfallSuffix
+}
+
+// payShortfall pays from the treasury what a released task was priced above
+// its escrowed amount. The shortfall is a payment of its own, with its own
+// ledger records and transfer memo, so the escrowed payment stays settled
+// and a failed shortfall is left failed in the ledger for the
+// PaymentRetrier.
+func (p *Payment) payShortfall(ctx context.Context, record PaymentRecord, amount int64) {
+	p.logger.Info("paying escrow shortfall from treasury",
+		"task_id", record.TaskID, "agent_id", record.AgentID, "amount", amount)
+	if err := p.pay(ctx, shortfallTaskID(record.TaskID), record.AgentID, amount, false); err != nil {
+		p.logger.Error("escrow shortfall payment failed",
+			"task_id", record.TaskID, "agent_id", record.AgentID, "amount", amount, "error", err)
+	}
+}
+
+// heldEscrowLocked returns the task's escrow if it is still held. The
+// caller must hold p.mu.
+func (p *Payment) heldEscrowLocked(taskID string) (TaskEscrow, bool) {
+	e, ok := p.escrows[taskID]
+	return e, ok && e.State == EscrowHeld
+}
+
+// heldEscrowTotal sums the amounts of every held escrow.
+func (p *Payment) heldEscrowTotal() int64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var total int64
+	for _, e := range p.escrows {
+		if e.State == EscrowHeld {
+			total += e.Amount
+		}
+	}
+	return total
+}
+
+func (p *Payment) saveEscrow(escrow TaskEscrow) {
+	p.mu.Lock()
+	p.escrows[escrow.TaskID] = escrow
+	p.mu.Unlock()
+
+	if p.store == nil {
+		return
+	}
+	if err := p.store.SaveEscrow(escrow); err != nil {
+		p.logger.Warn("failed to persist escrow", "task_id", escrow.TaskID, "state", escrow.State, "error", err)
+	}
+}
+
+func (p *Payment) publishEscrow(ctx context.Context, escrow TaskEscrow) error {
+	payload := PaymentEscrowedPayload{
+		TaskID:          escrow.TaskID,
+		AgentID:         escrow.AgentID,
+		Amount:          escrow.Amount,
+		TokenID:         p.config.PaymentTokenID.String(),
+		ScheduleID:      escrow.ScheduleID,
+		EscrowAccountID: p.config.EscrowAccountID.String(),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal escrow payload: %w", err)
+	}
+
+	p.mu.Lock()
+	p.seqNum++
+	seqNum := p.seqNum
+	p.mu.Unlock()
+
+	return p.publisher.Publish(ctx, p.config.TaskTopicID, hcs.Envelope{
+		Type:        hcs.MessageTypePaymentEscrowed,
+		Sender:      "coordinator",
+		Recipient:   escrow.AgentID,
+		TaskID:      escrow.TaskID,
+		SequenceNum: seqNum,
+		Timestamp:   time.Now(),
+		Payload:     payloadBytes,
+	})
+}
+
+// restoreEscrows replaces tracked escrows with those recovered from a store.
+func (p *Payment) restoreEscrows(escrows map[string]TaskEscrow) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for taskID, e := range escrows {
+		p.escrows[taskID] = e
+	}
+}
+
+// Compile-time interface compliance check.
+var _ PaymentEscrow = (*Payment)(nil)
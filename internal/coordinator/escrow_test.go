@@ -0,0 +1,247 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+)
+
+func TestPayment_EscrowReleasedOnPay(t *testing.T) {
+	ctx := context.Background()
+	p, network, pub, agents := newTestPayment(t, testPaymentOptions{agents: 2, escrow: true})
+	agent := agents[0].String()
+	fundingTransfers := len(network.Transfers())
+
+	if err := p.EscrowForTask(ctx, "task-1", agent, 100, 0); err != nil {
+		t.Fatalf("EscrowForTask() error = %v", err)
+	}
+	escrow, ok := p.Escrow("task-1")
+	if !ok || escrow.State != EscrowHeld || escrow.Amount != 100 {
+		t.Fatalf("Escrow() = %+v, %v; want held for 100", escrow, ok)
+	}
+	if len(network.Transfers()) != fundingTransfers {
+		t.Fatal("escrow moved tokens before release")
+	}
+
+	// Agents learn the schedule ID and can check it on chain.
+	if len(pub.calls) != 1 || pub.calls[0].Type != hcs.MessageTypePaymentEscrowed || pub.calls[0].Recipient != agent {
+		t.Fatalf("published = %+v, want one payment_escrowed notice to the agent", pub.calls)
+	}
+	var notice PaymentEscrowedPayload
+	if err := json.Unmarshal(pub.calls[0].Payload, &notice); err != nil {
+		t.Fatalf("unmarshal escrow notice: %v", err)
+	}
+	scheduleID, err := hiero.ScheduleIDFromString(notice.ScheduleID)
+	if err != nil || notice.ScheduleID != escrow.ScheduleID {
+		t.Fatalf("notice schedule ID = %q, want %q", notice.ScheduleID, escrow.ScheduleID)
+	}
+	if info, err := network.ScheduleInfo(ctx, scheduleID); err != nil || info.Executed || info.Deleted {
+		t.Fatalf("ScheduleInfo() = %+v, %v; want pending", info, err)
+	}
+
+	// A bonus above the escrowed amount is paid from the treasury once the
+	// escrow is released.
+	if err := p.PayForTask(ctx, "task-1", agent, 150); err != nil {
+		t.Fatalf("PayForTask() error = %v", err)
+	}
+	transfers := network.Transfers()[fundingTransfers:]
+	if len(transfers) != 2 || transfers[0].FromAccountID != p.config.EscrowAccountID ||
+		transfers[0].Amount != 100 || !transfers[0].TransactionID.GetScheduled() {
+		t.Fatalf("transfers = %+v, want a scheduled transfer of 100 from escrow first", transfers)
+	}
+	if shortfall := transfers[1]; shortfall.FromAccountID != p.config.TreasuryAccountID ||
+		shortfall.Amount != 50 || shortfall.Memo != paymentMemo(shortfallTaskID("task-1")) {
+		t.Fatalf("shortfall transfer = %+v, want 50 from the treasury", shortfall)
+	}
+	if state, _ := p.PaymentStatus("task-1"); state != PaymentProcessed {
+		t.Errorf("PaymentStatus() = %s, want processed", state)
+	}
+	if escrow, _ := p.Escrow("task-1"); escrow.State != EscrowReleased {
+		t.Errorf("escrow state = %s, want released", escrow.State)
+	}
+	records := p.PaymentRecords("task-1")
+	if len(records) != 1 || records[0].ScheduleID != notice.ScheduleID ||
+		records[0].TransactionID != transfers[0].TransactionID.String() || records[0].Amount != 100 {
+		t.Fatalf("ledger = %+v, want the released schedule's transfer", records)
+	}
+	shortfall := p.PaymentRecords(shortfallTaskID("task-1"))
+	if len(shortfall) != 1 || shortfall[0].State != PaymentProcessed || shortfall[0].Amount != 50 ||
+		shortfall[0].TransactionID != transfers[1].TransactionID.String() {
+		t.Fatalf("shortfall ledger = %+v, want the treasury transfer of 50", shortfall)
+	}
+
+	report, err := NewReconciler(p, network, p.config).Reconcile(ctx)
+	if err != nil || !report.OK() || report.Transfers != 2 {
+		t.Errorf("Reconcile() = %+v, %v; want the escrow and shortfall transfers matched", report, err)
+	}
+}
+
+func TestPayment_EscrowShortfallLeftForRetry(t *testing.T) {
+	ctx := context.Background()
+	p, network, _, agents := newTestPayment(t, testPaymentOptions{agents: 2, escrow: true})
+	agent := agents[0].String()
+
+	if err := p.EscrowForTask(ctx, "task-1", agent, 100, 0); err != nil {
+		t.Fatalf("EscrowForTask() error = %v", err)
+	}
+	// The treasury cannot cover the bonus, so only the escrow pays out.
+	if err := p.PayForTask(ctx, "task-1", agent, 100_000); err != nil {
+		t.Fatalf("PayForTask() error = %v", err)
+	}
+	if state, _ := p.PaymentStatus("task-1"); state != PaymentProcessed {
+		t.Errorf("task payment = %s, want processed", state)
+	}
+	taskID := shortfallTaskID("task-1")
+	if state, _ := p.PaymentStatus(taskID); state != PaymentFailed {
+		t.Fatalf("shortfall payment = %s, want failed", state)
+	}
+	if records := p.PaymentRecords(taskID); len(records) != 1 || records[0].Amount != 100_000-100 || records[0].Permanent {
+		t.Fatalf("shortfall ledger = %+v, want one retryable attempt for the difference", records)
+	}
+	if failed := newTestPaymentRetrier(p, network).failedTasks(); len(failed) != 1 || failed[0] != taskID {
+		t.Errorf("failedTasks() = %v, want the shortfall", failed)
+	}
+}
+
+func TestPayment_EscrowCancelled(t *testing.T) {
+	ctx := context.Background()
+	p, network, _, agents := newTestPayment(t, testPaymentOptions{agents: 2, escrow: true})
+
+	if err := p.EscrowForTask(ctx, "task-1", agents[0].String(), 100, 0); err != nil {
+		t.Fatalf("EscrowForTask() error = %v", err)
+	}
+	first, _ := p.Escrow("task-1")
+
+	// Reassigning the task moves the escrow to the new agent.
+	if err := p.EscrowForTask(ctx, "task-1", agents[1].String(), 100, 0); err != nil {
+		t.Fatalf("EscrowForTask() after reassignment error = %v", err)
+	}
+	second, _ := p.Escrow("task-1")
+	if second.ScheduleID == first.ScheduleID || second.AgentID != agents[1].String() {
+		t.Fatalf("escrow after reassignment = %+v, want a new schedule for the new agent", second)
+	}
+	firstID, _ := hiero.ScheduleIDFromString(first.ScheduleID)
+	if info, _ := network.ScheduleInfo(ctx, firstID); !info.Deleted {
+		t.Error("first agent's schedule not deleted on reassignment")
+	}
+
+	if err := p.CancelEscrow(ctx, "task-1"); err != nil {
+		t.Fatalf("CancelEscrow() error = %v", err)
+	}
+	if escrow, _ := p.Escrow("task-1"); escrow.State != EscrowCancelled {
+		t.Errorf("escrow state = %s, want cancelled", escrow.State)
+	}
+	if err := p.CancelEscrow(ctx, "task-1"); err != nil {
+		t.Errorf("CancelEscrow() on cancelled escrow error = %v, want no-op", err)
+	}
+
+	// With no escrow held, the task is paid from the treasury.
+	if err := p.PayForTask(ctx, "task-1", agents[1].String(), 100); err != nil {
+		t.Fatalf("PayForTask() error = %v", err)
+	}
+	transfers := network.Transfers()
+	if last := transfers[len(transfers)-1]; last.FromAccountID != p.config.TreasuryAccountID {
+		t.Errorf("payment debited %s, want the treasury", last.FromAccountID)
+	}
+}
+
+func TestPayment_EscrowExpiredPaysFromTreasury(t *testing.T) {
+	ctx := context.Background()
+	p, network, _, agents := newTestPayment(t, testPaymentOptions{agents: 2, escrow: true})
+	agent := agents[0].String()
+
+	if err := p.EscrowForTask(ctx, "task-1", agent, 100, 10*time.Millisecond); err != nil {
+		t.Fatalf("EscrowForTask() error = %v", err)
+	}
+	escrow, _ := p.Escrow("task-1")
+	if escrow.ExpiresAt.IsZero() {
+		t.Fatal("escrow has no expiration time")
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	if err := p.PayForTask(ctx, "task-1", agent, 100); err != nil {
+		t.Fatalf("PayForTask() error = %v", err)
+	}
+	transfers := network.Transfers()
+	if last := transfers[len(transfers)-1]; last.FromAccountID != p.config.TreasuryAccountID || last.Amount != 100 {
+		t.Errorf("payment = %+v, want 100 from the treasury", last)
+	}
+	if escrow, _ := p.Escrow("task-1"); escrow.State != EscrowExpired {
+		t.Errorf("escrow state = %s, want expired", escrow.State)
+	}
+	records := p.PaymentRecords("task-1")
+	if len(records) != 1 || records[0].State != PaymentProcessed || records[0].ScheduleID != "" {
+		t.Errorf("ledger = %+v, want one treasury payment", records)
+	}
+
+	// An escrow whose schedule is gone is cancelled without error.
+	if err := p.EscrowForTask(ctx, "task-2", agent, 100, 0); err != nil {
+		t.Fatalf("EscrowForTask() error = %v", err)
+	}
+	escrow, _ = p.Escrow("task-2")
+	scheduleID, _ := hiero.ScheduleIDFromString(escrow.ScheduleID)
+	if err := network.DeleteSchedule(ctx, scheduleID); err != nil {
+		t.Fatalf("DeleteSchedule() error = %v", err)
+	}
+	if err := p.CancelEscrow(ctx, "task-2"); err != nil {
+		t.Errorf("CancelEscrow() of deleted schedule error = %v", err)
+	}
+}
+
+func TestPayment_EscrowRequiresCoverage(t *testing.T) {
+	ctx := context.Background()
+	p, _, _, agents := newTestPayment(t, testPaymentOptions{agents: 2, escrow: true})
+
+	if err := p.EscrowForTask(ctx, "task-1", agents[0].String(), 600, 0); err != nil {
+		t.Fatalf("EscrowForTask() error = %v", err)
+	}
+	// The escrow account holds 1,000, of which 600 are already committed.
+	err := p.EscrowForTask(ctx, "task-2", agents[1].String(), 600, 0)
+	if !errors.Is(err, ErrInsufficientTreasury) {
+		t.Errorf("EscrowForTask() error = %v, want ErrInsufficientTreasury", err)
+	}
+
+	unconfigured := NewPayment(nil, &mockPublisher{}, p.config)
+	if err := unconfigured.EscrowForTask(ctx, "task-3", agents[0].String(), 100, 0); !errors.Is(err, ErrNoEscrow) {
+		t.Errorf("EscrowForTask() without schedule service error = %v, want ErrNoEscrow", err)
+	}
+}
+
+func TestResultHandler_EscrowsOnAssignment(t *testing.T) {
+	ctx := context.Background()
+	p, _, pub, agents := newTestPayment(t, testPaymentOptions{agents: 2, escrow: true})
+	rh := NewResultHandler(ResultHandlerConfig{
+		Payment:       p,
+		Config:        p.config,
+		Log:           slog.Default(),
+		AgentAccounts: map[string]string{"agent-1": agents[0].String()},
+		Escrow:        p,
+	})
+	rh.SetPlan(Plan{Sequences: []PlanSequence{{Tasks: []PlanTask{{ID: "task-1", PaymentAmount: 75}}}}})
+	assigner := NewAssigner(pub, p.config.TaskTopicID, nil)
+	assigner.SetEscrow(rh)
+
+	if _, err := assigner.assignPlanTask(ctx, PlanTask{ID: "task-1"}, "agent-1"); err != nil {
+		t.Fatalf("assignPlanTask() error = %v", err)
+	}
+	if escrow, ok := p.Escrow("task-1"); !ok || escrow.State != EscrowHeld || escrow.Amount != 75 {
+		t.Fatalf("Escrow() after assignment = %+v, %v; want 75 held", escrow, ok)
+	}
+	// The escrow is on the topic before the assignment.
+	if len(pub.calls) != 2 || pub.calls[0].Type != hcs.MessageTypePaymentEscrowed ||
+		pub.calls[1].Type != hcs.MessageTypeTaskAssignment {
+		t.Fatalf("published = %+v, want payment_escrowed then task_assignment", pub.calls)
+	}
+
+	rh.handleEvent(ctx, TaskStatusChanged{TaskID: "task-1", From: StatusInProgress, To: StatusFailed})
+	if escrow, _ := p.Escrow("task-1"); escrow.State != EscrowCancelled {
+		t.Errorf("escrow state after failure = %s, want cancelled", escrow.State)
+	}
+}
@@ -0,0 +1,215 @@
+package coordinator
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Event is a task lifecycle event published on an EventBus. Subscribers
+// switch on the concrete type.
+type Event interface {
+	// EventTaskID returns the task the event concerns.
+	EventTaskID() string
+}
+
+// TaskAssigned is published when a task assignment has been sent to an agent.
+type TaskAssigned struct {
+	TaskID  string
+	AgentID string
+}
+
+// TaskStatusChanged is published whenever the monitor records a new status
+// for a task.
+type TaskStatusChanged struct {
+	TaskID string
+	From   TaskStatus // empty when the task was not tracked before
+	To     TaskStatus
+}
+
+// ResultReceived is published when the assigned agent's task result arrives.
+// Accepted is false when a completed output failed validation.
+type ResultReceived struct {
+	TaskID   string
+	AgentID  string
+	Result   TaskResultPayload
+	Accepted bool
+	Reason   string
+}
+
+// GateDecided is published when a quality gate moves a task out of review.
+type GateDecided struct {
+	TaskID string
+	Passed bool
+
+	// Status is the task's status after the decision: complete if the gate
+	// passed, in_progress if it failed.
+	Status TaskStatus
+
+	// Reason explains a failed gate.
+	Reason string
+}
+
+// PaymentSettled is published when a task's payment transfer has succeeded.
+type PaymentSettled struct {
+	TaskID   string
+	AgentID  string
+	Amount   int64
+	TxStatus string
+
+	// TransactionID is the transfer that paid the task, shared by every
+	// task settled in the same batch.
+	TransactionID string
+}
+
+// EventTaskID implements Event.
+func (e TaskAssigned) EventTaskID() string { return e.TaskID }
+
+// EventTaskID implements Event.
+func (e TaskStatusChanged) EventTaskID() string { return e.TaskID }
+
+// EventTaskID implements Event.
+func (e ResultReceived) EventTaskID() string { return e.TaskID }
+
+// EventTaskID implements Event.
+func (e GateDecided) EventTaskID() string { return e.TaskID }
+
+// EventTaskID implements Event.
+func (e PaymentSettled) EventTaskID() string { return e.TaskID }
+
+// EventBus delivers task lifecycle events between coordinator components.
+// Each subscriber receives every event in publish order on its own goroutine,
+// so publishers never block on subscribers and may publish while holding
+// their own locks. A nil *EventBus discards everything published to it.
+type EventBus struct {
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	subs    map[int]*subscription
+	nextID  int
+	pending int // events queued or being handled, across subscribers
+	idle    *sync.Cond
+}
+
+// subscription queues events for one subscriber.
+type subscription struct {
+	handler func(Event)
+	queue   []Event
+	wake    chan struct{}
+	done    chan struct{}
+}
+
+// NewEventBus creates an event bus with no subscribers.
+func NewEventBus() *EventBus {
+	b := &EventBus{
+		logger: slog.Default(),
+		subs:   make(map[int]*subscription),
+	}
+	b.idle = sync.NewCond(&b.mu)
+	return b
+}
+
+// Subscribe registers handler for every event published from now on. The
+// returned function unsubscribes; events still queued for the handler are
+// dropped.
+func (b *EventBus) Subscribe(handler func(Event)) (unsubscribe func()) {
+	sub := &subscription{
+		handler: handler,
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = sub
+	b.mu.Unlock()
+
+	go b.deliver(sub)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, id)
+			b.settle(len(sub.queue))
+			sub.queue = nil
+			b.mu.Unlock()
+			close(sub.done)
+		})
+	}
+}
+
+// Publish queues an event for every current subscriber.
+func (b *EventBus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, sub := range b.subs {
+		sub.queue = append(sub.queue, event)
+		b.pending++
+		select {
+		case sub.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Wait blocks until every event published so far, and every event published
+// by subscribers while handling them, has been handled.
+func (b *EventBus) Wait() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.pending > 0 {
+		b.idle.Wait()
+	}
+}
+
+// deliver hands queued events to a subscriber until it unsubscribes.
+func (b *EventBus) deliver(sub *subscription) {
+	for {
+		select {
+		case <-sub.done:
+			return
+		case <-sub.wake:
+		}
+		for {
+			b.mu.Lock()
+			if len(sub.queue) == 0 {
+				b.mu.Unlock()
+				break
+			}
+			event := sub.queue[0]
+			sub.queue = sub.queue[1:]
+			b.mu.Unlock()
+
+			b.handle(sub, event)
+
+			b.mu.Lock()
+			b.settle(1)
+			b.mu.Unlock()
+		}
+	}
+}
+
+// handle runs a subscriber's handler, containing any panic so one faulty
+// subscriber cannot stop delivery to the others.
+func (b *EventBus) handle(sub *subscription, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("event subscriber panicked",
+				"event", fmt.Sprintf("%T", event), "task_id", event.EventTaskID(), "panic", r)
+		}
+	}()
+	sub.handler(event)
+}
+
+// settle marks n events as handled. b.mu must be held.
+func (b *EventBus) settle(n int) {
+	b.pending -= n
+	if b.pending == 0 {
+		b.idle.Broadcast()
+	}
+}
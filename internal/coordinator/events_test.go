@@ -0,0 +1,128 @@
+package coordinator
+
+import (
+	"context"
+	"testing"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+func TestEventBus_DeliversInOrderToEverySubscriber(t *testing.T) {
+	bus := NewEventBus()
+	var first, second []string
+	bus.Subscribe(func(e Event) { first = append(first, e.EventTaskID()) })
+	unsubscribe := bus.Subscribe(func(e Event) { second = append(second, e.EventTaskID()) })
+
+	for _, id := range []string{"task-1", "task-2", "task-3"} {
+		bus.Publish(TaskAssigned{TaskID: id})
+	}
+	bus.Wait()
+	unsubscribe()
+	bus.Publish(TaskAssigned{TaskID: "task-4"})
+	bus.Wait()
+
+	if len(first) != 4 || first[0] != "task-1" || first[3] != "task-4" {
+		t.Errorf("first subscriber got %v, want task-1..task-4 in order", first)
+	}
+	if len(second) != 3 {
+		t.Errorf("second subscriber got %v, want nothing after unsubscribing", second)
+	}
+}
+
+func TestEventBus_WaitCoversEventsPublishedByHandlers(t *testing.T) {
+	bus := NewEventBus()
+	var settled []string
+	bus.Subscribe(func(e Event) {
+		switch e := e.(type) {
+		case TaskAssigned:
+			bus.Publish(PaymentSettled{TaskID: e.TaskID})
+		case PaymentSettled:
+			settled = append(settled, e.TaskID)
+		}
+	})
+	bus.Subscribe(func(Event) { panic("faulty subscriber") })
+
+	bus.Publish(TaskAssigned{TaskID: "task-1"})
+	bus.Wait()
+	if len(settled) != 1 {
+		t.Errorf("settled = %v, want the event published by the handler", settled)
+	}
+
+	var nilBus *EventBus
+	nilBus.Publish(TaskAssigned{TaskID: "task-1"}) // must not panic
+}
+
+func TestMonitor_PublishesStatusChangesAndMarksPaid(t *testing.T) {
+	bus := NewEventBus()
+	var changes []TaskStatusChanged
+	bus.Subscribe(func(e Event) {
+		if c, ok := e.(TaskStatusChanged); ok {
+			changes = append(changes, c)
+		}
+	})
+	m := NewMonitor(nil, hiero.TopicID{}, nil)
+	m.SetEvents(bus)
+
+	m.InitTask("task-1")
+	m.MarkAssigned("task-1")
+	advanceTask(t, m, "task-1", StatusInProgress, StatusReview, StatusComplete)
+	bus.Publish(PaymentSettled{TaskID: "task-1"})
+	bus.Wait()
+
+	if status, _ := m.TaskState("task-1"); status != StatusPaid {
+		t.Errorf("status after settlement = %s, want paid", status)
+	}
+	want := []TaskStatus{StatusPending, StatusAssigned, StatusInProgress, StatusReview, StatusComplete, StatusPaid}
+	if len(changes) != len(want) {
+		t.Fatalf("changes = %+v, want %v", changes, want)
+	}
+	for i, status := range want {
+		if changes[i].To != status || (i > 0 && changes[i].From != want[i-1]) {
+			t.Errorf("change[%d] = %+v, want -> %s", i, changes[i], status)
+		}
+	}
+}
+
+func TestResultHandler_PaysOnlyOnceMonitorReportsComplete(t *testing.T) {
+	bus := NewEventBus()
+	var received []ResultReceived
+	bus.Subscribe(func(e Event) {
+		if r, ok := e.(ResultReceived); ok {
+			received = append(received, r)
+		}
+	})
+	m := NewMonitor(nil, hiero.TopicID{}, nil)
+	m.SetEvents(bus)
+	m.InitTask("task-1")
+	m.MarkAssigned("task-1")
+	advanceTask(t, m, "task-1", StatusInProgress)
+
+	payment := &recordingPayment{}
+	rh := NewResultHandler(ResultHandlerConfig{
+		Payment:       payment,
+		Config:        DefaultConfig(),
+		Log:           discardLogger(),
+		AgentAccounts: map[string]string{"inference-001": "0.0.201"},
+		Assignments:   staticAssignments{"task-1": "inference-001"},
+		Monitor:       m,
+		Events:        bus,
+	})
+	ctx := context.Background()
+	bus.Subscribe(func(e Event) { rh.handleEvent(ctx, e) })
+
+	rh.processMessage(ctx, taskResultEnvelope(t, "inference-001",
+		TaskResultPayload{TaskID: "task-1", Status: "completed", Output: "done"}))
+	bus.Wait()
+	if len(payment.calls) != 0 {
+		t.Fatalf("payments = %+v, want none before the task is complete", payment.calls)
+	}
+	if len(received) != 1 || !received[0].Accepted || received[0].AgentID != "inference-001" {
+		t.Errorf("results received = %+v, want one accepted", received)
+	}
+
+	advanceTask(t, m, "task-1", StatusReview, StatusComplete)
+	bus.Wait()
+	if len(payment.calls) != 1 || payment.calls[0].account != "0.0.201" {
+		t.Fatalf("payments = %+v, want one to 0.0.201 after completion", payment.calls)
+	}
+}
@@ -16,15 +16,15 @@ import (
 )
 
 // FestRuntime builds coordinator plans and dashboard snapshots from fest CLI output.
+// Plan tasks carry the task type and model from their fest metadata but no
+// agent; the assigner routes them.
 type FestRuntime struct {
 	Reader            *festival.Reader
-	InferenceAgentID  string
-	DeFiAgentID       string
 	StaleAfterSeconds int
 	Logger            *slog.Logger
 }
 
-func NewFestRuntime(reader *festival.Reader, inferenceAgentID, defiAgentID string, staleAfterSeconds int, logger *slog.Logger) *FestRuntime {
+func NewFestRuntime(reader *festival.Reader, staleAfterSeconds int, logger *slog.Logger) *FestRuntime {
 	if logger == nil {
 		logger = slog.Default()
 	}
@@ -33,8 +33,6 @@ func NewFestRuntime(reader *festival.Reader, inferenceAgentID, defiAgentID strin
 	}
 	return &FestRuntime{
 		Reader:            reader,
-		InferenceAgentID:  inferenceAgentID,
-		DeFiAgentID:       defiAgentID,
 		StaleAfterSeconds: staleAfterSeconds,
 		Logger:            logger,
 	}
@@ -47,7 +45,7 @@ func (r *FestRuntime) LoadPlan(ctx context.Context) (Plan, string, festival.Prog
 	}
 
 	execPlan := festival.BuildExecutionPlan(roadmap)
-	plan := mapExecutionPlan(execPlan, r.InferenceAgentID, r.DeFiAgentID)
+	plan := mapExecutionPlan(execPlan)
 	snapshot := festival.BuildProgressSnapshot(roadmap, selector, r.StaleAfterSeconds)
 
 	if plan.TaskCount() == 0 {
@@ -56,42 +54,102 @@ func (r *FestRuntime) LoadPlan(ctx context.Context) (Plan, string, festival.Prog
 	return plan, selector, snapshot, nil
 }
 
-func mapExecutionPlan(execPlan festival.ExecutionPlan, inferenceAgentID, defiAgentID string) Plan {
+func mapExecutionPlan(execPlan festival.ExecutionPlan) Plan {
+	index := indexExecutionTasks(execPlan)
 	plan := Plan{FestivalID: execPlan.FestivalID}
 	for _, seq := range execPlan.Sequences {
 		normSeq := PlanSequence{ID: seq.ID}
 		for _, task := range seq.Tasks {
-			if task.IsGate || task.Status == "completed" {
+			if task.Status == "completed" {
+				continue
+			}
+			if task.IsGate {
+				kind := gateKind(strings.ToLower(task.Name + " " + task.ID))
+				if kind == "" {
+					kind = GateKindReview
+				}
+				normSeq.Gates = append(normSeq.Gates, PlanGate{
+					ID:           chooseTaskID(task),
+					Name:         task.Name,
+					Kind:         kind,
+					Dependencies: resolveDependencies(task.Dependencies, index),
+				})
 				continue
 			}
 
+			// A task without a task type is kept so Plan.Validate can
+			// report it.
 			normTask := PlanTask{
 				ID:            chooseTaskID(task),
 				Name:          task.Name,
+				TaskType:      task.TaskType,
+				ModelID:       task.ModelID,
 				Priority:      1,
 				MaxTokens:     512,
 				PaymentAmount: 100,
-				Dependencies:  task.Dependencies,
+				Dependencies:  resolveDependencies(task.Dependencies, index),
 			}
 
-			if looksLikeDeFiTask(task) {
-				normTask.TaskType = "execute_trade"
-				normTask.AssignTo = defiAgentID
-			} else {
-				normTask.TaskType = "inference_job"
-				normTask.AssignTo = inferenceAgentID
-				normTask.ModelID = "qwen/qwen-2.5-7b-instruct"
+			if normTask.TaskType == TaskTypeInference {
+				if normTask.ModelID == "" {
+					normTask.ModelID = "qwen/qwen-2.5-7b-instruct"
+				}
 				normTask.Input = fmt.Sprintf("Execute festival task: %s", task.Name)
 			}
 			normSeq.Tasks = append(normSeq.Tasks, normTask)
 		}
-		if len(normSeq.Tasks) > 0 {
+		if len(normSeq.Tasks) > 0 || len(normSeq.Gates) > 0 {
 			plan.Sequences = append(plan.Sequences, normSeq)
 		}
 	}
 	return plan
 }
 
+// indexExecutionTasks maps both task IDs and names to their tasks, since fest
+// roadmaps reference dependencies by either.
+func indexExecutionTasks(execPlan festival.ExecutionPlan) map[string]festival.ExecutionTask {
+	index := make(map[string]festival.ExecutionTask)
+	for _, seq := range execPlan.Sequences {
+		for _, task := range seq.Tasks {
+			if task.Name != "" {
+				index[task.Name] = task
+			}
+			if task.ID != "" {
+				index[task.ID] = task
+			}
+		}
+	}
+	return index
+}
+
+// resolveDependencies rewrites fest dependency references to plan task IDs.
+// Completed tasks and gates are dropped as already satisfied; pending gates
+// are kept as references to the plan's sequence gates. References to unknown
+// tasks are kept as-is so Plan.Validate can report them.
+func resolveDependencies(deps []string, index map[string]festival.ExecutionTask) []string {
+	var resolved []string
+	added := make(map[string]bool)
+
+	add := func(id string) {
+		if !added[id] {
+			added[id] = true
+			resolved = append(resolved, id)
+		}
+	}
+
+	for _, ref := range deps {
+		task, ok := index[ref]
+		switch {
+		case !ok:
+			add(ref)
+		case task.Status == "completed":
+		default:
+			add(chooseTaskID(task))
+		}
+	}
+	return resolved
+}
+
 func chooseTaskID(task festival.ExecutionTask) string {
 	if strings.TrimSpace(task.ID) != "" {
 		return task.ID
@@ -99,14 +157,6 @@ func chooseTaskID(task festival.ExecutionTask) string {
 	return task.Name
 }
 
-func looksLikeDeFiTask(task festival.ExecutionTask) bool {
-	name := strings.ToLower(task.Name + " " + task.ID)
-	return strings.Contains(name, "defi") ||
-		strings.Contains(name, "trade") ||
-		strings.Contains(name, "swap") ||
-		strings.Contains(name, "pnl")
-}
-
 // FestProgressPublisher emits canonical festival progress snapshots to HCS.
 type FestProgressPublisher struct {
 	runtime        *FestRuntime
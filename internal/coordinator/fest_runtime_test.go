@@ -75,7 +75,7 @@ func testRuntime(t *testing.T, runner festCommandRunner) *FestRuntime {
 	t.Helper()
 	reader := festival.NewReader(festival.ReaderConfig{}, nil)
 	reader.SetRunner(runner)
-	return NewFestRuntime(reader, "inference-001", "defi-001", 30, nil)
+	return NewFestRuntime(reader, 30, nil)
 }
 
 func TestFestRuntimeLoadPlan(t *testing.T) {
@@ -101,8 +101,8 @@ func TestFestRuntimeLoadPlan(t *testing.T) {
 	}
 
 	task := plan.Sequences[0].Tasks[0]
-	if task.AssignTo != "inference-001" {
-		t.Fatalf("assign_to = %q, want inference-001", task.AssignTo)
+	if task.AssignTo != "" {
+		t.Fatalf("assign_to = %q, want empty (routed by registry)", task.AssignTo)
 	}
 	if task.TaskType != "inference_job" {
 		t.Fatalf("task_type = %q, want inference_job", task.TaskType)
@@ -192,3 +192,85 @@ func TestFestProgressPublisherPublishOnce_FailsWithoutSyntheticFallback(t *testi
 		t.Fatalf("published messages = %d, want 0", len(pub.messages))
 	}
 }
+
+func TestMapExecutionPlan_ResolvesDependencies(t *testing.T) {
+	execPlan := festival.ExecutionPlan{
+		FestivalID: "fest-1",
+		Sequences: []festival.ExecutionSequence{
+			{
+				ID: "01_build",
+				Tasks: []festival.ExecutionTask{
+					{ID: "01_build/01_setup.md", Name: "01_setup", Status: "completed"},
+					{ID: "01_build/02_impl.md", Name: "02_impl", Status: "pending", Dependencies: []string{"01_setup"}, TaskType: TaskTypeInference},
+					{ID: "01_build/03_review.md", Name: "03_review", Status: "pending", IsGate: true, Dependencies: []string{"02_impl"}},
+				},
+			},
+			{
+				ID: "02_ship",
+				Tasks: []festival.ExecutionTask{
+					{ID: "02_ship/01_deploy.md", Name: "01_deploy", Status: "pending", Dependencies: []string{"03_review"}, TaskType: TaskTypeInference, ModelID: "llama-3"},
+				},
+			},
+		},
+	}
+
+	plan := mapExecutionPlan(execPlan)
+	if err := plan.Validate(); err != nil {
+		t.Fatalf("mapped plan invalid: %v", err)
+	}
+
+	impl := plan.TaskByID("01_build/02_impl.md")
+	if impl == nil {
+		t.Fatal("impl task missing from plan")
+	}
+	if len(impl.Dependencies) != 0 {
+		t.Fatalf("impl dependencies = %v, want none (completed dependency dropped)", impl.Dependencies)
+	}
+
+	deploy := plan.TaskByID("02_ship/01_deploy.md")
+	if deploy == nil {
+		t.Fatal("deploy task missing from plan")
+	}
+	if deploy.TaskType != TaskTypeInference || deploy.ModelID != "llama-3" {
+		t.Fatalf("deploy task type = %q, model = %q; want the fest metadata", deploy.TaskType, deploy.ModelID)
+	}
+	if len(deploy.Dependencies) != 1 || deploy.Dependencies[0] != "01_build/03_review.md" {
+		t.Fatalf("deploy dependencies = %v, want [01_build/03_review.md]", deploy.Dependencies)
+	}
+
+	gate, seqID := plan.GateByID("01_build/03_review.md")
+	if gate == nil || seqID != "01_build" {
+		t.Fatalf("review gate = %+v in %q, want gate in 01_build", gate, seqID)
+	}
+	if gate.Kind != GateKindReview || len(gate.Dependencies) != 1 || gate.Dependencies[0] != "01_build/02_impl.md" {
+		t.Errorf("review gate = %+v, want review gate covering 01_build/02_impl.md", gate)
+	}
+}
+
+func TestMapExecutionPlan_TaskTypeFromMetadata(t *testing.T) {
+	plan := mapExecutionPlan(festival.ExecutionPlan{
+		FestivalID: "fest-types",
+		Sequences: []festival.ExecutionSequence{{
+			ID: "01_trade",
+			Tasks: []festival.ExecutionTask{
+				// The name suggests a trade; only the metadata counts.
+				{ID: "01_trade/01_swap_report.md", Name: "01_swap_report", Status: "pending", TaskType: TaskTypeInference},
+				{ID: "01_trade/02_rebalance.md", Name: "02_rebalance", Status: "pending", TaskType: TaskTypeTrade},
+				{ID: "01_trade/03_untyped.md", Name: "03_untyped", Status: "pending"},
+			},
+		}},
+	})
+
+	if task := plan.TaskByID("01_trade/01_swap_report.md"); task == nil || task.TaskType != TaskTypeInference || task.ModelID == "" {
+		t.Errorf("swap report = %+v, want an inference task with the default model", task)
+	}
+	if task := plan.TaskByID("01_trade/02_rebalance.md"); task == nil || task.TaskType != TaskTypeTrade {
+		t.Errorf("rebalance = %+v, want a trade task", task)
+	}
+
+	var verr *PlanValidationError
+	if err := plan.ValidateAgents(testRegistry(t)); !errors.As(err, &verr) ||
+		len(verr.Issues) != 1 || verr.Issues[0].Kind != PlanIssueMissingTaskType || verr.Issues[0].TaskID != "01_trade/03_untyped.md" {
+		t.Fatalf("ValidateAgents() = %v, want only the untyped task reported", err)
+	}
+}
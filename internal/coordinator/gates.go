@@ -2,10 +2,38 @@ package coordinator
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
+	"sync"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
 )
 
+// ErrGatePending is returned by a QualityGateEnforcer that cannot decide yet.
+// The monitor holds the task in StatusReview until the gate resolves it.
+var ErrGatePending = errors.New("gate decision pending")
+
+// GateChain is a QualityGateEnforcer that passes only if every enforcer in it
+// passes, evaluated in order.
+type GateChain []QualityGateEnforcer
+
+// Evaluate implements QualityGateEnforcer.
+func (c GateChain) Evaluate(ctx context.Context, taskID string) (bool, error) {
+	for _, gate := range c {
+		passed, err := gate.Evaluate(ctx, taskID)
+		if err != nil || !passed {
+			return passed, err
+		}
+	}
+	return true, nil
+}
+
 // SimpleGateEnforcer implements QualityGateEnforcer with basic checks.
 type SimpleGateEnforcer struct {
 	monitor ProgressMonitor
@@ -22,22 +50,401 @@ func (g *SimpleGateEnforcer) Evaluate(ctx context.Context, taskID string) (bool,
 		return false, fmt.Errorf("evaluate gate for task %s: %w", taskID, err)
 	}
 
-	// Quality gate tasks themselves always pass.
+	// Quality gate tasks are decided by SequenceGates, never by an agent
+	// claiming them complete.
 	if isQualityGateTask(taskID) {
-		return true, nil
+		return false, nil
 	}
 
 	// Individual implementation tasks can complete freely.
-	// Sequence-level gates are enforced by the coordinator.
+	// Sequence-level gates are enforced by SequenceGates.
 	return true, nil
 }
 
 func isQualityGateTask(taskID string) bool {
-	return strings.Contains(taskID, "testing") ||
-		strings.Contains(taskID, "review") ||
-		strings.Contains(taskID, "iterate") ||
-		strings.Contains(taskID, "fest_commit")
+	return gateKind(taskID) != ""
+}
+
+// gateKind classifies a fest gate task by name, returning "" for tasks that
+// are not gates.
+func gateKind(name string) string {
+	for _, kind := range []string{GateKindTesting, GateKindReview, GateKindIterate, GateKindFestCommit} {
+		if strings.Contains(name, kind) {
+			return kind
+		}
+	}
+	return ""
+}
+
+// GateDecision is the outcome of a sequence gate.
+type GateDecision string
+
+const (
+	GatePending GateDecision = "pending"
+	GatePassed  GateDecision = "passed"
+	GateFailed  GateDecision = "failed"
+)
+
+// GateCheck decides a gate once every task it covers has completed.
+type GateCheck interface {
+	// Check returns whether the gate passes and, if not, why.
+	Check(ctx context.Context, gate PlanGate, taskIDs []string) (passed bool, reason string, err error)
+}
+
+// GateCheckFunc adapts a function to the GateCheck interface.
+type GateCheckFunc func(ctx context.Context, gate PlanGate, taskIDs []string) (bool, string, error)
+
+// Check calls f.
+func (f GateCheckFunc) Check(ctx context.Context, gate PlanGate, taskIDs []string) (bool, string, error) {
+	return f(ctx, gate, taskIDs)
+}
+
+// NewResultGateCheck returns a GateCheck that passes once every task the gate
+// covers has a completed result that passes outputs' validators. outputs may
+// be nil, in which case any completed result passes.
+func NewResultGateCheck(results ResultLookup, outputs *OutputGate) GateCheck {
+	return GateCheckFunc(func(ctx context.Context, gate PlanGate, taskIDs []string) (bool, string, error) {
+		if err := ctx.Err(); err != nil {
+			return false, "", fmt.Errorf("check gate %s: %w", gate.ID, err)
+		}
+		for _, id := range taskIDs {
+			result, ok := results.Result(id)
+			if !ok || result.Status != "completed" {
+				return false, fmt.Sprintf("task %s has no completed result", id), nil
+			}
+			if outputs == nil {
+				continue
+			}
+			if err := outputs.Check(result); err != nil {
+				return false, fmt.Sprintf("task %s output invalid: %v", id, err), nil
+			}
+		}
+		return true, "", nil
+	})
 }
 
-// Compile-time interface compliance check.
-var _ QualityGateEnforcer = (*SimpleGateEnforcer)(nil)
+// QualityGatePayload is the payload for a quality_gate message announcing a
+// sequence gate decision.
+type QualityGatePayload struct {
+	GateID     string       `json:"gate_id"`
+	SequenceID string       `json:"sequence_id"`
+	Kind       string       `json:"kind"`
+	Decision   GateDecision `json:"decision"`
+	Reason     string       `json:"reason,omitempty"`
+	TaskIDs    []string     `json:"task_ids"`
+}
+
+// SequenceGates enforces the gates in a plan's sequences. A gate stays
+// pending until every task it covers is complete or paid, fails as soon as
+// one of them fails, and otherwise is decided by the GateCheck registered
+// for its kind. Gates of kinds without a check fail closed once their tasks
+// complete. Each decision is published once as a quality_gate envelope.
+type SequenceGates struct {
+	monitor   ProgressMonitor
+	publisher hcs.MessagePublisher
+	topicID   hiero.TopicID
+	logger    *slog.Logger
+
+	mu        sync.Mutex
+	plan      Plan
+	checks    map[string]GateCheck
+	decisions map[string]GateDecision
+	seqNum    uint64
+}
+
+// NewSequenceGates creates a gate keeper that reads task states from monitor
+// and publishes decisions to topicID.
+func NewSequenceGates(monitor ProgressMonitor, publisher hcs.MessagePublisher, topicID hiero.TopicID) *SequenceGates {
+	return &SequenceGates{
+		monitor:   monitor,
+		publisher: publisher,
+		topicID:   topicID,
+		logger:    slog.Default(),
+		checks:    make(map[string]GateCheck),
+		decisions: make(map[string]GateDecision),
+	}
+}
+
+// SetCheck registers the check that decides gates of the given kind. Gates of
+// a kind with no check fail.
+func (g *SequenceGates) SetCheck(kind string, check GateCheck) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.checks[kind] = check
+}
+
+// SetPlan sets the plan whose gates are enforced. Decisions for gates that
+// remain in the new plan are kept.
+func (g *SequenceGates) SetPlan(plan Plan) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.plan = plan
+}
+
+// Decision returns the current decision for a gate.
+func (g *SequenceGates) Decision(gateID string) GateDecision {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if d, ok := g.decisions[gateID]; ok {
+		return d
+	}
+	return GatePending
+}
+
+// Start re-evaluates pending gates every interval until the context is
+// cancelled, so decisions are published even for gates nothing depends on.
+func (g *SequenceGates) Start(ctx context.Context, interval time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("sequence gates start: %w", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			g.EvaluateAll(ctx)
+		}
+	}
+}
+
+// EvaluateAll evaluates every pending gate in the plan.
+func (g *SequenceGates) EvaluateAll(ctx context.Context) {
+	g.mu.Lock()
+	var ids []string
+	for _, seq := range g.plan.Sequences {
+		for _, gate := range seq.Gates {
+			ids = append(ids, gate.ID)
+		}
+	}
+	g.mu.Unlock()
+
+	for _, id := range ids {
+		g.evaluate(ctx, id)
+	}
+}
+
+// gateState reports the dependency state of a gate ID. ok is false when
+// depID is not a gate in the plan.
+func (g *SequenceGates) gateState(ctx context.Context, depID string) (dependencyState, bool) {
+	g.mu.Lock()
+	gate, _ := g.plan.GateByID(depID)
+	g.mu.Unlock()
+	if gate == nil {
+		return dependencyWaiting, false
+	}
+	return decisionDependency(g.evaluate(ctx, depID)), true
+}
+
+// sequenceState reports whether the gates of depID's sequence allow
+// dependentID to run. Dependencies within the same sequence, and on tasks in
+// sequences without gates, are always satisfied here.
+func (g *SequenceGates) sequenceState(ctx context.Context, dependentID, depID string) dependencyState {
+	g.mu.Lock()
+	depSeq := g.sequenceOfLocked(depID)
+	var gateIDs []string
+	if depSeq != nil && depSeq.ID != g.sequenceIDLocked(dependentID) {
+		for _, gate := range depSeq.Gates {
+			gateIDs = append(gateIDs, gate.ID)
+		}
+	}
+	g.mu.Unlock()
+
+	state := dependencySatisfied
+	for _, id := range gateIDs {
+		switch decisionDependency(g.evaluate(ctx, id)) {
+		case dependencyFailed:
+			return dependencyFailed
+		case dependencyWaiting:
+			state = dependencyWaiting
+		}
+	}
+	return state
+}
+
+// evaluate returns the gate's decision, deciding it if every covered task
+// has finished and publishing the decision when it is first made.
+func (g *SequenceGates) evaluate(ctx context.Context, gateID string) GateDecision {
+	return g.evaluateGate(ctx, gateID, make(map[string]bool))
+}
+
+// evaluateGate implements evaluate. A gate may cover other gates, as when a
+// fest review gate follows the sequence's testing gate; those are resolved
+// by their own decision, and visiting guards against gates covering each
+// other.
+func (g *SequenceGates) evaluateGate(ctx context.Context, gateID string, visiting map[string]bool) GateDecision {
+	if visiting[gateID] {
+		return GatePending
+	}
+	visiting[gateID] = true
+	defer delete(visiting, gateID)
+
+	g.mu.Lock()
+	if d, ok := g.decisions[gateID]; ok {
+		g.mu.Unlock()
+		return d
+	}
+	gatePtr, seqID := g.plan.GateByID(gateID)
+	if gatePtr == nil {
+		g.mu.Unlock()
+		return GatePending
+	}
+	gate := *gatePtr
+	taskIDs := g.coveredLocked(gate, seqID)
+	check := g.checks[gate.Kind]
+	g.mu.Unlock()
+
+	decision, reason := GatePending, ""
+	var tasks []string // covered IDs that are tasks rather than gates
+	for _, id := range taskIDs {
+		if g.isGate(id) {
+			switch g.evaluateGate(ctx, id, visiting) {
+			case GateFailed:
+				decision, reason = GateFailed, fmt.Sprintf("gate %s failed", id)
+			case GatePending:
+				if decision != GateFailed {
+					return GatePending
+				}
+			}
+			continue
+		}
+		tasks = append(tasks, id)
+		status, err := g.monitor.TaskState(id)
+		switch {
+		case err != nil:
+			return GatePending
+		case status == StatusFailed:
+			decision, reason = GateFailed, fmt.Sprintf("task %s failed", id)
+		case status != StatusComplete && status != StatusPaid && decision != GateFailed:
+			return GatePending
+		}
+	}
+
+	if decision == GatePending {
+		decision = GatePassed
+		if check == nil {
+			decision, reason = GateFailed, fmt.Sprintf("no check registered for gate kind %q", gate.Kind)
+		} else {
+			passed, why, err := check.Check(ctx, gate, tasks)
+			if err != nil {
+				g.logger.Warn("gate check error, will retry", "gate_id", gateID, "kind", gate.Kind, "error", err)
+				return GatePending
+			}
+			if !passed {
+				decision, reason = GateFailed, why
+			}
+		}
+	}
+
+	g.mu.Lock()
+	if d, ok := g.decisions[gateID]; ok {
+		// Decided concurrently; keep the first decision.
+		g.mu.Unlock()
+		return d
+	}
+	g.decisions[gateID] = decision
+	g.seqNum++
+	seqNum := g.seqNum
+	g.mu.Unlock()
+
+	g.logger.Info("sequence gate decided",
+		"gate_id", gateID, "sequence_id", seqID, "kind", gate.Kind, "decision", decision, "reason", reason)
+	g.publishDecision(ctx, seqNum, QualityGatePayload{
+		GateID:     gateID,
+		SequenceID: seqID,
+		Kind:       gate.Kind,
+		Decision:   decision,
+		Reason:     reason,
+		TaskIDs:    taskIDs,
+	})
+	return decision
+}
+
+// isGate reports whether id names a gate in the plan.
+func (g *SequenceGates) isGate(id string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	gate, _ := g.plan.GateByID(id)
+	return gate != nil
+}
+
+// publishDecision emits a quality_gate envelope for a gate decision.
+func (g *SequenceGates) publishDecision(ctx context.Context, seqNum uint64, decision QualityGatePayload) {
+	if g.publisher == nil {
+		return
+	}
+	payload, err := json.Marshal(decision)
+	if err != nil {
+		g.logger.Warn("failed to marshal gate decision", "gate_id", decision.GateID, "error", err)
+		return
+	}
+	env := hcs.Envelope{
+		Type:        hcs.MessageTypeQualityGate,
+		Sender:      "coordinator",
+		TaskID:      decision.GateID,
+		SequenceNum: seqNum,
+		Timestamp:   time.Now(),
+		Payload:     payload,
+	}
+	if err := g.publisher.Publish(ctx, g.topicID, env); err != nil {
+		g.logger.Warn("failed to publish gate decision", "gate_id", decision.GateID, "error", err)
+	}
+}
+
+// coveredLocked returns the task IDs a gate checks. g.mu must be held.
+func (g *SequenceGates) coveredLocked(gate PlanGate, seqID string) []string {
+	if len(gate.Dependencies) > 0 {
+		return append([]string(nil), gate.Dependencies...)
+	}
+	var ids []string
+	for _, seq := range g.plan.Sequences {
+		if seq.ID != seqID {
+			continue
+		}
+		for _, task := range seq.Tasks {
+			ids = append(ids, task.ID)
+		}
+	}
+	return ids
+}
+
+// sequenceOfLocked returns the sequence containing a task. g.mu must be held.
+func (g *SequenceGates) sequenceOfLocked(taskID string) *PlanSequence {
+	for i := range g.plan.Sequences {
+		for _, task := range g.plan.Sequences[i].Tasks {
+			if task.ID == taskID {
+				return &g.plan.Sequences[i]
+			}
+		}
+	}
+	return nil
+}
+
+// sequenceIDLocked returns the ID of the sequence containing a task, or ""
+// if the task is not in the plan. g.mu must be held.
+func (g *SequenceGates) sequenceIDLocked(taskID string) string {
+	if seq := g.sequenceOfLocked(taskID); seq != nil {
+		return seq.ID
+	}
+	return ""
+}
+
+func decisionDependency(d GateDecision) dependencyState {
+	switch d {
+	case GatePassed:
+		return dependencySatisfied
+	case GateFailed:
+		return dependencyFailed
+	default:
+		return dependencyWaiting
+	}
+}
+
+// Compile-time interface compliance checks.
+var (
+	_ QualityGateEnforcer = (*SimpleGateEnforcer)(nil)
+	_ QualityGateEnforcer = GateChain(nil)
+)
@@ -0,0 +1,337 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/festival"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+)
+
+// gatedPlan has a build sequence gated by a testing gate and a ship sequence
+// with one task behind the gate and one depending on a build task directly.
+func gatedPlan() Plan {
+	return Plan{
+		FestivalID: "fest-gated",
+		Sequences: []PlanSequence{
+			{
+				ID:    "01_build",
+				Tasks: []PlanTask{{ID: "build", Name: "build", PaymentAmount: 1}},
+				Gates: []PlanGate{{ID: "build-tests", Name: "testing", Kind: GateKindTesting}},
+			},
+			{
+				ID: "02_ship",
+				Tasks: []PlanTask{
+					{ID: "deploy", Name: "deploy", PaymentAmount: 1, Dependencies: []string{"build-tests"}},
+					{ID: "announce", Name: "announce", PaymentAmount: 1, Dependencies: []string{"build"}},
+				},
+			},
+		},
+	}
+}
+
+func gateDecisions(t *testing.T, pub *capturePublisher) []QualityGatePayload {
+	t.Helper()
+	var decisions []QualityGatePayload
+	for _, msg := range pub.messages {
+		if msg.Type != hcs.MessageTypeQualityGate {
+			continue
+		}
+		var d QualityGatePayload
+		if err := json.Unmarshal(msg.Payload, &d); err != nil {
+			t.Fatalf("unmarshal quality gate: %v", err)
+		}
+		decisions = append(decisions, d)
+	}
+	return decisions
+}
+
+// passAllGates registers a check that passes every gate kind, leaving the
+// decision to the states of the covered tasks.
+func passAllGates(gates *SequenceGates) {
+	pass := GateCheckFunc(func(context.Context, PlanGate, []string) (bool, string, error) {
+		return true, "", nil
+	})
+	for _, kind := range []string{GateKindTesting, GateKindReview, GateKindIterate, GateKindFestCommit} {
+		gates.SetCheck(kind, pass)
+	}
+}
+
+func newGateFixture(t *testing.T, plan Plan) (*Monitor, *SequenceGates, *capturePublisher) {
+	t.Helper()
+	m := newCoordinatorFixture(t).monitor
+	for _, seq := range plan.Sequences {
+		for _, task := range seq.Tasks {
+			m.InitTask(task.ID)
+			m.MarkAssigned(task.ID)
+		}
+	}
+	pub := &capturePublisher{}
+	gates := NewSequenceGates(m, pub, hiero.TopicID{Topic: 1})
+	gates.SetPlan(plan)
+	passAllGates(gates)
+	return m, gates, pub
+}
+
+func TestSequenceGates_PassesOnceTasksComplete(t *testing.T) {
+	m, gates, pub := newGateFixture(t, gatedPlan())
+
+	advanceTask(t, m, "build", StatusInProgress, StatusReview)
+	gates.EvaluateAll(context.Background())
+	if d := gates.Decision("build-tests"); d != GatePending {
+		t.Fatalf("decision before completion = %s, want pending", d)
+	}
+
+	advanceTask(t, m, "build", StatusComplete)
+	gates.EvaluateAll(context.Background())
+	gates.EvaluateAll(context.Background())
+
+	decisions := gateDecisions(t, pub)
+	if len(decisions) != 1 {
+		t.Fatalf("published %d decisions, want exactly 1", len(decisions))
+	}
+	d := decisions[0]
+	if d.GateID != "build-tests" || d.SequenceID != "01_build" || d.Kind != GateKindTesting || d.Decision != GatePassed {
+		t.Errorf("decision = %+v, want build-tests passed", d)
+	}
+	if len(d.TaskIDs) != 1 || d.TaskIDs[0] != "build" {
+		t.Errorf("covered tasks = %v, want [build]", d.TaskIDs)
+	}
+}
+
+func TestSequenceGates_FailsOnFailedTask(t *testing.T) {
+	m, gates, pub := newGateFixture(t, gatedPlan())
+
+	if err := m.failTask("build", false); err != nil {
+		t.Fatalf("failTask: %v", err)
+	}
+	gates.EvaluateAll(context.Background())
+
+	decisions := gateDecisions(t, pub)
+	if len(decisions) != 1 || decisions[0].Decision != GateFailed || decisions[0].Reason == "" {
+		t.Fatalf("decisions = %+v, want one failed decision with a reason", decisions)
+	}
+}
+
+func TestSequenceGates_CheckDecides(t *testing.T) {
+	m, gates, pub := newGateFixture(t, gatedPlan())
+	var checked []string
+	gates.SetCheck(GateKindTesting, GateCheckFunc(func(_ context.Context, gate PlanGate, taskIDs []string) (bool, string, error) {
+		checked = append(checked, gate.ID)
+		return false, "coverage below threshold", nil
+	}))
+
+	advanceTask(t, m, "build", StatusInProgress, StatusReview, StatusComplete)
+	gates.EvaluateAll(context.Background())
+
+	if len(checked) != 1 || checked[0] != "build-tests" {
+		t.Errorf("checked gates = %v, want [build-tests]", checked)
+	}
+	decisions := gateDecisions(t, pub)
+	if len(decisions) != 1 || decisions[0].Decision != GateFailed || decisions[0].Reason != "coverage below threshold" {
+		t.Fatalf("decisions = %+v, want failed with check reason", decisions)
+	}
+}
+
+func TestSequenceGates_FailsClosedWithoutCheck(t *testing.T) {
+	m := NewMonitor(nil, hiero.TopicID{}, nil)
+	m.InitTask("build")
+	m.MarkAssigned("build")
+	pub := &capturePublisher{}
+	gates := NewSequenceGates(m, pub, hiero.TopicID{Topic: 1})
+	gates.SetPlan(gatedPlan())
+
+	advanceTask(t, m, "build", StatusInProgress, StatusReview, StatusComplete)
+	gates.EvaluateAll(context.Background())
+
+	decisions := gateDecisions(t, pub)
+	if len(decisions) != 1 || decisions[0].Decision != GateFailed || decisions[0].Reason == "" {
+		t.Fatalf("decisions = %+v, want one failed decision for the unchecked kind", decisions)
+	}
+}
+
+// mapResults is a ResultLookup over fixed results.
+type mapResults map[string]TaskResultPayload
+
+func (r mapResults) Result(taskID string) (TaskResultPayload, bool) {
+	result, ok := r[taskID]
+	return result, ok
+}
+
+func TestResultGateCheck(t *testing.T) {
+	validators := make(ValidatorSet)
+	validators.Add(TaskTypeInference, MinLengthValidator{Min: 10})
+	outputs := NewOutputGate(validators)
+	outputs.SetPlan(Plan{Sequences: []PlanSequence{{ID: "seq-1", Tasks: []PlanTask{
+		{ID: "good", TaskType: TaskTypeInference},
+		{ID: "short", TaskType: TaskTypeInference},
+		{ID: "failed", TaskType: TaskTypeInference},
+	}}}})
+	results := mapResults{
+		"good":   {TaskID: "good", Status: "completed", Output: "a long enough answer"},
+		"short":  {TaskID: "short", Status: "completed", Output: "no"},
+		"failed": {TaskID: "failed", Status: "failed"},
+	}
+	check := NewResultGateCheck(results, outputs)
+	gate := PlanGate{ID: "gate-1", Kind: GateKindTesting}
+
+	tests := []struct {
+		name    string
+		taskIDs []string
+		want    bool
+	}{
+		{"valid results pass", []string{"good"}, true},
+		{"invalid output fails", []string{"good", "short"}, false},
+		{"failed result fails", []string{"failed"}, false},
+		{"missing result fails", []string{"missing"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passed, reason, err := check.Check(context.Background(), gate, tt.taskIDs)
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if passed != tt.want || (!passed && reason == "") {
+				t.Errorf("Check(%v) = %v, %q; want %v with a reason on failure", tt.taskIDs, passed, reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestSequenceGates_ChainedFestGates(t *testing.T) {
+	plan := mapExecutionPlan(festival.ExecutionPlan{
+		FestivalID: "fest-chained",
+		Sequences: []festival.ExecutionSequence{
+			{
+				ID: "01_build",
+				Tasks: []festival.ExecutionTask{
+					{ID: "01_build/01_impl.md", Name: "01_impl", Status: "pending", TaskType: TaskTypeInference},
+					{ID: "01_build/02_testing.md", Name: "02_testing", Status: "pending", IsGate: true, Dependencies: []string{"01_impl"}},
+					{ID: "01_build/03_review.md", Name: "03_review", Status: "pending", IsGate: true, Dependencies: []string{"02_testing"}},
+				},
+			},
+			{
+				ID: "02_ship",
+				Tasks: []festival.ExecutionTask{
+					{ID: "02_ship/01_deploy.md", Name: "01_deploy", Status: "pending", Dependencies: []string{"03_review"}, TaskType: TaskTypeInference},
+				},
+			},
+		},
+	})
+	if err := plan.Validate(); err != nil {
+		t.Fatalf("mapped plan invalid: %v", err)
+	}
+	m, gates, pub := newGateFixture(t, plan)
+
+	gates.EvaluateAll(context.Background())
+	if d := gates.Decision("01_build/03_review.md"); d != GatePending {
+		t.Fatalf("review decision before completion = %s, want pending", d)
+	}
+
+	advanceTask(t, m, "01_build/01_impl.md", StatusInProgress, StatusReview, StatusComplete)
+	if state, ok := gates.gateState(context.Background(), "01_build/03_review.md"); !ok || state != dependencySatisfied {
+		t.Fatalf("review gate state = %v, %v; want satisfied", state, ok)
+	}
+
+	decisions := gateDecisions(t, pub)
+	if len(decisions) != 2 || decisions[0].GateID != "01_build/02_testing.md" || decisions[1].GateID != "01_build/03_review.md" {
+		t.Fatalf("decisions = %+v, want testing then review", decisions)
+	}
+	for _, d := range decisions {
+		if d.Decision != GatePassed {
+			t.Errorf("gate %s = %s, want passed", d.GateID, d.Decision)
+		}
+	}
+}
+
+func TestAssignTasks_HoldsTasksBehindGates(t *testing.T) {
+	plan := gatedPlan()
+	m := NewMonitor(nil, hiero.TopicID{}, nil)
+	a := NewAssigner(&mockPublisher{}, hiero.TopicID{Topic: 1}, []string{"agent-1"})
+	a.SetMonitor(m, 5*time.Millisecond)
+	gatePub := &capturePublisher{}
+	gates := NewSequenceGates(m, gatePub, hiero.TopicID{Topic: 1})
+	passAllGates(gates)
+	a.SetGates(gates)
+
+	type result struct {
+		ids []string
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		ids, err := a.AssignTasks(context.Background(), plan)
+		done <- result{ids, err}
+	}()
+
+	waitForAssignment(t, a, "build")
+	advanceTask(t, m, "build", StatusInProgress, StatusReview)
+	time.Sleep(25 * time.Millisecond)
+	if a.Assignment("deploy") != "" || a.Assignment("announce") != "" {
+		t.Fatal("gated tasks dispatched before the gate passed")
+	}
+
+	advanceTask(t, m, "build", StatusComplete)
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			t.Fatalf("AssignTasks returned error: %v", res.err)
+		}
+		if len(res.ids) != 3 {
+			t.Fatalf("assigned IDs = %v, want all three tasks", res.ids)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("AssignTasks did not return after the gate passed")
+	}
+
+	if decisions := gateDecisions(t, gatePub); len(decisions) != 1 || decisions[0].Decision != GatePassed {
+		t.Errorf("decisions = %+v, want one passed decision", decisions)
+	}
+}
+
+func TestAssignTasks_BlocksTasksBehindFailedGate(t *testing.T) {
+	plan := gatedPlan()
+	m := NewMonitor(nil, hiero.TopicID{}, nil)
+	a := NewAssigner(&mockPublisher{}, hiero.TopicID{Topic: 1}, []string{"agent-1"})
+	a.SetMonitor(m, 5*time.Millisecond)
+	gates := NewSequenceGates(m, &capturePublisher{}, hiero.TopicID{Topic: 1})
+	gates.SetCheck(GateKindTesting, GateCheckFunc(func(context.Context, PlanGate, []string) (bool, string, error) {
+		return false, "tests failed", nil
+	}))
+	a.SetGates(gates)
+
+	done := make(chan []string, 1)
+	go func() {
+		ids, _ := a.AssignTasks(context.Background(), plan)
+		done <- ids
+	}()
+
+	waitForAssignment(t, a, "build")
+	advanceTask(t, m, "build", StatusInProgress, StatusReview, StatusComplete)
+
+	select {
+	case ids := <-done:
+		if len(ids) != 1 || ids[0] != "build" {
+			t.Fatalf("assigned IDs = %v, want only [build]", ids)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("AssignTasks did not return after the gate failed")
+	}
+	if gates.Decision("build-tests") != GateFailed {
+		t.Errorf("decision = %s, want failed", gates.Decision("build-tests"))
+	}
+}
+
+func TestAssignTasks_GatesRequireGateKeeper(t *testing.T) {
+	a := NewAssigner(&mockPublisher{}, hiero.TopicID{Topic: 1}, []string{"agent-1"})
+	a.SetMonitor(NewMonitor(nil, hiero.TopicID{}, nil), 0)
+
+	if _, err := a.AssignTasks(context.Background(), gatedPlan()); err == nil {
+		t.Fatal("expected error for gated plan without gate keeper")
+	}
+}
@@ -0,0 +1,121 @@
+package coordinator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/sim"
+)
+
+// coordinatorFixture wires an assigner and monitor over a registry of
+// interchangeable inference agents, publishing assignments to pub.
+type coordinatorFixture struct {
+	pub      *mockPublisher
+	assigner *Assigner
+	monitor  *Monitor
+}
+
+// newCoordinatorFixture registers the given inference agents, or
+// inference-001 and inference-002 when none are given.
+func newCoordinatorFixture(t *testing.T, agentIDs ...string) *coordinatorFixture {
+	t.Helper()
+	if len(agentIDs) == 0 {
+		agentIDs = []string{"inference-001", "inference-002"}
+	}
+	agents := make([]AgentInfo, 0, len(agentIDs))
+	for _, id := range agentIDs {
+		agents = append(agents, AgentInfo{ID: id, TaskTypes: []string{TaskTypeInference}})
+	}
+	registry, err := NewAgentRegistry(agents)
+	if err != nil {
+		t.Fatalf("NewAgentRegistry: %v", err)
+	}
+
+	f := &coordinatorFixture{pub: &mockPublisher{}}
+	f.assigner = NewAssigner(f.pub, hiero.TopicID{Topic: 1}, nil)
+	f.assigner.SetRegistry(registry)
+	f.monitor = NewMonitor(nil, hiero.TopicID{Topic: 2}, nil)
+	f.assigner.SetMonitor(f.monitor, time.Millisecond)
+	return f
+}
+
+// singleTaskPlan returns a plan with one inference task, task-1.
+func singleTaskPlan(festivalID string) Plan {
+	return Plan{
+		FestivalID: festivalID,
+		Sequences: []PlanSequence{{ID: "seq-1", Tasks: []PlanTask{
+			{ID: "task-1", TaskType: TaskTypeInference, PaymentAmount: 1},
+		}}},
+	}
+}
+
+// testPaymentOptions selects the optional parts of a test payment manager.
+type testPaymentOptions struct {
+	agents      int            // associated agent accounts
+	batchSize   int            // batch payments when above zero
+	batchWindow time.Duration  // flush window for batched payments
+	escrow      bool           // fund an escrow account and escrow payments
+	transfer    *flakyTransfer // single transfers go through it when set
+}
+
+// newTestPayment returns a payment manager on a simulated network with a
+// funded treasury, and the agent accounts associated with its token.
+func newTestPayment(t *testing.T, opts testPaymentOptions) (*Payment, *sim.Network, *mockPublisher, []hiero.AccountID) {
+	t.Helper()
+	ctx := context.Background()
+	network := sim.NewNetwork()
+	treasury := network.CreateAccount()
+	tokenID, err := network.CreateFungibleToken(ctx, hts.TokenConfig{
+		Name: "Agent Payment Token", Symbol: "APT", InitialSupply: 10_000, TreasuryAccountID: treasury,
+	})
+	if err != nil {
+		t.Fatalf("CreateFungibleToken() error = %v", err)
+	}
+	agents := make([]hiero.AccountID, opts.agents)
+	for i := range agents {
+		agents[i] = network.CreateAccount()
+		if err := network.AssociateToken(ctx, tokenID, agents[i]); err != nil {
+			t.Fatalf("AssociateToken() error = %v", err)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.PaymentTokenID = tokenID
+	cfg.TreasuryAccountID = treasury
+	cfg.PaymentBatchSize = opts.batchSize
+	cfg.PaymentBatchWindow = opts.batchWindow
+	if opts.escrow {
+		cfg.EscrowAccountID = network.CreateAccount()
+		cfg.ReconcileGrace = 0
+		if err := network.AssociateToken(ctx, tokenID, cfg.EscrowAccountID); err != nil {
+			t.Fatalf("AssociateToken() error = %v", err)
+		}
+		if _, err := network.Transfer(ctx, hts.TransferRequest{
+			TokenID: tokenID, FromAccountID: treasury, ToAccountID: cfg.EscrowAccountID, Amount: 1_000, Memo: "fund escrow",
+		}); err != nil {
+			t.Fatalf("Transfer() error = %v", err)
+		}
+	}
+
+	var transfer hts.TokenTransfer = network
+	if opts.transfer != nil {
+		opts.transfer.network = network
+		transfer = opts.transfer
+	}
+	pub := &mockPublisher{}
+	p := NewPayment(transfer, pub, cfg)
+	if opts.batchSize > 0 {
+		// No balance precheck, so a batch can reach an unassociated recipient.
+		p.SetBatchTransfer(network)
+	} else {
+		p.SetBalances(network)
+	}
+	if opts.escrow {
+		p.SetEscrow(network, network)
+	}
+	return p, network, pub, agents
+}
@@ -2,6 +2,9 @@ package coordinator
 
 import (
 	"context"
+	"time"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/pkg/creclient"
 )
 
 // TaskAssigner reads a festival plan and assigns tasks to agents via HCS.
@@ -41,3 +44,40 @@ type PaymentManager interface {
 	// PaymentStatus returns the payment status for a task.
 	PaymentStatus(taskID string) (PaymentState, error)
 }
+
+// PaymentEscrow commits task payments on chain before the work starts.
+type PaymentEscrow interface {
+	// EscrowForTask schedules the payment for a task to the assigned agent.
+	// The transfer executes only when PayForTask releases it, and the
+	// schedule expires after expiry, or the network default if it is zero.
+	EscrowForTask(ctx context.Context, taskID string, agentID string, amount int64, expiry time.Duration) error
+
+	// CancelEscrow deletes a task's scheduled payment that was not released.
+	CancelEscrow(ctx context.Context, taskID string) error
+}
+
+// AssignmentEscrow escrows a task's payment as part of assigning it.
+type AssignmentEscrow interface {
+	// EscrowAssignment commits the task's payment to the agent about to be
+	// assigned it. It is called before the assignment is published.
+	EscrowAssignment(ctx context.Context, taskID string, agentID string) error
+}
+
+// AssignmentLookup resolves which agent a task was assigned to.
+type AssignmentLookup interface {
+	// Assignment returns the agent ID assigned to a task, or empty string if unassigned.
+	Assignment(taskID string) string
+}
+
+// ResultLookup resolves the latest result an agent reported for a task.
+type ResultLookup interface {
+	// Result returns the stored result for a task, if any.
+	Result(taskID string) (TaskResultPayload, bool)
+}
+
+// RiskEvaluator decides whether a DeFi task may be dispatched. It is
+// implemented by the CRE Risk Router client.
+type RiskEvaluator interface {
+	// EvaluateRisk returns the risk decision for a requested trade.
+	EvaluateRisk(ctx context.Context, req creclient.RiskRequest) (creclient.RiskDecision, error)
+}
@@ -0,0 +1,146 @@
+package coordinator
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+)
+
+// paymentMemoPrefix starts the memo of every payment transfer; the task ID
+// follows it.
+const paymentMemoPrefix = "payment:task:"
+
+// paymentMemo returns the transfer memo for a task's payment.
+func paymentMemo(taskID string) string {
+	return paymentMemoPrefix + taskID
+}
+
+// taskIDFromMemo returns the task a payment transfer memo names.
+func taskIDFromMemo(memo string) (string, bool) {
+	taskID, ok := strings.CutPrefix(memo, paymentMemoPrefix)
+	return taskID, ok && taskID != ""
+}
+
+// PaymentRecord is one payment attempt in the payment ledger. The record is
+// written when the attempt starts and rewritten as its transfer and
+// settlement notice complete, so the ledger keeps the transaction ID of a
+// transfer that reached consensus even if the settlement notice was never
+// published.
+type PaymentRecord struct {
+	TaskID  string `json:"task_id"`
+	Attempt int    `json:"attempt"`
+
+	// AgentID is the recipient's Hedera account ID.
+	AgentID string `json:"agent_id"`
+	TokenID string `json:"token_id"`
+	Amount  int64  `json:"amount"`
+	Memo    string `json:"memo"`
+
+	// State is pending until the transfer returns, then processed or failed.
+	// Permanent marks a failure that retrying cannot fix.
+	State     PaymentState `json:"state"`
+	Error     string       `json:"error,omitempty"`
+	Permanent bool         `json:"permanent,omitempty"`
+
+	// TransactionID and TxStatus come from the transfer receipt.
+	TransactionID string `json:"transaction_id,omitempty"`
+	TxStatus      string `json:"tx_status,omitempty"`
+
+	// ScheduleID is the escrow schedule the attempt released, if the task's
+	// payment was escrowed.
+	ScheduleID string `json:"schedule_id,omitempty"`
+
+	// Settlement is the payment_settled envelope published for the
+	// transfer, or SettlementError why it could not be.
+	Settlement      *hcs.Envelope `json:"settlement,omitempty"`
+	SettlementError string        `json:"settlement_error,omitempty"`
+
+	Time time.Time `json:"time"`
+}
+
+// PaymentRecords returns every payment attempt for a task, oldest first.
+func (p *Payment) PaymentRecords(taskID string) []PaymentRecord {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]PaymentRecord(nil), p.ledger[taskID]...)
+}
+
+// Ledger returns every payment attempt for every task, oldest first.
+func (p *Payment) Ledger() []PaymentRecord {
+	p.mu.RLock()
+	var records []PaymentRecord
+	for _, taskRecords := range p.ledger {
+		records = append(records, taskRecords...)
+	}
+	p.mu.RUnlock()
+
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Time.Before(records[j].Time) })
+	return records
+}
+
+// openRecord starts a ledger record for a new payment attempt.
+func (p *Payment) openRecord(taskID, agentID string, amount int64) PaymentRecord {
+	p.mu.Lock()
+	record := PaymentRecord{
+		TaskID:  taskID,
+		Attempt: len(p.ledger[taskID]) + 1,
+		AgentID: agentID,
+		TokenID: p.config.PaymentTokenID.String(),
+		Amount:  amount,
+		Memo:    paymentMemo(taskID),
+		State:   PaymentPending,
+		Time:    time.Now().UTC(),
+	}
+	p.ledger[taskID] = append(p.ledger[taskID], record)
+	p.mu.Unlock()
+
+	p.persistRecord(record)
+	return record
+}
+
+// updateRecord replaces the ledger entry for the record's attempt.
+func (p *Payment) updateRecord(record PaymentRecord) {
+	p.mu.Lock()
+	p.ledger[record.TaskID] = mergePaymentRecord(p.ledger[record.TaskID], record)
+	p.mu.Unlock()
+
+	p.persistRecord(record)
+}
+
+// errPaymentInterrupted ends attempts that were in flight when the
+// coordinator stopped.
+var errPaymentInterrupted = errors.New("payment interrupted by coordinator restart")
+
+// failRecord marks an attempt failed with the error that ended it.
+func (p *Payment) failRecord(record PaymentRecord, err error) {
+	record.State = PaymentFailed
+	record.Error = err.Error()
+	record.Permanent = permanentPaymentError(err)
+	if errors.Is(err, ErrNotAssociated) && record.Attempt <= associationLagAttempts {
+		// The mirror node may not have indexed a recent association yet.
+		record.Permanent = false
+	}
+	p.updateRecord(record)
+}
+
+func (p *Payment) persistRecord(record PaymentRecord) {
+	if p.store == nil {
+		return
+	}
+	if err := p.store.SavePaymentRecord(record); err != nil {
+		p.logger.Warn("failed to persist payment record",
+			"task_id", record.TaskID, "attempt", record.Attempt, "error", err)
+	}
+}
+
+// restoreLedger replaces the ledger with records recovered from a store.
+func (p *Payment) restoreLedger(records map[string][]PaymentRecord) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for taskID, taskRecords := range records {
+		p.ledger[taskID] = append([]PaymentRecord(nil), taskRecords...)
+	}
+}
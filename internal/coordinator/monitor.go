@@ -3,8 +3,11 @@ package coordinator
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"sync"
+	"time"
 
 	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
 
@@ -24,9 +27,20 @@ type Monitor struct {
 	subscriber   hcs.MessageSubscriber
 	topicID      hiero.TopicID
 	gateEnforcer QualityGateEnforcer
+	store        StateStore
+	watchdog     *Watchdog
+	events       *EventBus
+	logger       *slog.Logger
 
-	mu     sync.RWMutex
-	states map[string]TaskStatus
+	// Gate evaluations run outside mu, at most cap(gateSlots) at a time.
+	gateTimeout time.Duration
+	gateSlots   chan struct{}
+	gateWG      sync.WaitGroup
+
+	mu         sync.RWMutex
+	states     map[string]TaskStatus
+	evaluating map[string]bool // tasks with a gate evaluation in flight
+	recheck    map[string]bool // tasks to evaluate again if their gate is pending
 }
 
 // NewMonitor creates a new progress monitor.
@@ -35,8 +49,54 @@ func NewMonitor(subscriber hcs.MessageSubscriber, topicID hiero.TopicID, gate Qu
 		subscriber:   subscriber,
 		topicID:      topicID,
 		gateEnforcer: gate,
+		logger:       slog.Default(),
+		gateTimeout:  DefaultConfig().QualityGateTimeout,
+		gateSlots:    make(chan struct{}, DefaultConfig().QualityGateWorkers),
 		states:       make(map[string]TaskStatus),
+		evaluating:   make(map[string]bool),
+		recheck:      make(map[string]bool),
+	}
+}
+
+// SetGateLimits bounds quality gate evaluation: at most workers gates run at
+// once, and each is given timeout to decide before it counts as failed.
+// Non-positive values keep the defaults.
+func (m *Monitor) SetGateLimits(workers int, timeout time.Duration) {
+	if workers > 0 {
+		m.gateSlots = make(chan struct{}, workers)
 	}
+	if timeout > 0 {
+		m.gateTimeout = timeout
+	}
+}
+
+// SetEvents configures the event bus that status changes and gate decisions
+// are published to. The monitor also subscribes to it, moving tasks to
+// StatusPaid when their payment settles.
+func (m *Monitor) SetEvents(events *EventBus) {
+	m.events = events
+	events.Subscribe(func(e Event) {
+		if settled, ok := e.(PaymentSettled); ok {
+			m.markPaid(settled.TaskID)
+		}
+	})
+}
+
+// SetStore configures a state store that every status change is written to.
+func (m *Monitor) SetStore(store StateStore) {
+	m.store = store
+}
+
+// SetGate replaces the quality gate evaluated when a task is reported
+// complete.
+func (m *Monitor) SetGate(gate QualityGateEnforcer) {
+	m.gateEnforcer = gate
+}
+
+// SetWatchdog configures the watchdog whose task leases status updates renew.
+// Status updates from an agent that no longer holds a task's lease are ignored.
+func (m *Monitor) SetWatchdog(watchdog *Watchdog) {
+	m.watchdog = watchdog
 }
 
 // Start begins monitoring the HCS topic for status updates. Blocks until context is cancelled.
@@ -93,7 +153,22 @@ func (m *Monitor) AllTaskStates() map[string]TaskStatus {
 func (m *Monitor) InitTask(taskID string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.states[taskID] = StatusPending
+	m.setState(taskID, StatusPending)
+}
+
+// MarkAssigned records that a task has been published to an agent. Tasks not
+// yet tracked are registered first; tasks past pending are left unchanged.
+func (m *Monitor) MarkAssigned(taskID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, exists := m.states[taskID]
+	if !exists {
+		current = StatusPending
+	}
+	if CanTransition(current, StatusAssigned) {
+		m.setState(taskID, StatusAssigned)
+	}
 }
 
 func (m *Monitor) processMessage(ctx context.Context, msg hcs.Envelope) {
@@ -106,6 +181,12 @@ func (m *Monitor) processMessage(ctx context.Context, msg hcs.Envelope) {
 		return
 	}
 
+	if m.watchdog != nil && !m.watchdog.Renew(payload.TaskID, msg.Sender) {
+		m.logger.Warn("status update from agent not holding task lease, ignoring",
+			"task_id", payload.TaskID, "sender", msg.Sender, "new_status", payload.NewStatus)
+		return
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -115,18 +196,241 @@ func (m *Monitor) processMessage(ctx context.Context, msg hcs.Envelope) {
 	}
 
 	if payload.NewStatus == StatusComplete && m.gateEnforcer != nil {
-		passed, err := m.gateEnforcer.Evaluate(ctx, payload.TaskID)
-		if err != nil || !passed {
-			m.states[payload.TaskID] = StatusInProgress
-			return
+		// Completion is claimed, not granted: hold the task in review and
+		// let the gate decide outside the lock.
+		if currentStatus != StatusReview {
+			if err := Transition(currentStatus, StatusReview); err != nil {
+				return
+			}
+			m.setState(payload.TaskID, StatusReview)
 		}
+		m.startGateLocked(ctx, payload.TaskID)
+		return
 	}
 
 	if err := Transition(currentStatus, payload.NewStatus); err != nil {
 		return
 	}
 
-	m.states[payload.TaskID] = payload.NewStatus
+	m.setState(payload.TaskID, payload.NewStatus)
+}
+
+// recheckGate evaluates the quality gate again for a task held in review,
+// once something the gate was waiting on, such as the task's result, has
+// arrived. Tasks not in review are left alone.
+func (m *Monitor) recheckGate(ctx context.Context, taskID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.gateEnforcer == nil || m.states[taskID] != StatusReview {
+		return
+	}
+	if m.evaluating[taskID] {
+		m.recheck[taskID] = true
+		return
+	}
+	m.startGateLocked(ctx, taskID)
+}
+
+// startGateLocked starts a gate evaluation for a task in review unless one is
+// already in flight. m.mu must be held.
+func (m *Monitor) startGateLocked(ctx context.Context, taskID string) {
+	if m.evaluating[taskID] {
+		return
+	}
+	m.evaluating[taskID] = true
+	m.gateWG.Add(1)
+	go m.evaluateGate(ctx, taskID)
+}
+
+// evaluateGate runs the quality gate for a task in review once a worker slot
+// is free, then completes the task or sends it back to in progress. Gates
+// that report ErrGatePending leave the task in review for resolveReview or
+// recheckGate; a recheck requested while the gate ran evaluates it again.
+func (m *Monitor) evaluateGate(ctx context.Context, taskID string) {
+	defer m.gateWG.Done()
+	released := false
+	defer func() {
+		if released {
+			return
+		}
+		m.mu.Lock()
+		delete(m.evaluating, taskID)
+		m.mu.Unlock()
+	}()
+
+	var passed bool
+	var err error
+	var gateCtx context.Context
+	for {
+		select {
+		case m.gateSlots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		m.mu.Lock()
+		delete(m.recheck, taskID)
+		m.mu.Unlock()
+
+		var cancel context.CancelFunc
+		gateCtx, cancel = context.WithTimeout(ctx, m.gateTimeout)
+		passed, err = m.gateEnforcer.Evaluate(gateCtx, taskID)
+		cancel()
+		<-m.gateSlots
+
+		if !errors.Is(err, ErrGatePending) {
+			break
+		}
+		m.mu.Lock()
+		again := m.recheck[taskID]
+		if !again {
+			// Released here rather than in the deferred func, so a recheck
+			// requested from now on starts a new evaluation.
+			delete(m.evaluating, taskID)
+			released = true
+		}
+		m.mu.Unlock()
+		if !again {
+			m.logger.Info("quality gate pending, task held in review", "task_id", taskID)
+			return
+		}
+	}
+	reason := ""
+	switch {
+	case err != nil:
+		passed = false
+		reason = err.Error()
+	case !passed:
+		reason = "quality gate rejected the task"
+	}
+	if err != nil && gateCtx.Err() == context.DeadlineExceeded {
+		reason = fmt.Sprintf("quality gate timed out after %s", m.gateTimeout)
+	}
+
+	if _, err := m.resolveReview(taskID, passed, reason); err != nil {
+		m.logger.Warn("failed to apply quality gate decision", "task_id", taskID, "error", err)
+	}
+}
+
+// applyTransition moves a task to a new status if the transition is valid,
+// without running quality gates. Used when folding replayed history, where
+// gate decisions have already been made.
+func (m *Monitor) applyTransition(taskID string, to TaskStatus) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, exists := m.states[taskID]
+	if !exists {
+		current = StatusPending
+	}
+	if err := Transition(current, to); err != nil {
+		return fmt.Errorf("task %s: %w", taskID, err)
+	}
+	m.setState(taskID, to)
+	return nil
+}
+
+// failTask moves a task to StatusFailed, unless it already is. With requeue
+// set it then moves the task straight back to StatusPending under the same
+// lock, so dependents waiting on it never observe the failure.
+func (m *Monitor) failTask(taskID string, requeue bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, exists := m.states[taskID]
+	if !exists {
+		current = StatusPending
+	}
+	if current != StatusFailed {
+		if err := Transition(current, StatusFailed); err != nil {
+			return fmt.Errorf("task %s: %w", taskID, err)
+		}
+		m.setState(taskID, StatusFailed)
+	}
+	if requeue {
+		m.setState(taskID, StatusPending)
+	}
+	return nil
+}
+
+// resolveReview applies a gate decision to a task held in StatusReview,
+// moving it to StatusComplete if passed and back to StatusInProgress
+// otherwise, and publishes a GateDecided event. It reports false, leaving the
+// task unchanged, when the task is no longer in review.
+func (m *Monitor) resolveReview(taskID string, passed bool, reason string) (bool, error) {
+	m.mu.Lock()
+	current, exists := m.states[taskID]
+	if !exists {
+		m.mu.Unlock()
+		return false, fmt.Errorf("resolve review for task %s: not tracked by monitor", taskID)
+	}
+	if current != StatusReview {
+		// The task moved on while the gate ran, e.g. it was failed or revoked.
+		m.mu.Unlock()
+		return false, nil
+	}
+	decision := GateDecided{TaskID: taskID, Passed: passed, Status: StatusComplete}
+	if !passed {
+		decision.Status = StatusInProgress
+		decision.Reason = reason
+	}
+	m.setState(taskID, decision.Status)
+	m.events.Publish(decision)
+	m.mu.Unlock()
+
+	m.logger.Info("quality gate decided",
+		"task_id", taskID, "passed", passed, "status", decision.Status, "reason", decision.Reason)
+	return true, nil
+}
+
+// reopenTask sends a task back to StatusInProgress after its output was
+// rejected. Paid and failed tasks are left unchanged.
+func (m *Monitor) reopenTask(taskID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, exists := m.states[taskID]
+	if !exists {
+		return fmt.Errorf("reopen task %s: not tracked by monitor", taskID)
+	}
+	switch current {
+	case StatusPaid, StatusFailed:
+		return fmt.Errorf("reopen task %s: task is %s", taskID, current)
+	}
+	m.setState(taskID, StatusInProgress)
+	return nil
+}
+
+// markPaid moves a completed task to StatusPaid.
+func (m *Monitor) markPaid(taskID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if current, exists := m.states[taskID]; exists && CanTransition(current, StatusPaid) {
+		m.setState(taskID, StatusPaid)
+	}
+}
+
+// setState records a status, publishes the change and writes it through to
+// the store. Callers must hold m.mu.
+func (m *Monitor) setState(taskID string, status TaskStatus) {
+	from := m.states[taskID]
+	m.states[taskID] = status
+	m.events.Publish(TaskStatusChanged{TaskID: taskID, From: from, To: status})
+	if m.store == nil {
+		return
+	}
+	if err := m.store.SaveTaskStatus(taskID, status); err != nil {
+		m.logger.Warn("failed to persist task status", "task_id", taskID, "status", status, "error", err)
+	}
+}
+
+// restore replaces tracked states with those recovered from a store.
+func (m *Monitor) restore(states map[string]TaskStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for taskID, status := range states {
+		m.states[taskID] = status
+	}
 }
 
 // Compile-time interface compliance check.
@@ -2,7 +2,10 @@ package coordinator
 
 import (
 	"context"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
 )
@@ -74,8 +77,8 @@ func TestSimpleGateEnforcer_Evaluate(t *testing.T) {
 		taskID string
 		want   bool
 	}{
-		{"quality gate task passes", "06_testing", true},
-		{"review gate passes", "07_review", true},
+		{"quality gate task is not granted", "06_testing", false},
+		{"review gate is not granted", "07_review", false},
 		{"implementation task passes", "03_implement_topic", true},
 	}
 
@@ -102,3 +105,140 @@ func TestSimpleGateEnforcer_ContextCancellation(t *testing.T) {
 		t.Error("expected error for cancelled context")
 	}
 }
+
+// blockingGate is a quality gate whose evaluations wait for release or for
+// their context to end.
+type blockingGate struct {
+	release chan bool
+	running chan string
+	active  atomic.Int32
+	peak    atomic.Int32
+}
+
+func newBlockingGate() *blockingGate {
+	return &blockingGate{release: make(chan bool), running: make(chan string, 16)}
+}
+
+func (g *blockingGate) Evaluate(ctx context.Context, taskID string) (bool, error) {
+	n := g.active.Add(1)
+	defer g.active.Add(-1)
+	for {
+		peak := g.peak.Load()
+		if n <= peak || g.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	g.running <- taskID
+	select {
+	case passed := <-g.release:
+		return passed, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// recordGateDecisions attaches an event bus to the monitor and collects the
+// gate decisions published on it. Read the result after m.events.Wait.
+func recordGateDecisions(m *Monitor) *[]GateDecided {
+	var decisions []GateDecided
+	bus := NewEventBus()
+	bus.Subscribe(func(e Event) {
+		if d, ok := e.(GateDecided); ok {
+			decisions = append(decisions, d)
+		}
+	})
+	m.SetEvents(bus)
+	return &decisions
+}
+
+func startedTask(t *testing.T, m *Monitor, taskID string) {
+	t.Helper()
+	m.InitTask(taskID)
+	m.MarkAssigned(taskID)
+	advanceTask(t, m, taskID, StatusInProgress)
+}
+
+func TestMonitor_GateRunsOutsideLock(t *testing.T) {
+	gate := newBlockingGate()
+	m := NewMonitor(nil, hiero.TopicID{}, gate)
+	results := recordGateDecisions(m)
+	startedTask(t, m, "task-1")
+
+	reportStatus(t, m, "task-1", StatusComplete)
+	<-gate.running
+	// The gate is still deciding; status reads must not block on it.
+	if status, _ := m.TaskState("task-1"); status != StatusReview {
+		t.Errorf("status while gate runs = %s, want review", status)
+	}
+	// A repeated completion does not start a second evaluation.
+	reportStatus(t, m, "task-1", StatusComplete)
+
+	gate.release <- true
+	m.gateWG.Wait()
+	m.events.Wait()
+	if status, _ := m.TaskState("task-1"); status != StatusComplete {
+		t.Errorf("status after pass = %s, want complete", status)
+	}
+	if results := *results; len(results) != 1 || !results[0].Passed || results[0].Status != StatusComplete {
+		t.Errorf("gate decisions = %+v, want one pass", results)
+	}
+}
+
+func TestMonitor_GateFailureAndTimeoutReopen(t *testing.T) {
+	gate := newBlockingGate()
+	m := NewMonitor(nil, hiero.TopicID{}, gate)
+	m.SetGateLimits(0, 20*time.Millisecond)
+	results := recordGateDecisions(m)
+	startedTask(t, m, "task-1")
+	startedTask(t, m, "task-2")
+
+	reportStatus(t, m, "task-1", StatusComplete)
+	<-gate.running
+	gate.release <- false
+	m.gateWG.Wait()
+
+	advanceTask(t, m, "task-2", StatusComplete) // never released: times out
+	<-gate.running
+	m.events.Wait()
+
+	for _, id := range []string{"task-1", "task-2"} {
+		if status, _ := m.TaskState(id); status != StatusInProgress {
+			t.Errorf("%s status = %s, want in_progress", id, status)
+		}
+	}
+	decisions := *results
+	if len(decisions) != 2 || decisions[0].Passed || decisions[1].Passed {
+		t.Fatalf("gate decisions = %+v, want two failures", decisions)
+	}
+	if !strings.Contains(decisions[1].Reason, "timed out") {
+		t.Errorf("timeout reason = %q", decisions[1].Reason)
+	}
+}
+
+func TestMonitor_GateWorkersBounded(t *testing.T) {
+	gate := newBlockingGate()
+	m := NewMonitor(nil, hiero.TopicID{}, gate)
+	m.SetGateLimits(2, time.Minute)
+	ids := []string{"task-1", "task-2", "task-3", "task-4", "task-5"}
+	for _, id := range ids {
+		startedTask(t, m, id)
+	}
+	for _, id := range ids {
+		reportStatus(t, m, id, StatusComplete)
+	}
+
+	for range ids {
+		<-gate.running
+		gate.release <- true
+	}
+	m.gateWG.Wait()
+
+	if peak := gate.peak.Load(); peak > 2 {
+		t.Errorf("peak concurrent evaluations = %d, want at most 2", peak)
+	}
+	for _, id := range ids {
+		if status, _ := m.TaskState(id); status != StatusComplete {
+			t.Errorf("%s status = %s, want complete", id, status)
+		}
+	}
+}
@@ -3,7 +3,9 @@ package coordinator
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"sync"
 	"time"
 
@@ -11,6 +13,21 @@ import (
 
 	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
 	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/schedule"
+)
+
+var (
+	// ErrInsufficientTreasury is returned when the treasury's token balance
+	// cannot cover a payment.
+	ErrInsufficientTreasury = errors.New("insufficient treasury balance")
+
+	// ErrNotAssociated is returned when the recipient account is not
+	// associated with the payment token and so cannot receive it.
+	ErrNotAssociated = errors.New("recipient not associated with payment token")
+
+	// ErrInvalidAccount is returned when the recipient is not a valid
+	// Hedera account ID.
+	ErrInvalidAccount = errors.New("invalid recipient account")
 )
 
 // PaymentSettledPayload is the HCS message payload for a payment settlement.
@@ -20,17 +37,35 @@ type PaymentSettledPayload struct {
 	Amount   int64  `json:"amount"`
 	TokenID  string `json:"token_id"`
 	TxStatus string `json:"tx_status"`
+
+	// TransactionID is the transfer that paid the task. Tasks settled in
+	// one batch share it.
+	TransactionID string `json:"transaction_id,omitempty"`
 }
 
 // Payment implements the PaymentManager interface.
 type Payment struct {
 	transferSvc hts.TokenTransfer
+	balances    hts.TokenBalances
 	publisher   hcs.MessagePublisher
 	config      Config
+	store       StateStore
+	events      *EventBus
+	logger      *slog.Logger
 
 	mu       sync.RWMutex
-	payments map[string]PaymentState // taskID -> payment state
+	payments map[string]PaymentState    // taskID -> payment state
+	ledger   map[string][]PaymentRecord // taskID -> payment attempts
+	escrows  map[string]TaskEscrow      // taskID -> latest escrow
 	seqNum   uint64
+
+	scheduler      schedule.ExpiringScheduleCreator
+	scheduleSigner schedule.ScheduleSigner
+
+	batcher    hts.BatchTokenTransfer
+	batchMu    sync.Mutex
+	batch      []queuedPayment
+	batchTimer *time.Timer
 }
 
 // NewPayment creates a new payment manager.
@@ -39,12 +74,46 @@ func NewPayment(transferSvc hts.TokenTransfer, publisher hcs.MessagePublisher, c
 		transferSvc: transferSvc,
 		publisher:   publisher,
 		config:      config,
+		logger:      slog.Default(),
 		payments:    make(map[string]PaymentState),
+		ledger:      make(map[string][]PaymentRecord),
+		escrows:     make(map[string]TaskEscrow),
 	}
 }
 
+// SetStore configures a state store that every payment state change is
+// written to. With a store set, a payment is only attempted once its pending
+// state is durable, so the double-payment guard survives restarts.
+func (p *Payment) SetStore(store StateStore) {
+	p.store = store
+}
+
+// SetBalances configures the token balance queries used to check, before
+// each transfer, that the recipient is associated with the payment token and
+// the treasury can cover the amount. Without it, those conditions surface
+// only as transfer errors.
+func (p *Payment) SetBalances(balances hts.TokenBalances) {
+	p.balances = balances
+}
+
+// SetEvents configures the event bus that settled payments are published to.
+func (p *Payment) SetEvents(events *EventBus) {
+	p.events = events
+}
+
 // PayForTask triggers a token transfer to the agent that completed the task.
+// A task whose payment is escrowed is paid by releasing the escrow instead.
+// When batching is enabled the payment is queued instead: a nil error means
+// it passed its checks, and its outcome is reported by the PaymentSettled
+// event and PaymentStatus once the batch settles. A task whose payment failed
+// is not paid again here: the PaymentRetrier retries it once it has checked
+// the chain for a transfer that executed despite the failure.
 func (p *Payment) PayForTask(ctx context.Context, taskID string, agentID string, amount int64) error {
+	return p.pay(ctx, taskID, agentID, amount, false)
+}
+
+// pay runs a payment attempt; retry allows a task whose payment failed.
+func (p *Payment) pay(ctx context.Context, taskID string, agentID string, amount int64, retry bool) error {
 	if err := ctx.Err(); err != nil {
 		return fmt.Errorf("pay for task %s to %s amount %d: %w", taskID, agentID, amount, err)
 	}
@@ -53,21 +122,45 @@ func (p *Payment) PayForTask(ctx context.Context, taskID string, agentID string,
 		return fmt.Errorf("pay for task %s to %s: amount must be positive, got %d", taskID, agentID, amount)
 	}
 
-	// Atomically check for double-payment and mark as pending.
-	p.mu.Lock()
-	existing := p.payments[taskID]
-	if existing == PaymentProcessed || existing == PaymentPending {
-		p.mu.Unlock()
-		return fmt.Errorf("pay for task %s to %s amount %d: already %s", taskID, agentID, amount, existing)
+	if err := p.begin(taskID, retry); err != nil {
+		return fmt.Errorf("pay for task %s to %s amount %d: %w", taskID, agentID, amount, err)
+	}
+
+	record := p.openRecord(taskID, agentID, amount)
+
+	p.mu.RLock()
+	escrow, escrowed := p.heldEscrowLocked(taskID)
+	p.mu.RUnlock()
+	if escrowed {
+		err := p.release(ctx, record, escrow)
+		if !errors.Is(err, errEscrowGone) {
+			if err != nil {
+				return fmt.Errorf("pay for task %s to %s amount %d: %w", taskID, agentID, amount, err)
+			}
+			return nil
+		}
+		p.logger.Warn("escrow schedule gone, paying from treasury", "task_id", taskID, "schedule_id", escrow.ScheduleID)
 	}
-	p.payments[taskID] = PaymentPending
-	p.mu.Unlock()
 
 	// Parse agent account ID.
 	agentAccountID, err := hiero.AccountIDFromString(agentID)
 	if err != nil {
-		p.setPaymentState(taskID, PaymentFailed)
-		return fmt.Errorf("pay for task %s to %s amount %d: parse agent account: %w", taskID, agentID, amount, err)
+		err = fmt.Errorf("parse agent account: %w: %v", ErrInvalidAccount, err)
+		p.failPayment(record, err)
+		return fmt.Errorf("pay for task %s to %s amount %d: %w", taskID, agentID, amount, err)
+	}
+
+	if p.batching() {
+		if err := p.enqueue(ctx, queuedPayment{record: record, account: agentAccountID}); err != nil {
+			p.failPayment(record, err)
+			return fmt.Errorf("pay for task %s to %s amount %d: %w", taskID, agentID, amount, err)
+		}
+		return nil
+	}
+
+	if err := p.precheck(ctx, agentAccountID, amount); err != nil {
+		p.failPayment(record, err)
+		return fmt.Errorf("pay for task %s to %s amount %d: %w", taskID, agentID, amount, err)
 	}
 
 	// Execute the token transfer.
@@ -76,21 +169,121 @@ func (p *Payment) PayForTask(ctx context.Context, taskID string, agentID string,
 		FromAccountID: p.config.TreasuryAccountID,
 		ToAccountID:   agentAccountID,
 		Amount:        amount,
-		Memo:          fmt.Sprintf("payment:task:%s", taskID),
+		Memo:          record.Memo,
 	})
 	if err != nil {
-		p.setPaymentState(taskID, PaymentFailed)
-		return fmt.Errorf("pay for task %s to %s amount %d: transfer: %w", taskID, agentID, amount, err)
+		if receipt != nil {
+			// Recorded so a retry can find the transfer if it did execute.
+			record.TransactionID = receipt.TransactionID.String()
+		}
+		err = fmt.Errorf("transfer: %w", err)
+		p.failPayment(record, err)
+		return fmt.Errorf("pay for task %s to %s amount %d: %w", taskID, agentID, amount, err)
+	}
+
+	if err := p.settle(ctx, record, receipt.TransactionID.String(), receipt.Status); err != nil {
+		return fmt.Errorf("pay for task %s to %s amount %d: publish settlement: %w", taskID, agentID, amount, err)
+	}
+	return nil
+}
+
+// begin atomically checks for double payment and marks the task's payment
+// pending. A failed payment may begin again only as a retry, since its
+// transfer may have executed. With a store set, the pending state is durable
+// before it returns.
+func (p *Payment) begin(taskID string, retry bool) error {
+	p.mu.Lock()
+	existing := p.payments[taskID]
+	if existing == PaymentProcessed || existing == PaymentPending || (existing == PaymentFailed && !retry) {
+		p.mu.Unlock()
+		return fmt.Errorf("already %s", existing)
 	}
+	p.payments[taskID] = PaymentPending
+	p.mu.Unlock()
 
-	// Mark as processed.
-	p.setPaymentState(taskID, PaymentProcessed)
+	if p.store != nil {
+		if err := p.store.SavePaymentState(taskID, PaymentPending); err != nil {
+			p.mu.Lock()
+			if existing == "" {
+				delete(p.payments, taskID)
+			} else {
+				p.payments[taskID] = existing
+			}
+			p.mu.Unlock()
+			return fmt.Errorf("persist pending state: %w", err)
+		}
+	}
+	return nil
+}
+
+// settle marks a payment attempt whose transfer reached consensus processed
+// and announces it. The transfer is recorded before anything else can fail,
+// so the ledger holds its transaction ID even if the settlement notice is
+// never published; the returned error is the publish failure, if any.
+func (p *Payment) settle(ctx context.Context, record PaymentRecord, txID, txStatus string) error {
+	record.State = PaymentProcessed
+	record.TransactionID = txID
+	record.TxStatus = txStatus
+	p.updateRecord(record)
+
+	p.setPaymentState(record.TaskID, PaymentProcessed)
+	p.events.Publish(PaymentSettled{
+		TaskID:        record.TaskID,
+		AgentID:       record.AgentID,
+		Amount:        record.Amount,
+		TxStatus:      txStatus,
+		TransactionID: txID,
+	})
 
 	// Publish settlement notification via HCS.
-	if err := p.publishSettlement(ctx, taskID, agentID, amount, receipt.Status); err != nil {
-		return fmt.Errorf("pay for task %s to %s amount %d: publish settlement: %w", taskID, agentID, amount, err)
+	env, err := p.publishSettlement(ctx, record)
+	if err != nil {
+		record.SettlementError = err.Error()
+		p.updateRecord(record)
+		return err
+	}
+	record.Settlement = &env
+	p.updateRecord(record)
+	return nil
+}
+
+// failPayment marks a payment attempt failed in both the payment state and
+// the ledger.
+func (p *Payment) failPayment(record PaymentRecord, err error) {
+	p.setPaymentState(record.TaskID, PaymentFailed)
+	p.failRecord(record, err)
+}
+
+// precheck verifies the recipient can receive the payment token and the
+// treasury holds enough of it. A failed query is logged and the transfer is
+// left to decide, since the balance source may lag or be unavailable.
+func (p *Payment) precheck(ctx context.Context, recipient hiero.AccountID, amount int64) error {
+	return p.precheckFrom(ctx, p.config.TreasuryAccountID, recipient, amount)
+}
+
+// precheckFrom is precheck for a payment debited from the given account.
+func (p *Payment) precheckFrom(ctx context.Context, treasury, recipient hiero.AccountID, amount int64) error {
+	if p.balances == nil {
+		return nil
+	}
+	tokenID := p.config.PaymentTokenID
+
+	associated, err := p.balances.IsAssociated(ctx, tokenID, recipient)
+	switch {
+	case err != nil:
+		p.logger.Warn("payment precheck: association query failed", "account", recipient, "error", err)
+	case !associated:
+		return fmt.Errorf("account %s, token %s: %w", recipient, tokenID, ErrNotAssociated)
 	}
 
+	balance, err := p.balances.TokenBalance(ctx, tokenID, treasury)
+	switch {
+	case err != nil:
+		p.logger.Warn("payment precheck: treasury balance query failed", "account", treasury, "error", err)
+	case balance < amount:
+		return fmt.Errorf("treasury %s holds %d of token %s, need %d: %w",
+			treasury, balance, tokenID, amount, ErrInsufficientTreasury)
+	}
 	return nil
 }
 
@@ -106,18 +299,19 @@ func (p *Payment) PaymentStatus(taskID string) (PaymentState, error) {
 	return state, nil
 }
 
-func (p *Payment) publishSettlement(ctx context.Context, taskID string, agentID string, amount int64, txStatus string) error {
+func (p *Payment) publishSettlement(ctx context.Context, record PaymentRecord) (hcs.Envelope, error) {
 	payload := PaymentSettledPayload{
-		TaskID:   taskID,
-		AgentID:  agentID,
-		Amount:   amount,
-		TokenID:  p.config.PaymentTokenID.String(),
-		TxStatus: txStatus,
+		TaskID:        record.TaskID,
+		AgentID:       record.AgentID,
+		Amount:        record.Amount,
+		TokenID:       record.TokenID,
+		TxStatus:      record.TxStatus,
+		TransactionID: record.TransactionID,
 	}
 
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("marshal settlement payload: %w", err)
+		return hcs.Envelope{}, fmt.Errorf("marshal settlement payload: %w", err)
 	}
 
 	p.mu.Lock()
@@ -128,20 +322,70 @@ func (p *Payment) publishSettlement(ctx context.Context, taskID string, agentID
 	env := hcs.Envelope{
 		Type:        hcs.MessageTypePaymentSettled,
 		Sender:      "coordinator",
-		Recipient:   agentID,
-		TaskID:      taskID,
+		Recipient:   record.AgentID,
+		TaskID:      record.TaskID,
 		SequenceNum: seqNum,
 		Timestamp:   time.Now(),
 		Payload:     payloadBytes,
 	}
 
-	return p.publisher.Publish(ctx, p.config.TaskTopicID, env)
+	if err := p.publisher.Publish(ctx, p.config.TaskTopicID, env); err != nil {
+		return hcs.Envelope{}, err
+	}
+	return env, nil
 }
 
 func (p *Payment) setPaymentState(taskID string, state PaymentState) {
 	p.mu.Lock()
-	defer p.mu.Unlock()
 	p.payments[taskID] = state
+	p.mu.Unlock()
+
+	if p.store == nil {
+		return
+	}
+	if err := p.store.SavePaymentState(taskID, state); err != nil {
+		p.logger.Warn("failed to persist payment state", "task_id", taskID, "state", state, "error", err)
+	}
+}
+
+// interruptPending fails the payments recovered as pending. Whether their
+// transfer reached the network is unknown, so they are left to the
+// PaymentRetrier, which looks for it on chain before paying again. A payment
+// stopped before its first attempt was recorded sent nothing and is
+// forgotten, so the task can be paid afresh.
+func (p *Payment) interruptPending() {
+	p.mu.Lock()
+	var pending []string
+	for taskID, state := range p.payments {
+		if state == PaymentPending {
+			pending = append(pending, taskID)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, taskID := range pending {
+		records := p.PaymentRecords(taskID)
+		if len(records) == 0 {
+			p.mu.Lock()
+			delete(p.payments, taskID)
+			p.mu.Unlock()
+			continue
+		}
+		p.logger.Warn("payment interrupted by restart, marking failed", "task_id", taskID)
+		p.setPaymentState(taskID, PaymentFailed)
+		if last := records[len(records)-1]; last.State == PaymentPending {
+			p.failRecord(last, errPaymentInterrupted)
+		}
+	}
+}
+
+// restore replaces tracked payment states with those recovered from a store.
+func (p *Payment) restore(payments map[string]PaymentState) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for taskID, state := range payments {
+		p.payments[taskID] = state
+	}
 }
 
 // Compile-time interface compliance check.
@@ -0,0 +1,287 @@
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+)
+
+// permanentStatuses are the Hedera statuses that a payment transfer fails
+// with again however often it is retried.
+var permanentStatuses = map[hiero.Status]bool{
+	hiero.StatusTokenNotAssociatedToAccount:     true,
+	hiero.StatusInvalidAccountID:                true,
+	hiero.StatusAccountDeleted:                  true,
+	hiero.StatusInvalidTokenID:                  true,
+	hiero.StatusTokenWasDeleted:                 true,
+	hiero.StatusAccountFrozenForToken:           true,
+	hiero.StatusAccountKycNotGrantedForToken:    true,
+	hiero.StatusAccountRepeatedInAccountAmounts: true,
+	hiero.StatusInvalidScheduleID:               true,
+	hiero.StatusScheduleAlreadyDeleted:          true,
+}
+
+// associationLagAttempts is how many attempts a payment gets while the
+// mirror node reports its recipient as not associated with the payment
+// token. The mirror lags consensus by seconds, so an association made just
+// before the payment may not show up yet; later attempts take it as final.
+const associationLagAttempts = 2
+
+// permanentPaymentError reports whether a payment failed for a reason that
+// retrying cannot fix, such as a recipient that is not associated with the
+// payment token or is not a valid account. Everything else, including an
+// underfunded treasury, is treated as transient.
+func permanentPaymentError(err error) bool {
+	if errors.Is(err, ErrNotAssociated) || errors.Is(err, ErrInvalidAccount) || errors.Is(err, hts.ErrTooManyTransfers) {
+		return true
+	}
+	var precheck hiero.ErrHederaPreCheckStatus
+	if errors.As(err, &precheck) {
+		return permanentStatuses[precheck.Status]
+	}
+	var receipt hiero.ErrHederaReceiptStatus
+	if errors.As(err, &receipt) {
+		return permanentStatuses[receipt.Status]
+	}
+	return false
+}
+
+// PaymentRetryPolicy decides when failed payments are attempted again.
+type PaymentRetryPolicy struct {
+	// MaxAttempts is the total number of attempts per task, including the
+	// first. Values of one or less disable payment retries.
+	MaxAttempts int
+
+	// Backoff is the delay after the first failed attempt. It doubles for
+	// each further attempt, up to MaxBackoff. It should exceed the mirror
+	// node's indexing delay, so a transfer that reached consensus despite
+	// an error is seen before the payment is retried.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultPaymentRetryPolicy retries a failed payment up to four times,
+// starting a minute after the failure.
+func DefaultPaymentRetryPolicy() PaymentRetryPolicy {
+	return PaymentRetryPolicy{
+		MaxAttempts: 5,
+		Backoff:     time.Minute,
+		MaxBackoff:  15 * time.Minute,
+	}
+}
+
+// Validate checks the policy for negative limits.
+func (p PaymentRetryPolicy) Validate() error {
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("payment retry policy: max attempts must not be negative")
+	}
+	if p.Backoff < 0 || p.MaxBackoff < 0 {
+		return fmt.Errorf("payment retry policy: backoff must not be negative")
+	}
+	if p.MaxAttempts > 1 && p.Backoff == 0 {
+		return fmt.Errorf("payment retry policy: backoff must be positive when retrying")
+	}
+	return nil
+}
+
+// backoff returns the delay before the attempt that follows the given
+// number of failed attempts.
+func (p PaymentRetryPolicy) backoff(attempts int) time.Duration {
+	return RetryPolicy{Backoff: p.Backoff, MaxBackoff: p.MaxBackoff}.backoff(attempts)
+}
+
+// PaymentRetrier re-attempts failed task payments. Before each retry it
+// looks on chain for a transfer that already paid the task, since a
+// transfer can reach consensus even though its caller saw an error; such a
+// payment is recorded as settled rather than sent again.
+type PaymentRetrier struct {
+	payment *Payment
+	history hts.TransferHistory
+	policy  PaymentRetryPolicy
+	logger  *slog.Logger
+	now     func() time.Time
+
+	mu       sync.Mutex
+	reported map[string]bool // tasks whose abandonment has been logged
+}
+
+// NewPaymentRetrier creates a retrier for the payment manager's failed
+// payments that checks for prior transfers in history.
+func NewPaymentRetrier(payment *Payment, history hts.TransferHistory, policy PaymentRetryPolicy) *PaymentRetrier {
+	return &PaymentRetrier{
+		payment:  payment,
+		history:  history,
+		policy:   policy,
+		logger:   slog.Default(),
+		now:      time.Now,
+		reported: make(map[string]bool),
+	}
+}
+
+// Start retries due payments every backoff interval until the context is
+// cancelled. It returns at once when retries are disabled.
+func (r *PaymentRetrier) Start(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("payment retrier start: %w", err)
+	}
+	if r.policy.MaxAttempts <= 1 || r.policy.Backoff <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(r.policy.Backoff)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.RetryDue(ctx)
+		}
+	}
+}
+
+// RetryDue retries every failed payment whose backoff has elapsed and
+// returns how many were retried or found settled on chain.
+func (r *PaymentRetrier) RetryDue(ctx context.Context) int {
+	now := r.now()
+	var handled int
+	for _, taskID := range r.failedTasks() {
+		if ctx.Err() != nil {
+			return handled
+		}
+		records := r.payment.PaymentRecords(taskID)
+		if len(records) == 0 {
+			continue // failed before its first attempt was recorded
+		}
+		last := records[len(records)-1]
+		if !r.retryable(taskID, last, len(records)) {
+			continue
+		}
+		if now.Sub(last.Time) < r.policy.backoff(len(records)) {
+			continue
+		}
+
+		prior, found, err := r.findTransfer(ctx, taskID, records)
+		if err != nil {
+			// Without the chain's answer a retry could pay twice.
+			r.logger.Warn("payment retry deferred: transfer history unavailable", "task_id", taskID, "error", err)
+			continue
+		}
+		handled++
+		if found {
+			r.logger.Info("failed payment found settled on chain",
+				"task_id", taskID, "transaction_id", prior.TransactionID.String())
+			if err := r.payment.settleFromChain(ctx, taskID, prior); err != nil {
+				r.logger.Error("recording on-chain payment failed", "task_id", taskID, "error", err)
+			}
+			continue
+		}
+
+		r.logger.Info("retrying payment", "task_id", taskID, "attempt", len(records)+1, "previous_error", last.Error)
+		if err := r.payment.pay(ctx, taskID, last.AgentID, last.Amount, true); err != nil {
+			r.logger.Warn("payment retry failed", "task_id", taskID, "attempt", len(records)+1, "error", err)
+		}
+	}
+	return handled
+}
+
+// retryable reports whether a failed payment gets another attempt, logging
+// once when it is given up on.
+func (r *PaymentRetrier) retryable(taskID string, last PaymentRecord, attempts int) bool {
+	if !last.Permanent && attempts < r.policy.MaxAttempts {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.reported[taskID] {
+		r.reported[taskID] = true
+		r.logger.Error("payment abandoned",
+			"task_id", taskID, "attempts", attempts, "permanent", last.Permanent, "error", last.Error)
+	}
+	return false
+}
+
+// failedTasks returns the tasks whose payment is failed, sorted.
+func (r *PaymentRetrier) failedTasks() []string {
+	r.payment.mu.RLock()
+	defer r.payment.mu.RUnlock()
+
+	var taskIDs []string
+	for taskID, state := range r.payment.payments {
+		if state == PaymentFailed {
+			taskIDs = append(taskIDs, taskID)
+		}
+	}
+	sort.Strings(taskIDs)
+	return taskIDs
+}
+
+// findTransfer looks on chain for a transfer that paid the task since its
+// first attempt: one whose memo names the task, or one carrying the
+// transaction ID of an attempt, credited to that attempt's recipient.
+//
+// A batch memo names no task, so batched attempts are found by the batch's
+// transaction ID alone. The batch credits each recipient once for all its
+// tasks, so the attempt's own amount is reported rather than the credit.
+func (r *PaymentRetrier) findTransfer(ctx context.Context, taskID string, records []PaymentRecord) (hts.TransferRecord, bool, error) {
+	transfers, err := paymentTransfers(ctx, r.history, r.payment.config, records[0].Time)
+	if err != nil {
+		return hts.TransferRecord{}, false, fmt.Errorf("find transfer for task %s: %w", taskID, err)
+	}
+
+	for _, t := range transfers {
+		for _, rec := range records {
+			if t.ToAccountID.String() != rec.AgentID {
+				continue
+			}
+			if rec.TransactionID != "" && t.TransactionID.String() == rec.TransactionID {
+				if strings.HasPrefix(t.Memo, paymentBatchMemoPrefix) {
+					t.Amount = rec.Amount
+				}
+				return t, true, nil
+			}
+			if memoTask, _ := taskIDFromMemo(t.Memo); memoTask == taskID {
+				return t, true, nil
+			}
+		}
+	}
+	return hts.TransferRecord{}, false, nil
+}
+
+// settleFromChain records a failed task's payment as settled by a transfer
+// found on chain, without transferring again. The attempt is recorded in the
+// ledger and announced like any other settlement.
+func (p *Payment) settleFromChain(ctx context.Context, taskID string, t hts.TransferRecord) error {
+	if err := p.begin(taskID, true); err != nil {
+		return fmt.Errorf("settle task %s from transaction %s: %w", taskID, t.TransactionID.String(), err)
+	}
+
+	record := p.openRecord(taskID, t.ToAccountID.String(), t.Amount)
+	record.Memo = t.Memo
+
+	p.mu.RLock()
+	escrow, escrowed := p.heldEscrowLocked(taskID)
+	p.mu.RUnlock()
+	if escrowed && t.FromAccountID == p.config.EscrowAccountID {
+		record.ScheduleID = escrow.ScheduleID
+		escrow.State = EscrowReleased
+		escrow.Time = time.Now().UTC()
+		p.saveEscrow(escrow)
+	}
+
+	if err := p.settle(ctx, record, t.TransactionID.String(), hiero.StatusSuccess.String()); err != nil {
+		return fmt.Errorf("settle task %s from transaction %s: publish settlement: %w", taskID, t.TransactionID.String(), err)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package coordinator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/sim"
+)
+
+// flakyTransfer fails the first failures transfers. With executed set, the
+// failing transfers still reach the network, as when a receipt times out.
+type flakyTransfer struct {
+	network  *sim.Network
+	failures int
+	executed bool
+}
+
+func (f *flakyTransfer) Transfer(ctx context.Context, req hts.TransferRequest) (*hts.TransferReceipt, error) {
+	if f.failures == 0 {
+		return f.network.Transfer(ctx, req)
+	}
+	f.failures--
+	if f.executed {
+		if _, err := f.network.Transfer(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("receipt: timed out")
+}
+
+func (f *flakyTransfer) AssociateToken(ctx context.Context, tokenID hiero.TokenID, accountID hiero.AccountID) error {
+	return f.network.AssociateToken(ctx, tokenID, accountID)
+}
+
+// unavailableHistory fails every transfer history query.
+type unavailableHistory struct{}
+
+func (unavailableHistory) TokenTransfers(context.Context, hiero.TokenID, hiero.AccountID, time.Time) ([]hts.TransferRecord, error) {
+	return nil, errors.New("mirror node unavailable")
+}
+
+// newTestPaymentRetrier returns a retrier whose clock is after every backoff.
+func newTestPaymentRetrier(p *Payment, history hts.TransferHistory) *PaymentRetrier {
+	r := NewPaymentRetrier(p, history, DefaultPaymentRetryPolicy())
+	r.now = func() time.Time { return time.Now().Add(time.Hour) }
+	return r
+}
+
+func TestPaymentRetrier_RetriesTransientFailure(t *testing.T) {
+	ctx := context.Background()
+	p, network, _, agents := newTestPayment(t, testPaymentOptions{agents: 1, transfer: &flakyTransfer{failures: 1}})
+	agent := agents[0]
+	events := NewEventBus()
+	var mu sync.Mutex
+	var settled []PaymentSettled
+	events.Subscribe(func(e Event) {
+		if s, ok := e.(PaymentSettled); ok {
+			mu.Lock()
+			settled = append(settled, s)
+			mu.Unlock()
+		}
+	})
+	p.SetEvents(events)
+
+	if err := p.PayForTask(ctx, "task-1", agent.String(), 100); err == nil {
+		t.Fatal("PayForTask() succeeded, want the first transfer to fail")
+	}
+	if records := p.PaymentRecords("task-1"); records[0].Permanent {
+		t.Fatalf("record = %+v, want a transient failure", records[0])
+	}
+
+	// Not due until the backoff has passed.
+	early := NewPaymentRetrier(p, network, DefaultPaymentRetryPolicy())
+	if n := early.RetryDue(ctx); n != 0 {
+		t.Fatalf("RetryDue() before backoff = %d, want 0", n)
+	}
+
+	r := newTestPaymentRetrier(p, network)
+	if n := r.RetryDue(ctx); n != 1 {
+		t.Fatalf("RetryDue() = %d, want 1", n)
+	}
+	if state, _ := p.PaymentStatus("task-1"); state != PaymentProcessed {
+		t.Errorf("PaymentStatus() = %s, want processed", state)
+	}
+	if records := p.PaymentRecords("task-1"); len(records) != 2 || records[1].State != PaymentProcessed {
+		t.Errorf("ledger = %+v, want failed attempt then settled retry", records)
+	}
+	if n := len(network.Transfers()); n != 1 {
+		t.Errorf("transfers = %d, want 1", n)
+	}
+
+	events.Wait()
+	mu.Lock()
+	defer mu.Unlock()
+	if len(settled) != 1 || settled[0].TaskID != "task-1" {
+		t.Errorf("PaymentSettled events = %+v, want one for task-1", settled)
+	}
+}
+
+func TestPaymentRetrier_FindsPriorTransferOnChain(t *testing.T) {
+	ctx := context.Background()
+	p, network, _, agents := newTestPayment(t, testPaymentOptions{agents: 1, transfer: &flakyTransfer{failures: 1, executed: true}})
+	agent := agents[0]
+
+	if err := p.PayForTask(ctx, "task-1", agent.String(), 100); err == nil {
+		t.Fatal("PayForTask() succeeded, want an error after the transfer executed")
+	}
+	transfers := network.Transfers()
+	if len(transfers) != 1 {
+		t.Fatalf("transfers = %d, want the transfer that executed despite the error", len(transfers))
+	}
+
+	r := newTestPaymentRetrier(p, network)
+	if n := r.RetryDue(ctx); n != 1 {
+		t.Fatalf("RetryDue() = %d, want 1", n)
+	}
+	if n := len(network.Transfers()); n != 1 {
+		t.Fatalf("transfers after retry = %d, want no second payment", n)
+	}
+	if state, _ := p.PaymentStatus("task-1"); state != PaymentProcessed {
+		t.Errorf("PaymentStatus() = %s, want processed", state)
+	}
+	records := p.PaymentRecords("task-1")
+	if len(records) != 2 || records[1].TransactionID != transfers[0].TransactionID.String() {
+		t.Errorf("ledger = %+v, want the on-chain transfer recorded as attempt 2", records)
+	}
+}
+
+func TestPaymentRetrier_FindsBatchCreditPerTask(t *testing.T) {
+	ctx := context.Background()
+	p, network, _, agents := newTestPayment(t, testPaymentOptions{agents: 1, batchSize: 2, batchWindow: time.Hour})
+	p.SetBatchTransfer(receiptLostBatch{network: network})
+
+	// Both tasks pay one agent, so the batch credits it once with 150.
+	_ = p.PayForTask(ctx, "task-1", agents[0].String(), 100)
+	_ = p.PayForTask(ctx, "task-2", agents[0].String(), 50)
+	transfers := network.Transfers()
+	if len(transfers) != 1 || transfers[0].Amount != 150 {
+		t.Fatalf("transfers = %+v, want one credit of 150", transfers)
+	}
+
+	if n := newTestPaymentRetrier(p, network).RetryDue(ctx); n != 2 {
+		t.Fatalf("RetryDue() = %d, want 2", n)
+	}
+	if n := len(network.Transfers()); n != 1 {
+		t.Fatalf("transfers after retry = %d, want no second payment", n)
+	}
+	for taskID, amount := range map[string]int64{"task-1": 100, "task-2": 50} {
+		records := p.PaymentRecords(taskID)
+		last := records[len(records)-1]
+		if last.State != PaymentProcessed || last.Amount != amount ||
+			last.TransactionID != transfers[0].TransactionID.String() {
+			t.Errorf("task %s settled as %+v, want %d in the batch transaction", taskID, last, amount)
+		}
+	}
+}
+
+func TestPaymentRetrier_SkipsPermanentAndExhausted(t *testing.T) {
+	ctx := context.Background()
+	p, network, _, agents := newTestPayment(t, testPaymentOptions{agents: 1, transfer: &flakyTransfer{failures: 10}})
+	agent := agents[0]
+	unassociated := network.CreateAccount()
+
+	if err := p.PayForTask(ctx, "task-unassociated", unassociated.String(), 100); !errors.Is(err, ErrNotAssociated) {
+		t.Fatalf("PayForTask() error = %v, want ErrNotAssociated", err)
+	}
+	if err := p.PayForTask(ctx, "task-invalid", "not-an-account", 100); !errors.Is(err, ErrInvalidAccount) {
+		t.Fatalf("PayForTask() error = %v, want ErrInvalidAccount", err)
+	}
+	if err := p.PayForTask(ctx, "task-flaky", agent.String(), 100); err == nil {
+		t.Fatal("PayForTask() succeeded, want transfer failure")
+	}
+
+	// The unassociated recipient is retried while the mirror may lag, then
+	// given up on; the flaky transfer is retried until MaxAttempts.
+	r := newTestPaymentRetrier(p, network)
+	r.policy.MaxAttempts = associationLagAttempts + 2
+	for round, want := range []int{2, 2, 1, 0} {
+		if n := r.RetryDue(ctx); n != want {
+			t.Fatalf("RetryDue() round %d = %d, want %d", round+1, n, want)
+		}
+	}
+	for taskID, want := range map[string]int{
+		"task-unassociated": associationLagAttempts + 1,
+		"task-invalid":      1,
+		"task-flaky":        associationLagAttempts + 2,
+	} {
+		if got := len(p.PaymentRecords(taskID)); got != want {
+			t.Errorf("%s attempts = %d, want %d", taskID, got, want)
+		}
+	}
+}
+
+func TestPaymentRetrier_RetriesRecentlyAssociatedRecipient(t *testing.T) {
+	ctx := context.Background()
+	p, network, _, _ := newTestPayment(t, testPaymentOptions{agents: 1, transfer: &flakyTransfer{}})
+	unassociated := network.CreateAccount()
+	if err := p.PayForTask(ctx, "task-1", unassociated.String(), 100); !errors.Is(err, ErrNotAssociated) {
+		t.Fatalf("PayForTask() error = %v, want ErrNotAssociated", err)
+	}
+	if records := p.PaymentRecords("task-1"); records[0].Permanent {
+		t.Fatal("first not-associated failure marked permanent, want a retry")
+	}
+
+	// The mirror node catches up with the association.
+	if err := network.AssociateToken(ctx, p.config.PaymentTokenID, unassociated); err != nil {
+		t.Fatalf("AssociateToken() error = %v", err)
+	}
+	r := newTestPaymentRetrier(p, network)
+	if n := r.RetryDue(ctx); n != 1 {
+		t.Fatalf("RetryDue() = %d, want 1", n)
+	}
+	if state, _ := p.PaymentStatus("task-1"); state != PaymentProcessed {
+		t.Errorf("payment state = %s, want processed", state)
+	}
+}
+
+func TestPaymentRetrier_DefersWithoutHistory(t *testing.T) {
+	ctx := context.Background()
+	p, network, _, agents := newTestPayment(t, testPaymentOptions{agents: 1, transfer: &flakyTransfer{failures: 1}})
+	agent := agents[0]
+	if err := p.PayForTask(ctx, "task-1", agent.String(), 100); err == nil {
+		t.Fatal("PayForTask() succeeded, want transfer failure")
+	}
+
+	r := newTestPaymentRetrier(p, unavailableHistory{})
+	if n := r.RetryDue(ctx); n != 0 || len(network.Transfers()) != 0 {
+		t.Errorf("RetryDue() = %d with %d transfers, want no retry while history is unavailable", n, len(network.Transfers()))
+	}
+}
+
+func TestPermanentPaymentError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{fmt.Errorf("precheck: %w", ErrNotAssociated), true},
+		{fmt.Errorf("receipt: %w", hiero.ErrHederaReceiptStatus{Status: hiero.StatusTokenNotAssociatedToAccount}), true},
+		{fmt.Errorf("execute: %w", hiero.ErrHederaPreCheckStatus{Status: hiero.StatusInvalidAccountID}), true},
+		{fmt.Errorf("execute: %w", hiero.ErrHederaPreCheckStatus{Status: hiero.StatusBusy}), false},
+		{fmt.Errorf("precheck: %w", ErrInsufficientTreasury), false},
+		{errors.New("receipt: timed out"), false},
+	}
+	for _, tt := range tests {
+		if got := permanentPaymentError(tt.err); got != tt.want {
+			t.Errorf("permanentPaymentError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
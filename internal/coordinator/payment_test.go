@@ -2,9 +2,14 @@ package coordinator
 
 import (
 	"context"
+	"errors"
+	"path/filepath"
 	"testing"
 
 	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/sim"
 )
 
 func TestPayment_PayForTask_ContextCancellation(t *testing.T) {
@@ -112,3 +117,123 @@ func TestNewPayment_InitializesMap(t *testing.T) {
 func TestPayment_InterfaceCompliance(t *testing.T) {
 	var _ PaymentManager = (*Payment)(nil)
 }
+
+func TestPayment_PrecheckBalances(t *testing.T) {
+	ctx := context.Background()
+	network := sim.NewNetwork()
+	treasury := network.CreateAccount()
+	associated := network.CreateAccount()
+	unassociated := network.CreateAccount()
+	tokenID, err := network.CreateFungibleToken(ctx, hts.TokenConfig{
+		Name: "Agent Payment Token", Symbol: "APT", InitialSupply: 500, TreasuryAccountID: treasury,
+	})
+	if err != nil {
+		t.Fatalf("CreateFungibleToken() error = %v", err)
+	}
+	if err := network.AssociateToken(ctx, tokenID, associated); err != nil {
+		t.Fatalf("AssociateToken() error = %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.PaymentTokenID = tokenID
+	cfg.TreasuryAccountID = treasury
+	p := NewPayment(network, &mockPublisher{}, cfg)
+	p.SetBalances(network)
+
+	tests := []struct {
+		name    string
+		taskID  string
+		agent   hiero.AccountID
+		amount  int64
+		wantErr error
+	}{
+		{"recipient not associated", "task-1", unassociated, 100, ErrNotAssociated},
+		{"treasury too low", "task-2", associated, 501, ErrInsufficientTreasury},
+		{"covered", "task-3", associated, 500, nil},
+		{"treasury drained", "task-4", associated, 1, ErrInsufficientTreasury},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.PayForTask(ctx, tt.taskID, tt.agent.String(), tt.amount)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("PayForTask() error = %v, want %v", err, tt.wantErr)
+			}
+			want := PaymentProcessed
+			if tt.wantErr != nil {
+				want = PaymentFailed
+			}
+			if state, _ := p.PaymentStatus(tt.taskID); state != want {
+				t.Errorf("payment state = %s, want %s", state, want)
+			}
+		})
+	}
+
+	if transfers := network.Transfers(); len(transfers) != 1 {
+		t.Errorf("transfers = %d, want only the covered payment", len(transfers))
+	}
+}
+
+func TestPayment_LedgerRecordsAttempts(t *testing.T) {
+	ctx := context.Background()
+	network := sim.NewNetwork()
+	treasury := network.CreateAccount()
+	agent := network.CreateAccount()
+	tokenID, err := network.CreateFungibleToken(ctx, hts.TokenConfig{
+		Name: "Agent Payment Token", Symbol: "APT", InitialSupply: 500, TreasuryAccountID: treasury,
+	})
+	if err != nil {
+		t.Fatalf("CreateFungibleToken() error = %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.PaymentTokenID = tokenID
+	cfg.TreasuryAccountID = treasury
+	store := openTestStore(t, filepath.Join(t.TempDir(), "state.jsonl"))
+	pub := &mockPublisher{}
+	p := NewPayment(network, pub, cfg)
+	p.SetStore(store)
+
+	// The first attempt fails on chain; the retry after association settles.
+	if err := p.PayForTask(ctx, "task-1", agent.String(), 100); err == nil {
+		t.Fatal("PayForTask() to unassociated account succeeded")
+	}
+	if err := network.AssociateToken(ctx, tokenID, agent); err != nil {
+		t.Fatalf("AssociateToken() error = %v", err)
+	}
+	if err := p.PayForTask(ctx, "task-1", agent.String(), 100); err == nil {
+		t.Fatal("PayForTask() paid a failed payment again outside the retrier")
+	}
+	if err := p.pay(ctx, "task-1", agent.String(), 100, true); err != nil {
+		t.Fatalf("pay() retry error = %v", err)
+	}
+
+	records := p.PaymentRecords("task-1")
+	if len(records) != 2 {
+		t.Fatalf("records = %d, want 2", len(records))
+	}
+	if r := records[0]; r.Attempt != 1 || r.State != PaymentFailed || r.Error == "" || r.TransactionID != "" {
+		t.Errorf("first record = %+v, want failed attempt 1 with error", r)
+	}
+	r := records[1]
+	if r.Attempt != 2 || r.State != PaymentProcessed || r.Memo != "payment:task:task-1" || r.Amount != 100 {
+		t.Errorf("second record = %+v, want processed attempt 2", r)
+	}
+	if want := network.Transfers()[0].TransactionID.String(); r.TransactionID != want {
+		t.Errorf("transaction ID = %q, want %q", r.TransactionID, want)
+	}
+	if r.Settlement == nil || r.Settlement.SequenceNum != pub.calls[0].SequenceNum {
+		t.Errorf("settlement = %+v, want the published envelope", r.Settlement)
+	}
+
+	// The ledger survives a restart.
+	snap, err := openTestStore(t, store.path).Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	restored := NewPayment(network, pub, cfg)
+	restored.restoreLedger(snap.PaymentRecords)
+	got := restored.PaymentRecords("task-1")
+	if len(got) != 2 || got[1].TransactionID != r.TransactionID || got[1].Settlement == nil {
+		t.Errorf("restored records = %+v, want both attempts with settlement", got)
+	}
+}
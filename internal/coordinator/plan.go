@@ -1,17 +1,61 @@
 package coordinator
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
 // Plan represents a parsed festival plan for coordinator execution.
 type Plan struct {
 	FestivalID string         `json:"festival_id"`
 	Sequences  []PlanSequence `json:"sequences"`
+
+	// DefaultTimeoutSeconds is how long an agent may hold a task without
+	// renewing its lease. Zero means tasks never time out unless they set
+	// their own TimeoutSeconds.
+	DefaultTimeoutSeconds int `json:"default_timeout_seconds,omitempty"`
 }
 
 // PlanSequence represents a sequence within the plan.
 type PlanSequence struct {
 	ID    string     `json:"id"`
 	Tasks []PlanTask `json:"tasks"`
+
+	// Gates are quality checkpoints that must pass before the sequence
+	// counts as complete.
+	Gates []PlanGate `json:"gates,omitempty"`
+}
+
+// Gate kinds, named after the fest gate tasks they come from.
+const (
+	GateKindTesting    = "testing"
+	GateKindReview     = "review"
+	GateKindIterate    = "iterate"
+	GateKindFestCommit = "fest_commit"
+)
+
+// PlanGate is a quality checkpoint in a sequence. It is not dispatched to an
+// agent; the coordinator decides it once the tasks it covers finish. Tasks
+// may depend on a gate by ID, and tasks in other sequences that depend on
+// any task in a gated sequence wait for all of that sequence's gates.
+type PlanGate struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+
+	// Dependencies lists the tasks the gate covers. Empty covers every
+	// task in the sequence.
+	Dependencies []string `json:"dependencies,omitempty"`
 }
 
+// Task types assigned by the coordinator's own plans. Agents advertise the
+// task types they accept in AgentInfo.TaskTypes.
+const (
+	TaskTypeInference = "inference_job"
+	TaskTypeTrade     = "execute_trade"
+)
+
 // PlanTask represents a single task in the plan.
 type PlanTask struct {
 	ID            string   `json:"id"`
@@ -24,6 +68,9 @@ type PlanTask struct {
 	MaxTokens     int      `json:"max_tokens,omitempty"`
 	PaymentAmount int64    `json:"payment_amount,omitempty"`
 	Dependencies  []string `json:"dependencies,omitempty"`
+
+	// TimeoutSeconds overrides the plan's DefaultTimeoutSeconds.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
 }
 
 // TaskCount returns the total number of tasks across all sequences.
@@ -46,3 +93,302 @@ func (p Plan) TaskByID(taskID string) *PlanTask {
 	}
 	return nil
 }
+
+// GateByID finds a gate in the plan by its ID, along with the ID of its
+// sequence. Returns nil if not found.
+func (p Plan) GateByID(gateID string) (*PlanGate, string) {
+	for i := range p.Sequences {
+		for j := range p.Sequences[i].Gates {
+			if p.Sequences[i].Gates[j].ID == gateID {
+				return &p.Sequences[i].Gates[j], p.Sequences[i].ID
+			}
+		}
+	}
+	return nil, ""
+}
+
+// hasGates reports whether any sequence in the plan has a gate.
+func (p Plan) hasGates() bool {
+	for _, seq := range p.Sequences {
+		if len(seq.Gates) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskTimeout returns how long an agent may hold the task between lease
+// renewals: the task's own timeout, else the plan default. Zero means the
+// task has no deadline.
+func (p Plan) TaskTimeout(task PlanTask) time.Duration {
+	if task.TimeoutSeconds > 0 {
+		return time.Duration(task.TimeoutSeconds) * time.Second
+	}
+	if p.DefaultTimeoutSeconds > 0 {
+		return time.Duration(p.DefaultTimeoutSeconds) * time.Second
+	}
+	return 0
+}
+
+// PlanIssueKind classifies a problem found while validating a plan.
+type PlanIssueKind string
+
+const (
+	PlanIssueMissingID         PlanIssueKind = "missing_id"
+	PlanIssueMissingTaskType   PlanIssueKind = "missing_task_type"
+	PlanIssueDuplicateID       PlanIssueKind = "duplicate_id"
+	PlanIssueUnknownDependency PlanIssueKind = "unknown_dependency"
+	PlanIssueCycle             PlanIssueKind = "dependency_cycle"
+	PlanIssueInvalidPayment    PlanIssueKind = "invalid_payment"
+	PlanIssueInvalidTimeout    PlanIssueKind = "invalid_timeout"
+	PlanIssueUnassignedDeFi    PlanIssueKind = "unassigned_defi"
+	PlanIssueUnknownAgent      PlanIssueKind = "unknown_agent"
+	PlanIssueNoCapableAgent    PlanIssueKind = "no_capable_agent"
+)
+
+// PlanIssue is a single problem found while validating a plan.
+type PlanIssue struct {
+	Kind       PlanIssueKind
+	SequenceID string
+	TaskID     string
+	Detail     string
+}
+
+func (i PlanIssue) String() string {
+	return fmt.Sprintf("sequence %s task %s: %s: %s", i.SequenceID, i.TaskID, i.Kind, i.Detail)
+}
+
+// PlanValidationError lists every problem found in a plan.
+type PlanValidationError struct {
+	FestivalID string
+	Issues     []PlanIssue
+}
+
+func (e *PlanValidationError) Error() string {
+	parts := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		parts[i] = issue.String()
+	}
+	return fmt.Sprintf("plan %s invalid: %d problem(s): %s",
+		e.FestivalID, len(e.Issues), strings.Join(parts, "; "))
+}
+
+// HasKind reports whether any issue of the given kind was found.
+func (e *PlanValidationError) HasKind(kind PlanIssueKind) bool {
+	for _, issue := range e.Issues {
+		if issue.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the plan for duplicate or missing task IDs, dependencies on
+// unknown tasks, dependency cycles, non-positive payment amounts, negative
+// timeouts and DeFi tasks without an agent. It returns a *PlanValidationError listing every
+// problem, or nil if the plan is valid.
+func (p Plan) Validate() error {
+	return p.validate(nil)
+}
+
+// ValidateAgents runs the Validate checks and also checks routing against
+// the registry: tasks pinned to an unregistered agent and tasks no
+// registered agent can run are reported. Unpinned DeFi tasks are allowed
+// since the registry routes them.
+func (p Plan) ValidateAgents(agents *AgentRegistry) error {
+	return p.validate(agents)
+}
+
+func (p Plan) validate(agents *AgentRegistry) error {
+	var issues []PlanIssue
+	sequenceOf := make(map[string]string, p.TaskCount())
+
+	for _, seq := range p.Sequences {
+		for _, task := range seq.Tasks {
+			if task.ID == "" {
+				issues = append(issues, PlanIssue{
+					Kind: PlanIssueMissingID, SequenceID: seq.ID,
+					Detail: fmt.Sprintf("task %q has no id", task.Name),
+				})
+				continue
+			}
+			if prev, exists := sequenceOf[task.ID]; exists {
+				issues = append(issues, PlanIssue{
+					Kind: PlanIssueDuplicateID, SequenceID: seq.ID, TaskID: task.ID,
+					Detail: fmt.Sprintf("id already used in sequence %s", prev),
+				})
+				continue
+			}
+			sequenceOf[task.ID] = seq.ID
+		}
+		for _, gate := range seq.Gates {
+			if gate.ID == "" {
+				issues = append(issues, PlanIssue{
+					Kind: PlanIssueMissingID, SequenceID: seq.ID,
+					Detail: fmt.Sprintf("gate %q has no id", gate.Name),
+				})
+				continue
+			}
+			if prev, exists := sequenceOf[gate.ID]; exists {
+				issues = append(issues, PlanIssue{
+					Kind: PlanIssueDuplicateID, SequenceID: seq.ID, TaskID: gate.ID,
+					Detail: fmt.Sprintf("id already used in sequence %s", prev),
+				})
+				continue
+			}
+			sequenceOf[gate.ID] = seq.ID
+		}
+	}
+
+	for _, seq := range p.Sequences {
+		for _, gate := range seq.Gates {
+			for _, dep := range gate.Dependencies {
+				if _, ok := sequenceOf[dep]; !ok {
+					issues = append(issues, PlanIssue{
+						Kind: PlanIssueUnknownDependency, SequenceID: seq.ID, TaskID: gate.ID,
+						Detail: fmt.Sprintf("gate covers unknown task %q", dep),
+					})
+				}
+			}
+		}
+	}
+
+	for _, seq := range p.Sequences {
+		for _, task := range seq.Tasks {
+			for _, dep := range task.Dependencies {
+				if _, ok := sequenceOf[dep]; !ok {
+					issues = append(issues, PlanIssue{
+						Kind: PlanIssueUnknownDependency, SequenceID: seq.ID, TaskID: task.ID,
+						Detail: fmt.Sprintf("depends on unknown task %q", dep),
+					})
+				}
+			}
+			if task.TaskType == "" && task.ID != "" {
+				issues = append(issues, PlanIssue{
+					Kind: PlanIssueMissingTaskType, SequenceID: seq.ID, TaskID: task.ID,
+					Detail: "task has no task type to route it by",
+				})
+			}
+			if task.PaymentAmount <= 0 {
+				issues = append(issues, PlanIssue{
+					Kind: PlanIssueInvalidPayment, SequenceID: seq.ID, TaskID: task.ID,
+					Detail: fmt.Sprintf("payment amount must be positive, got %d", task.PaymentAmount),
+				})
+			}
+			if task.TimeoutSeconds < 0 {
+				issues = append(issues, PlanIssue{
+					Kind: PlanIssueInvalidTimeout, SequenceID: seq.ID, TaskID: task.ID,
+					Detail: fmt.Sprintf("timeout must not be negative, got %ds", task.TimeoutSeconds),
+				})
+			}
+			switch {
+			case agents == nil:
+				if isDeFiTask(task) && task.AssignTo == "" {
+					issues = append(issues, PlanIssue{
+						Kind: PlanIssueUnassignedDeFi, SequenceID: seq.ID, TaskID: task.ID,
+						Detail: fmt.Sprintf("%s task has no assigned agent", task.TaskType),
+					})
+				}
+			case task.AssignTo != "":
+				if _, ok := agents.Agent(task.AssignTo); !ok {
+					issues = append(issues, PlanIssue{
+						Kind: PlanIssueUnknownAgent, SequenceID: seq.ID, TaskID: task.ID,
+						Detail: fmt.Sprintf("assigned to unregistered agent %q", task.AssignTo),
+					})
+				}
+			case !agents.CanRoute(task):
+				issues = append(issues, PlanIssue{
+					Kind: PlanIssueNoCapableAgent, SequenceID: seq.ID, TaskID: task.ID,
+					Detail: fmt.Sprintf("no registered agent runs task type %q with model %q", task.TaskType, task.ModelID),
+				})
+			}
+		}
+	}
+
+	for _, cycle := range p.dependencyCycles() {
+		issues = append(issues, PlanIssue{
+			Kind: PlanIssueCycle, SequenceID: sequenceOf[cycle[0]], TaskID: cycle[0],
+			Detail: strings.Join(cycle, " -> "),
+		})
+	}
+
+	if p.DefaultTimeoutSeconds < 0 {
+		issues = append(issues, PlanIssue{
+			Kind:   PlanIssueInvalidTimeout,
+			Detail: fmt.Sprintf("default timeout must not be negative, got %ds", p.DefaultTimeoutSeconds),
+		})
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+	return &PlanValidationError{FestivalID: p.FestivalID, Issues: issues}
+}
+
+// dependencyCycles returns each dependency cycle in the plan as the path of
+// task or gate IDs that closes the loop, e.g. [a b a]. A gate depends on the
+// tasks it covers.
+func (p Plan) dependencyCycles() [][]string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	graph := newTaskGraph(p)
+	order := append([]string(nil), graph.order...)
+	deps := make(map[string][]string, len(graph.order))
+	for _, id := range graph.order {
+		deps[id] = graph.tasks[id].Dependencies
+	}
+	for _, seq := range p.Sequences {
+		for _, gate := range seq.Gates {
+			if _, exists := deps[gate.ID]; exists || gate.ID == "" {
+				continue
+			}
+			covered := gate.Dependencies
+			if len(covered) == 0 {
+				for _, task := range seq.Tasks {
+					covered = append(covered, task.ID)
+				}
+			}
+			order = append(order, gate.ID)
+			deps[gate.ID] = covered
+		}
+	}
+
+	color := make(map[string]int, len(order))
+	var stack []string
+	var cycles [][]string
+
+	var visit func(id string)
+	visit = func(id string) {
+		color[id] = visiting
+		stack = append(stack, id)
+		for _, dep := range deps[id] {
+			if _, ok := deps[dep]; !ok {
+				continue
+			}
+			switch color[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				start := len(stack) - 1
+				for stack[start] != dep {
+					start--
+				}
+				cycle := append([]string(nil), stack[start:]...)
+				cycles = append(cycles, append(cycle, dep))
+			}
+		}
+		stack = stack[:len(stack)-1]
+		color[id] = visited
+	}
+
+	for _, id := range order {
+		if color[id] == unvisited {
+			visit(id)
+		}
+	}
+	return cycles
+}
@@ -0,0 +1,50 @@
+package coordinator
+
+// PricingRule computes a task payout as a base amount plus bonuses scaled by
+// the task's token budget and the agent-reported run time.
+type PricingRule struct {
+	// Base overrides the plan's PaymentAmount when positive.
+	Base int64 `json:"base,omitempty"`
+
+	// PerThousandTokens is added for every 1,000 tokens of PlanTask.MaxTokens.
+	PerThousandTokens int64 `json:"per_1k_tokens,omitempty"`
+
+	// PerSecond is added for every full second of TaskResultPayload.DurationMs.
+	PerSecond int64 `json:"per_second,omitempty"`
+
+	// Max caps the total payout when positive.
+	Max int64 `json:"max,omitempty"`
+}
+
+// PaymentPricing maps task types to pricing rules. Tasks whose type has no
+// rule are paid their plan PaymentAmount.
+type PaymentPricing map[string]PricingRule
+
+// Amount returns the payout for a completed task. task may be nil when the
+// result does not match a plan task; fallback is used when neither the rule
+// nor the plan specifies a base amount.
+func (p PaymentPricing) Amount(task *PlanTask, result TaskResultPayload, fallback int64) int64 {
+	base := fallback
+	if task != nil && task.PaymentAmount > 0 {
+		base = task.PaymentAmount
+	}
+	if task == nil {
+		return base
+	}
+
+	rule, ok := p[task.TaskType]
+	if !ok {
+		return base
+	}
+
+	if rule.Base > 0 {
+		base = rule.Base
+	}
+	amount := base +
+		rule.PerThousandTokens*int64(task.MaxTokens)/1000 +
+		rule.PerSecond*(result.DurationMs/1000)
+	if rule.Max > 0 && amount > rule.Max {
+		amount = rule.Max
+	}
+	return amount
+}
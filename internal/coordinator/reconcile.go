@@ -0,0 +1,320 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+)
+
+// DiscrepancyKind classifies a difference between the payment ledger and the
+// transfers recorded on chain.
+type DiscrepancyKind string
+
+const (
+	// DiscrepancyMissing is a settled ledger record with no matching
+	// on-chain transfer once the indexing grace period has passed.
+	DiscrepancyMissing DiscrepancyKind = "missing"
+
+	// DiscrepancyUnrecorded is an on-chain payment transfer that no settled
+	// ledger record accounts for.
+	DiscrepancyUnrecorded DiscrepancyKind = "unrecorded"
+
+	// DiscrepancyDuplicate is a task paid by more than one on-chain transfer.
+	DiscrepancyDuplicate DiscrepancyKind = "duplicate"
+
+	// DiscrepancyMismatch is an on-chain transfer whose recipient or amount
+	// differs from the ledger record with the same transaction ID.
+	DiscrepancyMismatch DiscrepancyKind = "mismatch"
+)
+
+// PaymentDiscrepancy is one difference found by reconciliation.
+type PaymentDiscrepancy struct {
+	Kind   DiscrepancyKind
+	TaskID string
+	Detail string
+
+	// Ledger is the ledger record involved, if any.
+	Ledger *PaymentRecord
+
+	// Transfers are the on-chain transfers involved, if any.
+	Transfers []hts.TransferRecord
+}
+
+// ReconcileReport is the outcome of one reconciliation run.
+type ReconcileReport struct {
+	Time time.Time
+
+	// Settled counts the settled ledger records checked and Transfers the
+	// on-chain payment transfers from the treasury and escrow account.
+	Settled   int
+	Transfers int
+
+	Discrepancies []PaymentDiscrepancy
+}
+
+// OK reports whether the ledger and chain agree.
+func (r ReconcileReport) OK() bool {
+	return len(r.Discrepancies) == 0
+}
+
+// Reconciler compares the payment ledger with the payment token transfers the
+// treasury and escrow account made on chain, matching them to tasks by the task ID in the
+// transfer memo, or for batched transfers by transaction ID and recipient.
+type Reconciler struct {
+	payment  *Payment
+	history  hts.TransferHistory
+	interval time.Duration
+	grace    time.Duration
+	logger   *slog.Logger
+	now      func() time.Time
+}
+
+// NewReconciler creates a reconciler for the payment manager's ledger that
+// runs every cfg.ReconcileInterval.
+func NewReconciler(payment *Payment, history hts.TransferHistory, cfg Config) *Reconciler {
+	return &Reconciler{
+		payment:  payment,
+		history:  history,
+		interval: cfg.ReconcileInterval,
+		grace:    cfg.ReconcileGrace,
+		logger:   slog.Default(),
+		now:      time.Now,
+	}
+}
+
+// Start reconciles every interval until the context is cancelled, logging
+// each discrepancy found. A zero interval disables it.
+func (r *Reconciler) Start(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("reconciler start: %w", err)
+	}
+	if r.interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			report, err := r.Reconcile(ctx)
+			if err != nil {
+				r.logger.Warn("payment reconciliation failed", "error", err)
+				continue
+			}
+			for _, d := range report.Discrepancies {
+				r.logger.Error("payment discrepancy", "kind", d.Kind, "task_id", d.TaskID, "detail", d.Detail)
+			}
+			r.logger.Info("payment reconciliation complete",
+				"settled", report.Settled, "transfers", report.Transfers, "discrepancies", len(report.Discrepancies))
+		}
+	}
+}
+
+// Reconcile compares the ledger with the treasury's and escrow account's
+// payment transfers since the earliest ledger record.
+func (r *Reconciler) Reconcile(ctx context.Context) (ReconcileReport, error) {
+	report := ReconcileReport{Time: r.now()}
+
+	ledger := r.payment.Ledger()
+	if len(ledger) == 0 {
+		return report, nil
+	}
+
+	history, err := paymentTransfers(ctx, r.history, r.payment.config, ledger[0].Time)
+	if err != nil {
+		return report, fmt.Errorf("reconcile payments: %w", err)
+	}
+
+	settled := make(map[string][]PaymentRecord)
+	byTransaction := make(map[string][]PaymentRecord)
+	for _, rec := range ledger {
+		if rec.State == PaymentProcessed {
+			settled[rec.TaskID] = append(settled[rec.TaskID], rec)
+			byTransaction[rec.TransactionID] = append(byTransaction[rec.TransactionID], rec)
+			report.Settled++
+		}
+	}
+
+	// On-chain payment transfers by task, in consensus order. A batched
+	// transfer's memo names no task, so its credits are attributed through
+	// the ledger records sharing its transaction ID.
+	onChain := make(map[string][]hts.TransferRecord)
+	var unattributed []PaymentDiscrepancy
+	for _, t := range history {
+		if taskID, ok := taskIDFromMemo(t.Memo); ok {
+			onChain[taskID] = append(onChain[taskID], t)
+			report.Transfers++
+			continue
+		}
+		if !strings.HasPrefix(t.Memo, paymentBatchMemoPrefix) {
+			continue
+		}
+		report.Transfers++
+		shares := batchShares(t, byTransaction[t.TransactionID.String()])
+		if len(shares) == 0 {
+			unattributed = append(unattributed, PaymentDiscrepancy{
+				Kind:      DiscrepancyUnrecorded,
+				Detail:    fmt.Sprintf("batch transaction %s paid %d to %s with no settled ledger record", t.TransactionID, t.Amount, t.ToAccountID),
+				Transfers: []hts.TransferRecord{t},
+			})
+		}
+		for taskID, share := range shares {
+			onChain[taskID] = append(onChain[taskID], share)
+		}
+	}
+
+	taskIDs := make([]string, 0, len(onChain)+len(settled))
+	for taskID := range settled {
+		taskIDs = append(taskIDs, taskID)
+	}
+	for taskID := range onChain {
+		if _, ok := settled[taskID]; !ok {
+			taskIDs = append(taskIDs, taskID)
+		}
+	}
+	sort.Strings(taskIDs)
+
+	for _, taskID := range taskIDs {
+		report.Discrepancies = append(report.Discrepancies, r.reconcileTask(taskID, settled[taskID], onChain[taskID], report.Time)...)
+	}
+	report.Discrepancies = append(report.Discrepancies, unattributed...)
+	return report, nil
+}
+
+// paymentTransfers returns the payment token transfers debited from the
+// treasury, or from the escrow account when payments are escrowed, at or
+// after since, in consensus order.
+func paymentTransfers(ctx context.Context, history hts.TransferHistory, cfg Config, since time.Time) ([]hts.TransferRecord, error) {
+	payers := []hiero.AccountID{cfg.TreasuryAccountID}
+	if cfg.EscrowAccountID.Account != 0 {
+		payers = append(payers, cfg.EscrowAccountID)
+	}
+
+	var debits []hts.TransferRecord
+	for _, payer := range payers {
+		transfers, err := history.TokenTransfers(ctx, cfg.PaymentTokenID, payer, since)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range transfers {
+			if t.FromAccountID == payer {
+				debits = append(debits, t)
+			}
+		}
+	}
+	sort.SliceStable(debits, func(i, j int) bool {
+		return debits[i].ConsensusTimestamp.Before(debits[j].ConsensusTimestamp)
+	})
+	return debits, nil
+}
+
+// batchShares attributes one credit of a batched transfer to the settled
+// ledger records in the same transaction that paid its recipient. Payments
+// to one recipient share a credit; when the credit is their sum it is split
+// by record, otherwise each record is given the whole credit so the
+// difference is reported as a mismatch.
+func batchShares(t hts.TransferRecord, records []PaymentRecord) map[string]hts.TransferRecord {
+	var paid []PaymentRecord
+	var sum int64
+	for _, rec := range records {
+		if rec.AgentID == t.ToAccountID.String() {
+			paid = append(paid, rec)
+			sum += rec.Amount
+		}
+	}
+
+	shares := make(map[string]hts.TransferRecord, len(paid))
+	for _, rec := range paid {
+		share := t
+		if sum == t.Amount {
+			share.Amount = rec.Amount
+		}
+		shares[rec.TaskID] = share
+	}
+	return shares
+}
+
+// reconcileTask compares one task's settled ledger records with its on-chain
+// transfers.
+func (r *Reconciler) reconcileTask(taskID string, records []PaymentRecord, transfers []hts.TransferRecord, now time.Time) []PaymentDiscrepancy {
+	var found []PaymentDiscrepancy
+
+	if len(transfers) > 1 {
+		found = append(found, PaymentDiscrepancy{
+			Kind:      DiscrepancyDuplicate,
+			TaskID:    taskID,
+			Detail:    fmt.Sprintf("%d transfers on chain", len(transfers)),
+			Transfers: transfers,
+		})
+	}
+
+	matched := make([]bool, len(transfers))
+	for i := range records {
+		rec := records[i]
+		j := matchTransfer(rec, transfers)
+		if j < 0 {
+			if now.Sub(rec.Time) < r.grace {
+				continue // not yet indexed by the mirror node
+			}
+			found = append(found, PaymentDiscrepancy{
+				Kind:   DiscrepancyMissing,
+				TaskID: taskID,
+				Detail: fmt.Sprintf("attempt %d transaction %s not found on chain", rec.Attempt, rec.TransactionID),
+				Ledger: &rec,
+			})
+			continue
+		}
+		matched[j] = true
+
+		t := transfers[j]
+		if t.ToAccountID.String() != rec.AgentID || t.Amount != rec.Amount {
+			found = append(found, PaymentDiscrepancy{
+				Kind:   DiscrepancyMismatch,
+				TaskID: taskID,
+				Detail: fmt.Sprintf("ledger paid %d to %s, chain paid %d to %s",
+					rec.Amount, rec.AgentID, t.Amount, t.ToAccountID),
+				Ledger:    &rec,
+				Transfers: []hts.TransferRecord{t},
+			})
+		}
+	}
+
+	// A duplicate already reports every transfer for the task.
+	if len(transfers) > 1 {
+		return found
+	}
+	for j, t := range transfers {
+		if matched[j] {
+			continue
+		}
+		found = append(found, PaymentDiscrepancy{
+			Kind:      DiscrepancyUnrecorded,
+			TaskID:    taskID,
+			Detail:    fmt.Sprintf("transaction %s paid %d to %s with no settled ledger record", t.TransactionID, t.Amount, t.ToAccountID),
+			Transfers: []hts.TransferRecord{t},
+		})
+	}
+	return found
+}
+
+// matchTransfer returns the index of the transfer with the record's
+// transaction ID, or -1.
+func matchTransfer(rec PaymentRecord, transfers []hts.TransferRecord) int {
+	for j, t := range transfers {
+		if t.TransactionID.String() == rec.TransactionID {
+			return j
+		}
+	}
+	return -1
+}
@@ -0,0 +1,108 @@
+package coordinator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/sim"
+)
+
+func TestReconciler_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	network := sim.NewNetwork()
+	treasury := network.CreateAccount()
+	agent := network.CreateAccount()
+	other := network.CreateAccount()
+	tokenID, err := network.CreateFungibleToken(ctx, hts.TokenConfig{
+		Name: "Agent Payment Token", Symbol: "APT", InitialSupply: 10_000, TreasuryAccountID: treasury,
+	})
+	if err != nil {
+		t.Fatalf("CreateFungibleToken() error = %v", err)
+	}
+	if err := network.AssociateToken(ctx, tokenID, agent); err != nil {
+		t.Fatalf("AssociateToken() error = %v", err)
+	}
+	if err := network.AssociateToken(ctx, tokenID, other); err != nil {
+		t.Fatalf("AssociateToken() error = %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.PaymentTokenID = tokenID
+	cfg.TreasuryAccountID = treasury
+	cfg.ReconcileGrace = time.Minute
+	p := NewPayment(network, &mockPublisher{}, cfg)
+	r := NewReconciler(p, network, cfg)
+
+	if report, err := r.Reconcile(ctx); err != nil || !report.OK() || report.Transfers != 0 {
+		t.Fatalf("Reconcile() on empty ledger = %+v, %v; want clean report", report, err)
+	}
+
+	for _, taskID := range []string{"task-ok", "task-dup", "task-mismatch"} {
+		if err := p.PayForTask(ctx, taskID, agent.String(), 100); err != nil {
+			t.Fatalf("PayForTask(%s) error = %v", taskID, err)
+		}
+	}
+	transfer := func(memo string, to string, amount int64) {
+		t.Helper()
+		toID := agent
+		if to == "other" {
+			toID = other
+		}
+		if _, err := network.Transfer(ctx, hts.TransferRequest{
+			TokenID: tokenID, FromAccountID: treasury, ToAccountID: toID, Amount: amount, Memo: memo,
+		}); err != nil {
+			t.Fatalf("Transfer() error = %v", err)
+		}
+	}
+	// Paid again outside the coordinator, and paid with no ledger record.
+	transfer(paymentMemo("task-dup"), "agent", 100)
+	transfer(paymentMemo("task-stray"), "other", 50)
+	// Transfers without a payment memo are not payments.
+	transfer("top-up", "other", 10)
+
+	// Settled records whose transfers never reached the chain: one past the
+	// grace period, one still within it.
+	for _, rec := range []PaymentRecord{
+		{TaskID: "task-missing", TransactionID: "0.0.9@1.000000001", Time: time.Now().Add(-time.Hour)},
+		{TaskID: "task-recent", TransactionID: "0.0.9@2.000000002", Time: time.Now()},
+	} {
+		rec.Attempt, rec.AgentID, rec.Amount, rec.State = 1, agent.String(), 100, PaymentProcessed
+		p.updateRecord(rec)
+	}
+	// Rewrite a ledger record to disagree with the chain.
+	p.mu.Lock()
+	p.ledger["task-mismatch"][0].Amount = 90
+	p.mu.Unlock()
+
+	report, err := r.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if report.Settled != 5 || report.Transfers != 5 {
+		t.Errorf("settled, transfers = %d, %d; want 5, 5", report.Settled, report.Transfers)
+	}
+
+	got := make(map[string]DiscrepancyKind)
+	for _, d := range report.Discrepancies {
+		if prev, ok := got[d.TaskID]; ok {
+			t.Errorf("task %s reported as %s and %s", d.TaskID, prev, d.Kind)
+		}
+		got[d.TaskID] = d.Kind
+	}
+	want := map[string]DiscrepancyKind{
+		"task-dup":      DiscrepancyDuplicate,
+		"task-mismatch": DiscrepancyMismatch,
+		"task-missing":  DiscrepancyMissing,
+		"task-stray":    DiscrepancyUnrecorded,
+	}
+	if len(got) != len(want) {
+		t.Errorf("discrepancies = %v, want %v", got, want)
+	}
+	for taskID, kind := range want {
+		if got[taskID] != kind {
+			t.Errorf("task %s discrepancy = %q, want %q", taskID, got[taskID], kind)
+		}
+	}
+}
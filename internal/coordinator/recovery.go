@@ -0,0 +1,42 @@
+package coordinator
+
+import "fmt"
+
+// Recover loads persisted state from the store and rehydrates the given
+// components. Nil components are skipped. Call it before AssignTasks so tasks
+// assigned in a previous run are not dispatched again and tasks already paid
+// are not paid twice.
+//
+// Payments that were pending when the previous run stopped are marked
+// failed, for the PaymentRetrier to settle from the chain or retry. Completed
+// results not yet paid are queued for payment again; call
+// ResultHandler.PayRecovered once the plan is set to pay those whose tasks
+// already completed.
+func Recover(store StateStore, monitor *Monitor, assigner *Assigner, payment *Payment, results *ResultHandler, retrier *Retrier) (StateSnapshot, error) {
+	snap, err := store.Load()
+	if err != nil {
+		return snap, fmt.Errorf("recover coordinator state: %w", err)
+	}
+
+	if monitor != nil {
+		monitor.restore(snap.TaskStatuses)
+	}
+	if assigner != nil {
+		assigner.restore(snap.Assignments)
+	}
+	if payment != nil {
+		payment.restore(snap.Payments)
+		payment.restoreLedger(snap.PaymentRecords)
+		payment.restoreEscrows(snap.Escrows)
+		payment.interruptPending()
+	}
+	if results != nil {
+		results.restore(snap.Results)
+		results.restoreUnpaid()
+	}
+	if retrier != nil {
+		retrier.restore(snap.Attempts)
+	}
+
+	return snap, nil
+}
@@ -0,0 +1,300 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+)
+
+// defaultAgentStaleAfter is how long an agent may go without a heartbeat
+// before it is treated as offline: three missed 30-second heartbeats.
+const defaultAgentStaleAfter = 90 * time.Second
+
+// ErrNoCapableAgent is returned when no available agent can run a task.
+var ErrNoCapableAgent = errors.New("no capable agent available")
+
+// AgentStatus is an agent's availability as last reported or observed.
+type AgentStatus string
+
+const (
+	// AgentStatusUnknown means no heartbeat has been received yet.
+	AgentStatusUnknown AgentStatus = "unknown"
+
+	// AgentStatusOnline means the agent is sending heartbeats.
+	AgentStatusOnline AgentStatus = "online"
+
+	// AgentStatusOffline means the agent reported itself offline or its
+	// heartbeats stopped.
+	AgentStatusOffline AgentStatus = "offline"
+)
+
+// AgentInfo describes a worker agent and what it can run.
+type AgentInfo struct {
+	ID        string `json:"id"`
+	AccountID string `json:"account_id"`
+
+	// TaskTypes lists the PlanTask.TaskType values the agent accepts.
+	// Empty means any task type.
+	TaskTypes []string `json:"task_types,omitempty"`
+
+	// Models lists the PlanTask.ModelID values the agent can serve.
+	// Empty means any model.
+	Models []string `json:"models,omitempty"`
+
+	Status   AgentStatus `json:"status,omitempty"`
+	LastSeen time.Time   `json:"last_seen,omitempty"`
+}
+
+// CanRun reports whether the agent's capabilities cover the task.
+func (a AgentInfo) CanRun(task PlanTask) bool {
+	if task.TaskType != "" && len(a.TaskTypes) > 0 && !containsString(a.TaskTypes, task.TaskType) {
+		return false
+	}
+	if task.ModelID != "" && len(a.Models) > 0 && !containsString(a.Models, task.ModelID) {
+		return false
+	}
+	return true
+}
+
+// HeartbeatPayload is the payload for agent heartbeat messages. Capability
+// fields are optional; when present they replace the registered ones.
+type HeartbeatPayload struct {
+	AgentID   string      `json:"agent_id"`
+	Status    AgentStatus `json:"status,omitempty"`
+	TaskTypes []string    `json:"task_types,omitempty"`
+	Models    []string    `json:"models,omitempty"`
+}
+
+// AgentRegistry tracks the worker agents the coordinator can assign tasks
+// to. Agents are registered from configuration; heartbeats on the status
+// topic keep their status and capabilities current.
+type AgentRegistry struct {
+	logger     *slog.Logger
+	staleAfter time.Duration
+	now        func() time.Time
+
+	mu     sync.RWMutex
+	agents map[string]*AgentInfo
+	order  []string
+	next   int
+}
+
+// NewAgentRegistry creates a registry holding the given agents. Agent IDs
+// must be unique and non-empty.
+func NewAgentRegistry(agents []AgentInfo) (*AgentRegistry, error) {
+	r := &AgentRegistry{
+		logger:     slog.Default(),
+		staleAfter: defaultAgentStaleAfter,
+		now:        time.Now,
+		agents:     make(map[string]*AgentInfo),
+	}
+	for _, agent := range agents {
+		if agent.ID == "" {
+			return nil, fmt.Errorf("register agent with account %q: missing ID", agent.AccountID)
+		}
+		if _, exists := r.agents[agent.ID]; exists {
+			return nil, fmt.Errorf("register agent %s: duplicate ID", agent.ID)
+		}
+		r.Register(agent)
+	}
+	return r, nil
+}
+
+// SetStaleAfter sets how long an agent may go without a heartbeat before it
+// is treated as offline. Zero disables staleness checks.
+func (r *AgentRegistry) SetStaleAfter(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.staleAfter = d
+}
+
+// Register adds an agent or replaces a registered agent's account and capabilities.
+func (r *AgentRegistry) Register(agent AgentInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if agent.Status == "" {
+		agent.Status = AgentStatusUnknown
+	}
+	if _, exists := r.agents[agent.ID]; !exists {
+		r.order = append(r.order, agent.ID)
+	}
+	r.agents[agent.ID] = &agent
+}
+
+// Agent returns a registered agent by ID.
+func (r *AgentRegistry) Agent(agentID string) (AgentInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	agent, ok := r.agents[agentID]
+	if !ok {
+		return AgentInfo{}, false
+	}
+	return r.snapshotLocked(agent), true
+}
+
+// Agents returns every registered agent in registration order.
+func (r *AgentRegistry) Agents() []AgentInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	agents := make([]AgentInfo, 0, len(r.order))
+	for _, id := range r.order {
+		agents = append(agents, r.snapshotLocked(r.agents[id]))
+	}
+	return agents
+}
+
+// Accounts returns the Hedera account ID of every registered agent, keyed by agent ID.
+func (r *AgentRegistry) Accounts() map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	accounts := make(map[string]string, len(r.agents))
+	for id, agent := range r.agents {
+		accounts[id] = agent.AccountID
+	}
+	return accounts
+}
+
+// CanRoute reports whether any registered agent, available or not, can run the task.
+func (r *AgentRegistry) CanRoute(task PlanTask) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, id := range r.order {
+		if r.agents[id].CanRun(task) {
+			return true
+		}
+	}
+	return false
+}
+
+// Select picks an agent to run the task, skipping any in exclude. Online
+// agents are preferred over agents not yet heard from; offline agents are
+// never selected. Ties are broken round-robin so work spreads across equally
+// capable agents.
+func (r *AgentRegistry) Select(task PlanTask, exclude ...string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var online, unknown []string
+	for _, id := range r.order {
+		agent := r.agents[id]
+		if !agent.CanRun(task) || containsString(exclude, id) {
+			continue
+		}
+		switch r.snapshotLocked(agent).Status {
+		case AgentStatusOnline:
+			online = append(online, id)
+		case AgentStatusUnknown:
+			unknown = append(unknown, id)
+		}
+	}
+
+	candidates := online
+	if len(candidates) == 0 {
+		candidates = unknown
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("select agent for task %s (type %q, model %q): %w",
+			task.ID, task.TaskType, task.ModelID, ErrNoCapableAgent)
+	}
+
+	agentID := candidates[r.next%len(candidates)]
+	r.next++
+	return agentID, nil
+}
+
+// Start consumes heartbeats from the HCS topic to keep agent status current.
+// Blocks until the context is cancelled.
+func (r *AgentRegistry) Start(ctx context.Context, subscriber hcs.MessageSubscriber, topicID hiero.TopicID) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("agent registry start: %w", err)
+	}
+
+	msgCh, errCh := subscriber.Subscribe(ctx, topicID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-msgCh:
+			if !ok {
+				return nil
+			}
+			if msg.Type == hcs.MessageTypeHeartbeat {
+				r.handleHeartbeat(msg)
+			}
+		case _, ok := <-errCh:
+			if !ok {
+				errCh = nil // prevent spin on closed channel
+				continue
+			}
+		}
+	}
+}
+
+// handleHeartbeat records a heartbeat from a registered agent. Heartbeats
+// from unregistered senders are ignored so an unknown party cannot make
+// itself eligible for work.
+func (r *AgentRegistry) handleHeartbeat(msg hcs.Envelope) {
+	var payload HeartbeatPayload
+	if len(msg.Payload) > 0 {
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			r.logger.Warn("malformed heartbeat payload", "sender", msg.Sender, "error", err)
+			return
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	agent, ok := r.agents[msg.Sender]
+	if !ok {
+		r.logger.Debug("heartbeat from unregistered agent ignored", "sender", msg.Sender)
+		return
+	}
+
+	agent.LastSeen = r.now()
+	agent.Status = AgentStatusOnline
+	if payload.Status != "" {
+		agent.Status = payload.Status
+	}
+	if len(payload.TaskTypes) > 0 {
+		agent.TaskTypes = append([]string(nil), payload.TaskTypes...)
+	}
+	if len(payload.Models) > 0 {
+		agent.Models = append([]string(nil), payload.Models...)
+	}
+}
+
+// snapshotLocked copies an agent, marking it offline if its heartbeats have
+// gone stale. r.mu must be held.
+func (r *AgentRegistry) snapshotLocked(agent *AgentInfo) AgentInfo {
+	info := *agent
+	info.TaskTypes = append([]string(nil), agent.TaskTypes...)
+	info.Models = append([]string(nil), agent.Models...)
+	if r.staleAfter > 0 && !info.LastSeen.IsZero() && r.now().Sub(info.LastSeen) > r.staleAfter {
+		info.Status = AgentStatusOffline
+	}
+	return info
+}
+
+func containsString(values []string, v string) bool {
+	for _, candidate := range values {
+		if candidate == v {
+			return true
+		}
+	}
+	return false
+}
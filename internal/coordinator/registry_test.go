@@ -0,0 +1,214 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+)
+
+// testRegistry returns a registry with one inference agent and one DeFi agent.
+func testRegistry(t *testing.T) *AgentRegistry {
+	t.Helper()
+	r, err := NewAgentRegistry([]AgentInfo{
+		{ID: "inference-001", AccountID: "0.0.1001", TaskTypes: []string{TaskTypeInference}},
+		{ID: "defi-001", AccountID: "0.0.1002", TaskTypes: []string{TaskTypeTrade}},
+	})
+	if err != nil {
+		t.Fatalf("NewAgentRegistry: %v", err)
+	}
+	return r
+}
+
+func heartbeat(t *testing.T, sender string, payload HeartbeatPayload) hcs.Envelope {
+	t.Helper()
+	b, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal heartbeat: %v", err)
+	}
+	return hcs.Envelope{Type: hcs.MessageTypeHeartbeat, Sender: sender, Payload: b}
+}
+
+func TestNewAgentRegistry_RejectsInvalidAgents(t *testing.T) {
+	if _, err := NewAgentRegistry([]AgentInfo{{AccountID: "0.0.1"}}); err == nil {
+		t.Error("expected error for agent without ID")
+	}
+	if _, err := NewAgentRegistry([]AgentInfo{{ID: "a"}, {ID: "a"}}); err == nil {
+		t.Error("expected error for duplicate agent ID")
+	}
+}
+
+func TestAgentInfo_CanRun(t *testing.T) {
+	agent := AgentInfo{ID: "a", TaskTypes: []string{TaskTypeInference}, Models: []string{"llama-3"}}
+
+	tests := []struct {
+		name string
+		task PlanTask
+		want bool
+	}{
+		{"matching type and model", PlanTask{TaskType: TaskTypeInference, ModelID: "llama-3"}, true},
+		{"no requirements", PlanTask{}, true},
+		{"wrong type", PlanTask{TaskType: TaskTypeTrade}, false},
+		{"wrong model", PlanTask{TaskType: TaskTypeInference, ModelID: "gpt-4"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := agent.CanRun(tt.task); got != tt.want {
+				t.Errorf("CanRun(%+v) = %v, want %v", tt.task, got, tt.want)
+			}
+		})
+	}
+
+	if !(AgentInfo{ID: "any"}).CanRun(PlanTask{TaskType: "audit", ModelID: "x"}) {
+		t.Error("agent without declared capabilities should run any task")
+	}
+}
+
+func TestAgentRegistry_SelectRoutesByCapability(t *testing.T) {
+	r := testRegistry(t)
+
+	got, err := r.Select(PlanTask{ID: "t1", TaskType: TaskTypeTrade})
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+	if got != "defi-001" {
+		t.Errorf("Select(trade) = %q, want defi-001", got)
+	}
+
+	_, err = r.Select(PlanTask{ID: "t2", TaskType: "audit"})
+	if !errors.Is(err, ErrNoCapableAgent) {
+		t.Errorf("Select(audit) error = %v, want ErrNoCapableAgent", err)
+	}
+}
+
+func TestAgentRegistry_SelectPrefersOnlineAndRoundRobins(t *testing.T) {
+	r, err := NewAgentRegistry([]AgentInfo{
+		{ID: "a", TaskTypes: []string{TaskTypeInference}},
+		{ID: "b", TaskTypes: []string{TaskTypeInference}},
+		{ID: "c", TaskTypes: []string{TaskTypeInference}},
+	})
+	if err != nil {
+		t.Fatalf("NewAgentRegistry: %v", err)
+	}
+	task := PlanTask{ID: "t", TaskType: TaskTypeInference}
+
+	seen := map[string]int{}
+	for i := 0; i < 6; i++ {
+		id, err := r.Select(task)
+		if err != nil {
+			t.Fatalf("Select: %v", err)
+		}
+		seen[id]++
+	}
+	if seen["a"] != 2 || seen["b"] != 2 || seen["c"] != 2 {
+		t.Errorf("round-robin distribution = %v, want 2 each", seen)
+	}
+
+	r.handleHeartbeat(heartbeat(t, "b", HeartbeatPayload{AgentID: "b"}))
+	for i := 0; i < 3; i++ {
+		if id, _ := r.Select(task); id != "b" {
+			t.Fatalf("Select = %q, want online agent b", id)
+		}
+	}
+
+	r.handleHeartbeat(heartbeat(t, "b", HeartbeatPayload{AgentID: "b", Status: AgentStatusOffline}))
+	if id, _ := r.Select(task); id == "b" {
+		t.Error("offline agent b was selected")
+	}
+}
+
+func TestAgentRegistry_StaleHeartbeatMarksOffline(t *testing.T) {
+	r := testRegistry(t)
+	now := time.Unix(1_700_000_000, 0)
+	r.now = func() time.Time { return now }
+	r.SetStaleAfter(time.Minute)
+
+	r.handleHeartbeat(heartbeat(t, "defi-001", HeartbeatPayload{AgentID: "defi-001"}))
+	if agent, _ := r.Agent("defi-001"); agent.Status != AgentStatusOnline {
+		t.Fatalf("status = %q, want online", agent.Status)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if agent, _ := r.Agent("defi-001"); agent.Status != AgentStatusOffline {
+		t.Fatalf("status after missed heartbeats = %q, want offline", agent.Status)
+	}
+	if _, err := r.Select(PlanTask{ID: "t", TaskType: TaskTypeTrade}); !errors.Is(err, ErrNoCapableAgent) {
+		t.Errorf("Select error = %v, want ErrNoCapableAgent", err)
+	}
+}
+
+func TestAgentRegistry_HeartbeatUpdatesCapabilities(t *testing.T) {
+	r := testRegistry(t)
+
+	r.handleHeartbeat(heartbeat(t, "inference-001", HeartbeatPayload{
+		AgentID:   "inference-001",
+		TaskTypes: []string{TaskTypeInference, "embedding"},
+		Models:    []string{"llama-3"},
+	}))
+	agent, _ := r.Agent("inference-001")
+	if !agent.CanRun(PlanTask{TaskType: "embedding", ModelID: "llama-3"}) {
+		t.Errorf("capabilities not updated: %+v", agent)
+	}
+
+	r.handleHeartbeat(heartbeat(t, "intruder", HeartbeatPayload{AgentID: "intruder"}))
+	if _, ok := r.Agent("intruder"); ok {
+		t.Error("heartbeat from unregistered sender registered an agent")
+	}
+}
+
+func TestAgentRegistry_StartConsumesHeartbeats(t *testing.T) {
+	r := testRegistry(t)
+	msgCh := make(chan hcs.Envelope, 1)
+	msgCh <- heartbeat(t, "inference-001", HeartbeatPayload{AgentID: "inference-001"})
+	close(msgCh)
+
+	sub := &registrySubscriber{msgCh: msgCh, errCh: make(chan error)}
+	if err := r.Start(context.Background(), sub, hiero.TopicID{Topic: 2}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if agent, _ := r.Agent("inference-001"); agent.Status != AgentStatusOnline {
+		t.Errorf("status = %q, want online", agent.Status)
+	}
+}
+
+func TestAssignTasks_RoutesThroughRegistry(t *testing.T) {
+	pub := &mockPublisher{}
+	a := NewAssigner(pub, hiero.TopicID{Topic: 1}, nil)
+	a.SetRegistry(testRegistry(t))
+
+	plan := Plan{
+		FestivalID: "fest-registry",
+		Sequences: []PlanSequence{{ID: "seq-1", Tasks: []PlanTask{
+			{ID: "infer", TaskType: TaskTypeInference},
+			{ID: "audit", TaskType: "audit"},
+		}}},
+	}
+
+	ids, err := a.AssignTasks(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("AssignTasks: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != "infer" {
+		t.Fatalf("assigned IDs = %v, want [infer]", ids)
+	}
+	if got := a.Assignment("infer"); got != "inference-001" {
+		t.Errorf("Assignment(infer) = %q, want inference-001", got)
+	}
+	if got := a.Assignment("audit"); got != "" {
+		t.Errorf("Assignment(audit) = %q, want unassigned", got)
+	}
+}
+
+type registrySubscriber struct {
+	msgCh chan hcs.Envelope
+	errCh chan error
+}
+
+func (s *registrySubscriber) Subscribe(_ context.Context, _ hiero.TopicID) (<-chan hcs.Envelope, <-chan error) {
+	return s.msgCh, s.errCh
+}
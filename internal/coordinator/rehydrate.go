@@ -0,0 +1,264 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+)
+
+// RehydrateConfig holds the inputs for rebuilding coordinator state from HCS history.
+type RehydrateConfig struct {
+	Replayer hcs.MessageReplayer
+	Config   Config
+	From     hcs.ReplayOptions
+	Monitor  *Monitor
+	Assigner *Assigner
+	Payment  *Payment
+	Results  *ResultHandler // optional
+	Log      *slog.Logger
+
+	// Checkpoints is optional. It should be the SequencedSubscriber the
+	// live subscriptions go through: each replayed topic's checkpoint is
+	// advanced to the last replayed message, so live subscriptions resume
+	// after the history already folded instead of delivering it again.
+	Checkpoints *hcs.SequencedSubscriber
+}
+
+// RehydrateStats counts the replayed messages folded into coordinator state.
+type RehydrateStats struct {
+	Assignments   int
+	StatusUpdates int
+	Results       int
+	Settlements   int
+	Skipped       int
+}
+
+// Rehydrate rebuilds task, assignment and payment state by replaying the task
+// and status topics and folding task_assignment, status_update, task_result,
+// quality_gate and payment_settled messages into the given components. No
+// transfers are made and no messages are published. Messages are folded in
+// causal order: assignments first, then agent status and results, then gate
+// decisions and settlements.
+//
+// Status updates and results are only taken from the agent the task is
+// assigned to. An agent's complete status is a claim the quality gate had to
+// grant, so it is folded as review; the task only becomes complete when a
+// passed quality_gate decision covering it or its settlement confirms it.
+// Completed results left unpaid are queued as by Recover.
+//
+// With cfg.Checkpoints set, each topic's checkpoint is advanced past the
+// replayed history.
+func Rehydrate(ctx context.Context, cfg RehydrateConfig) (RehydrateStats, error) {
+	var stats RehydrateStats
+	if err := ctx.Err(); err != nil {
+		return stats, fmt.Errorf("rehydrate: %w", err)
+	}
+
+	log := cfg.Log
+	if log == nil {
+		log = slog.Default()
+	}
+
+	from := cfg.From
+	if from.EndTime.IsZero() {
+		from.EndTime = time.Now()
+	}
+
+	taskMsgs, err := collectReplay(ctx, cfg.Replayer, cfg.Config.TaskTopicID, from, log)
+	if err != nil {
+		return stats, fmt.Errorf("rehydrate: replay task topic %s: %w", cfg.Config.TaskTopicID, err)
+	}
+	statusMsgs, err := collectReplay(ctx, cfg.Replayer, cfg.Config.StatusTopicID, from, log)
+	if err != nil {
+		return stats, fmt.Errorf("rehydrate: replay status topic %s: %w", cfg.Config.StatusTopicID, err)
+	}
+
+	for _, env := range taskMsgs {
+		if env.Type == hcs.MessageTypeTaskAssignment {
+			foldAssignment(cfg, env, &stats)
+		}
+	}
+	for _, env := range statusMsgs {
+		switch env.Type {
+		case hcs.MessageTypeStatusUpdate:
+			foldStatusUpdate(cfg, env, &stats)
+		case hcs.MessageTypeTaskResult:
+			foldTaskResult(cfg, env, &stats)
+		}
+	}
+	for _, env := range taskMsgs {
+		switch env.Type {
+		case hcs.MessageTypeQualityGate:
+			foldGateDecision(cfg, env)
+		case hcs.MessageTypePaymentSettled:
+			foldSettlement(cfg, env, &stats, log)
+		}
+	}
+	if cfg.Results != nil {
+		cfg.Results.restoreUnpaid()
+	}
+	if cfg.Checkpoints != nil {
+		seedCheckpoint(cfg.Checkpoints, cfg.Config.TaskTopicID, taskMsgs)
+		seedCheckpoint(cfg.Checkpoints, cfg.Config.StatusTopicID, statusMsgs)
+	}
+
+	return stats, nil
+}
+
+// seedCheckpoint advances a topic's checkpoint to the last replayed message
+// that carries its topic position, leaving a later checkpoint in place.
+func seedCheckpoint(checkpoints *hcs.SequencedSubscriber, topicID hiero.TopicID, msgs []hcs.Envelope) {
+	for i := len(msgs) - 1; i >= 0; i-- {
+		env := msgs[i]
+		if env.TopicSequenceNum == 0 {
+			continue
+		}
+		if cp, ok := checkpoints.Checkpoint(topicID); ok && cp.SequenceNumber >= env.TopicSequenceNum {
+			return
+		}
+		checkpoints.SetCheckpoint(topicID, hcs.Checkpoint{
+			ConsensusTimestamp: env.ConsensusTimestamp,
+			SequenceNumber:     env.TopicSequenceNum,
+		})
+		return
+	}
+}
+
+// collectReplay drains a bounded replay of a topic into memory.
+func collectReplay(ctx context.Context, replayer hcs.MessageReplayer, topicID hiero.TopicID, opts hcs.ReplayOptions, log *slog.Logger) ([]hcs.Envelope, error) {
+	msgCh, errCh := replayer.Replay(ctx, topicID, opts)
+
+	var msgs []hcs.Envelope
+	for msgCh != nil || errCh != nil {
+		select {
+		case env, ok := <-msgCh:
+			if !ok {
+				msgCh = nil
+				continue
+			}
+			msgs = append(msgs, env)
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+				continue
+			}
+			log.Warn("replay error", "topic", topicID, "error", err)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return msgs, err
+	}
+	return msgs, nil
+}
+
+func foldAssignment(cfg RehydrateConfig, env hcs.Envelope, stats *RehydrateStats) {
+	var payload TaskAssignmentPayload
+	if env.Sender != "coordinator" || json.Unmarshal(env.Payload, &payload) != nil || payload.TaskID == "" {
+		stats.Skipped++
+		return
+	}
+
+	if cfg.Assigner != nil {
+		cfg.Assigner.restore(map[string]string{payload.TaskID: payload.AgentID})
+	}
+	if cfg.Monitor != nil {
+		cfg.Monitor.MarkAssigned(payload.TaskID)
+	}
+	stats.Assignments++
+}
+
+// fromAssignee reports whether a replayed agent message was sent by the
+// agent the task is assigned to.
+func fromAssignee(cfg RehydrateConfig, taskID, sender string) bool {
+	if cfg.Assigner == nil {
+		return false
+	}
+	assigned := cfg.Assigner.Assignment(taskID)
+	return assigned != "" && assigned == sender
+}
+
+func foldStatusUpdate(cfg RehydrateConfig, env hcs.Envelope, stats *RehydrateStats) {
+	var payload StatusUpdatePayload
+	if json.Unmarshal(env.Payload, &payload) != nil || payload.TaskID == "" ||
+		!fromAssignee(cfg, payload.TaskID, env.Sender) {
+		stats.Skipped++
+		return
+	}
+
+	if cfg.Monitor != nil {
+		status := payload.NewStatus
+		if status == StatusComplete {
+			status = StatusReview
+			if current, err := cfg.Monitor.TaskState(payload.TaskID); err == nil && current == StatusReview {
+				stats.StatusUpdates++
+				return
+			}
+		}
+		if err := cfg.Monitor.applyTransition(payload.TaskID, status); err != nil {
+			stats.Skipped++
+			return
+		}
+	}
+	stats.StatusUpdates++
+}
+
+func foldTaskResult(cfg RehydrateConfig, env hcs.Envelope, stats *RehydrateStats) {
+	var result TaskResultPayload
+	if json.Unmarshal(env.Payload, &result) != nil || result.TaskID == "" ||
+		!fromAssignee(cfg, result.TaskID, env.Sender) {
+		stats.Skipped++
+		return
+	}
+
+	if cfg.Results != nil {
+		cfg.Results.restore(map[string]TaskResultPayload{result.TaskID: result})
+	}
+	stats.Results++
+}
+
+// foldGateDecision completes the tasks in review that a passed sequence gate
+// covered: the gate only passes once every task it covers is complete.
+func foldGateDecision(cfg RehydrateConfig, env hcs.Envelope) {
+	var payload QualityGatePayload
+	if env.Sender != "coordinator" || json.Unmarshal(env.Payload, &payload) != nil ||
+		payload.Decision != GatePassed || cfg.Monitor == nil {
+		return
+	}
+	for _, taskID := range payload.TaskIDs {
+		confirmComplete(cfg.Monitor, taskID)
+	}
+}
+
+// confirmComplete moves a task folded as review to complete.
+func confirmComplete(m *Monitor, taskID string) {
+	if current, err := m.TaskState(taskID); err == nil && current == StatusReview {
+		_ = m.applyTransition(taskID, StatusComplete)
+	}
+}
+
+func foldSettlement(cfg RehydrateConfig, env hcs.Envelope, stats *RehydrateStats, log *slog.Logger) {
+	var payload PaymentSettledPayload
+	if env.Sender != "coordinator" || json.Unmarshal(env.Payload, &payload) != nil || payload.TaskID == "" {
+		stats.Skipped++
+		return
+	}
+
+	if cfg.Payment != nil {
+		cfg.Payment.restore(map[string]PaymentState{payload.TaskID: PaymentProcessed})
+	}
+	if cfg.Monitor != nil {
+		confirmComplete(cfg.Monitor, payload.TaskID)
+		if err := cfg.Monitor.applyTransition(payload.TaskID, StatusPaid); err != nil {
+			log.Warn("settled task not complete in replayed history",
+				"task_id", payload.TaskID, "error", err)
+		}
+	}
+	stats.Settlements++
+}
@@ -0,0 +1,222 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/sim"
+)
+
+// fakeReplayer replays fixed per-topic histories.
+type fakeReplayer struct {
+	history map[hiero.TopicID][]hcs.Envelope
+}
+
+func (r *fakeReplayer) Replay(_ context.Context, topicID hiero.TopicID, _ hcs.ReplayOptions) (<-chan hcs.Envelope, <-chan error) {
+	msgs := r.history[topicID]
+	msgCh := make(chan hcs.Envelope, len(msgs))
+	errCh := make(chan error)
+	for _, m := range msgs {
+		msgCh <- m
+	}
+	close(msgCh)
+	close(errCh)
+	return msgCh, errCh
+}
+
+func replayEnvelope(t *testing.T, msgType hcs.MessageType, sender string, payload any) hcs.Envelope {
+	t.Helper()
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	return hcs.Envelope{Type: msgType, Sender: sender, Payload: raw}
+}
+
+func TestRehydrate_FoldsHistory(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TaskTopicID = hiero.TopicID{Topic: 1}
+	cfg.StatusTopicID = hiero.TopicID{Topic: 2}
+
+	replayer := &fakeReplayer{history: map[hiero.TopicID][]hcs.Envelope{
+		cfg.TaskTopicID: {
+			replayEnvelope(t, hcs.MessageTypeTaskAssignment, "coordinator", TaskAssignmentPayload{TaskID: "task-1", AgentID: "inference-001"}),
+			replayEnvelope(t, hcs.MessageTypeTaskAssignment, "coordinator", TaskAssignmentPayload{TaskID: "task-2", AgentID: "defi-001"}),
+			// Settlement published before the status topic history is folded.
+			replayEnvelope(t, hcs.MessageTypePaymentSettled, "coordinator", PaymentSettledPayload{TaskID: "task-1", Amount: 100}),
+			// Spoofed settlement from an agent must be ignored.
+			replayEnvelope(t, hcs.MessageTypePaymentSettled, "defi-001", PaymentSettledPayload{TaskID: "task-2", Amount: 100}),
+		},
+		cfg.StatusTopicID: {
+			replayEnvelope(t, hcs.MessageTypeStatusUpdate, "inference-001", StatusUpdatePayload{TaskID: "task-1", NewStatus: StatusInProgress}),
+			replayEnvelope(t, hcs.MessageTypeStatusUpdate, "inference-001", StatusUpdatePayload{TaskID: "task-1", NewStatus: StatusReview}),
+			replayEnvelope(t, hcs.MessageTypeStatusUpdate, "inference-001", StatusUpdatePayload{TaskID: "task-1", NewStatus: StatusComplete}),
+			replayEnvelope(t, hcs.MessageTypeTaskResult, "inference-001", TaskResultPayload{TaskID: "task-1", Status: "completed"}),
+			replayEnvelope(t, hcs.MessageTypeStatusUpdate, "defi-001", StatusUpdatePayload{TaskID: "task-2", NewStatus: StatusInProgress}),
+		},
+	}}
+
+	monitor := NewMonitor(nil, cfg.StatusTopicID, nil)
+	assigner := NewAssigner(nil, cfg.TaskTopicID, nil)
+	// A nil transfer service would panic if Rehydrate attempted a transfer.
+	payment := NewPayment(nil, nil, cfg)
+	results := NewResultHandler(ResultHandlerConfig{Config: cfg})
+
+	stats, err := Rehydrate(context.Background(), RehydrateConfig{
+		Replayer: replayer,
+		Config:   cfg,
+		Monitor:  monitor,
+		Assigner: assigner,
+		Payment:  payment,
+		Results:  results,
+	})
+	if err != nil {
+		t.Fatalf("Rehydrate() error = %v", err)
+	}
+
+	if stats.Assignments != 2 || stats.StatusUpdates != 4 || stats.Results != 1 || stats.Settlements != 1 || stats.Skipped != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if got := assigner.Assignment("task-2"); got != "defi-001" {
+		t.Errorf("task-2 assignment = %q, want defi-001", got)
+	}
+	if status, _ := monitor.TaskState("task-1"); status != StatusPaid {
+		t.Errorf("task-1 status = %s, want paid", status)
+	}
+	if status, _ := monitor.TaskState("task-2"); status != StatusInProgress {
+		t.Errorf("task-2 status = %s, want in_progress", status)
+	}
+	if state, _ := payment.PaymentStatus("task-1"); state != PaymentProcessed {
+		t.Errorf("task-1 payment = %s, want processed", state)
+	}
+	if _, err := payment.PaymentStatus("task-2"); err == nil {
+		t.Error("spoofed settlement should not be tracked")
+	}
+	if _, ok := results.Result("task-1"); !ok {
+		t.Error("task-1 result not rehydrated")
+	}
+}
+
+func TestRehydrate_TrustsOnlyAssigneeAndConfirmedCompletion(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TaskTopicID = hiero.TopicID{Topic: 1}
+	cfg.StatusTopicID = hiero.TopicID{Topic: 2}
+
+	replayer := &fakeReplayer{history: map[hiero.TopicID][]hcs.Envelope{
+		cfg.TaskTopicID: {
+			replayEnvelope(t, hcs.MessageTypeTaskAssignment, "coordinator", TaskAssignmentPayload{TaskID: "task-1", AgentID: "inference-001"}),
+			replayEnvelope(t, hcs.MessageTypeTaskAssignment, "coordinator", TaskAssignmentPayload{TaskID: "task-2", AgentID: "inference-001"}),
+			replayEnvelope(t, hcs.MessageTypeTaskAssignment, "coordinator", TaskAssignmentPayload{TaskID: "task-3", AgentID: "inference-001"}),
+			replayEnvelope(t, hcs.MessageTypeQualityGate, "coordinator", QualityGatePayload{
+				GateID: "gate-1", Decision: GatePassed, TaskIDs: []string{"task-2"},
+			}),
+			// A gate decision from an agent confirms nothing.
+			replayEnvelope(t, hcs.MessageTypeQualityGate, "inference-001", QualityGatePayload{
+				GateID: "gate-2", Decision: GatePassed, TaskIDs: []string{"task-1"},
+			}),
+		},
+		cfg.StatusTopicID: {
+			replayEnvelope(t, hcs.MessageTypeStatusUpdate, "inference-001", StatusUpdatePayload{TaskID: "task-1", NewStatus: StatusInProgress}),
+			replayEnvelope(t, hcs.MessageTypeStatusUpdate, "inference-001", StatusUpdatePayload{TaskID: "task-1", NewStatus: StatusComplete}),
+			replayEnvelope(t, hcs.MessageTypeStatusUpdate, "inference-001", StatusUpdatePayload{TaskID: "task-2", NewStatus: StatusInProgress}),
+			replayEnvelope(t, hcs.MessageTypeStatusUpdate, "inference-001", StatusUpdatePayload{TaskID: "task-2", NewStatus: StatusComplete}),
+			// Another agent cannot move or answer for a task it does not hold.
+			replayEnvelope(t, hcs.MessageTypeStatusUpdate, "defi-001", StatusUpdatePayload{TaskID: "task-3", NewStatus: StatusInProgress}),
+			replayEnvelope(t, hcs.MessageTypeTaskResult, "defi-001", TaskResultPayload{TaskID: "task-3", Status: "completed"}),
+		},
+	}}
+
+	monitor := NewMonitor(nil, cfg.StatusTopicID, nil)
+	assigner := NewAssigner(nil, cfg.TaskTopicID, nil)
+	results := NewResultHandler(ResultHandlerConfig{Config: cfg})
+
+	stats, err := Rehydrate(context.Background(), RehydrateConfig{
+		Replayer: replayer,
+		Config:   cfg,
+		Monitor:  monitor,
+		Assigner: assigner,
+		Payment:  NewPayment(nil, nil, cfg),
+		Results:  results,
+	})
+	if err != nil {
+		t.Fatalf("Rehydrate() error = %v", err)
+	}
+
+	if stats.StatusUpdates != 4 || stats.Results != 0 || stats.Skipped != 2 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if status, _ := monitor.TaskState("task-1"); status != StatusReview {
+		t.Errorf("unconfirmed task-1 status = %s, want review", status)
+	}
+	if status, _ := monitor.TaskState("task-2"); status != StatusComplete {
+		t.Errorf("gate-confirmed task-2 status = %s, want complete", status)
+	}
+	if status, _ := monitor.TaskState("task-3"); status != StatusAssigned {
+		t.Errorf("task-3 status = %s, want assigned", status)
+	}
+	if _, ok := results.Result("task-3"); ok {
+		t.Error("result from a non-assigned agent should not be rehydrated")
+	}
+}
+
+func TestRehydrate_LiveSubscriptionSkipsReplayedHistory(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	network := sim.NewNetwork()
+	cfg := DefaultConfig()
+	for _, id := range []*hiero.TopicID{&cfg.TaskTopicID, &cfg.StatusTopicID} {
+		topicID, err := network.CreateTopic(ctx, "test")
+		if err != nil {
+			t.Fatalf("CreateTopic() error = %v", err)
+		}
+		*id = topicID
+	}
+	publish := func(topicID hiero.TopicID, env hcs.Envelope) {
+		t.Helper()
+		if err := network.Publish(ctx, topicID, env); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	publish(cfg.TaskTopicID, replayEnvelope(t, hcs.MessageTypeTaskAssignment, "coordinator", TaskAssignmentPayload{TaskID: "task-1", AgentID: "inference-001"}))
+	publish(cfg.StatusTopicID, replayEnvelope(t, hcs.MessageTypeStatusUpdate, "inference-001", StatusUpdatePayload{TaskID: "task-1", NewStatus: StatusInProgress}))
+
+	sequenced := hcs.NewSequencedSubscriber(network)
+	if _, err := Rehydrate(ctx, RehydrateConfig{
+		Replayer:    network,
+		Config:      cfg,
+		Monitor:     NewMonitor(nil, cfg.StatusTopicID, nil),
+		Assigner:    NewAssigner(nil, cfg.TaskTopicID, nil),
+		Payment:     NewPayment(nil, nil, cfg),
+		Checkpoints: sequenced,
+	}); err != nil {
+		t.Fatalf("Rehydrate() error = %v", err)
+	}
+
+	// The live subscription starts after the folded status update.
+	msgCh, _ := sequenced.Subscribe(ctx, cfg.StatusTopicID)
+	publish(cfg.StatusTopicID, replayEnvelope(t, hcs.MessageTypeStatusUpdate, "inference-001", StatusUpdatePayload{TaskID: "task-1", NewStatus: StatusReview}))
+	select {
+	case env := <-msgCh:
+		var update StatusUpdatePayload
+		if err := json.Unmarshal(env.Payload, &update); err != nil || update.NewStatus != StatusReview {
+			t.Errorf("first live message = %+v, want the review update published after rehydration", update)
+		}
+	case <-ctx.Done():
+		t.Fatal("no live message delivered")
+	}
+}
+
+func TestRehydrate_ContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := Rehydrate(ctx, RehydrateConfig{Replayer: &fakeReplayer{}}); err == nil {
+		t.Error("expected error for cancelled context")
+	}
+}
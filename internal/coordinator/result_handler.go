@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sort"
 	"sync"
+	"time"
 
 	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
 
@@ -22,10 +24,36 @@ type TaskResultPayload struct {
 	TxHash     string `json:"tx_hash,omitempty"`
 }
 
+// Protocol violation reasons published when a task result is rejected.
+const (
+	ViolationTaskNotAssigned = "task_not_assigned"
+	ViolationWrongAgent      = "sender_not_assigned_agent"
+)
+
+// ProtocolViolationPayload is the HCS message payload for a rejected agent message.
+type ProtocolViolationPayload struct {
+	TaskID        string `json:"task_id"`
+	Sender        string `json:"sender"`
+	AssignedAgent string `json:"assigned_agent,omitempty"`
+	MessageType   string `json:"message_type"`
+	Reason        string `json:"reason"`
+}
+
+// OutputRejectedPayload is the HCS message payload telling an agent its task
+// result failed output validation. The task is back in progress and the agent
+// is expected to send a corrected result.
+type OutputRejectedPayload struct {
+	TaskID   string `json:"task_id"`
+	AgentID  string `json:"agent_id"`
+	TaskType string `json:"task_type,omitempty"`
+	Reason   string `json:"reason"`
+}
+
 // ResultHandler subscribes to the status topic and processes task_result
 // and pnl_report messages from agents.
 type ResultHandler struct {
 	subscriber hcs.MessageSubscriber
+	publisher  hcs.MessagePublisher
 	topicID    hiero.TopicID
 	payment    PaymentManager
 	config     Config
@@ -34,33 +62,102 @@ type ResultHandler struct {
 	// agentAccounts maps agent ID → Hedera account ID string for payments.
 	agentAccounts map[string]string
 
+	assignments AssignmentLookup
+	pricing     PaymentPricing
+	store       StateStore
+	retrier     *Retrier
+	outputGate  *OutputGate
+	monitor     *Monitor
+	events      *EventBus
+	escrow      PaymentEscrow
+
+	// stopEvents ends the event subscription taken when the handler was
+	// created and cancels the context its events are handled under.
+	stopEvents func()
+
 	mu      sync.RWMutex
+	plan    Plan
 	results map[string]TaskResultPayload
+	unpaid  map[string]string // taskID -> agent ID, accepted results awaiting completion
+	seqNum  uint64
 }
 
 // ResultHandlerConfig holds configuration for the result handler.
 type ResultHandlerConfig struct {
 	Subscriber    hcs.MessageSubscriber
+	Publisher     hcs.MessagePublisher // publishes protocol violations to Config.TaskTopicID
 	TopicID       hiero.TopicID
 	Payment       PaymentManager
 	Config        Config
 	Log           *slog.Logger
 	AgentAccounts map[string]string
+	Assignments   AssignmentLookup // resolves the agent a result must come from
+	Pricing       PaymentPricing   // optional; per-task-type pricing rules
+	Store         StateStore       // optional; results are written through when set
+	Retrier       *Retrier         // optional; records attempts and retries failed tasks
+	OutputGate    *OutputGate      // optional; validates completed outputs before payment
+	Monitor       *Monitor         // optional; reopens tasks whose output is rejected
+
+	// Events is optional; results are published to it. With both Events and
+	// Monitor set, a task is only paid once the monitor reports it complete.
+	Events *EventBus
+
+	// Escrow is optional and requires Events. With it set, the handler is
+	// the Assigner's AssignmentEscrow: a task's base payment is escrowed
+	// before its assignment is published, and the escrow is cancelled if the
+	// task fails; PayForTask then releases it.
+	Escrow PaymentEscrow
 }
 
-// NewResultHandler creates a handler that processes agent results from the status topic.
+// NewResultHandler creates a handler that processes agent results from the
+// status topic. It subscribes to cfg.Events at once, so tasks that fail or
+// complete before Start is called are still handled.
 func NewResultHandler(cfg ResultHandlerConfig) *ResultHandler {
-	return &ResultHandler{
+	rh := &ResultHandler{
 		subscriber:    cfg.Subscriber,
+		publisher:     cfg.Publisher,
 		topicID:       cfg.TopicID,
 		payment:       cfg.Payment,
 		config:        cfg.Config,
 		log:           cfg.Log,
 		agentAccounts: cfg.AgentAccounts,
+		assignments:   cfg.Assignments,
+		pricing:       cfg.Pricing,
+		store:         cfg.Store,
+		retrier:       cfg.Retrier,
+		outputGate:    cfg.OutputGate,
+		monitor:       cfg.Monitor,
+		events:        cfg.Events,
+		escrow:        cfg.Escrow,
 		results:       make(map[string]TaskResultPayload),
+		unpaid:        make(map[string]string),
+	}
+	rh.subscribeEvents()
+	return rh
+}
+
+// subscribeEvents subscribes to the lifecycle events the handler acts on:
+// failures when escrowing, completions when payment waits for the monitor.
+func (rh *ResultHandler) subscribeEvents() {
+	ctx, cancel := context.WithCancel(context.Background())
+	rh.stopEvents = cancel
+	if !rh.gatesPayment() && (rh.events == nil || rh.escrow == nil) {
+		return
+	}
+	unsubscribe := rh.events.Subscribe(func(e Event) { rh.handleEvent(ctx, e) })
+	rh.stopEvents = func() {
+		unsubscribe()
+		cancel()
 	}
 }
 
+// SetPlan sets the plan that completed tasks are priced against.
+func (rh *ResultHandler) SetPlan(plan Plan) {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+	rh.plan = plan
+}
+
 // Start begins listening for results on the status topic. Blocks until ctx is cancelled.
 func (rh *ResultHandler) Start(ctx context.Context) error {
 	if err := ctx.Err(); err != nil {
@@ -68,6 +165,7 @@ func (rh *ResultHandler) Start(ctx context.Context) error {
 	}
 
 	msgCh, errCh := rh.subscriber.Subscribe(ctx, rh.topicID)
+	defer rh.stopEvents()
 
 	for {
 		select {
@@ -96,6 +194,82 @@ func (rh *ResultHandler) Result(taskID string) (TaskResultPayload, bool) {
 	return r, ok
 }
 
+// restore replaces stored results with those recovered from a store.
+func (rh *ResultHandler) restore(results map[string]TaskResultPayload) {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+	for taskID, result := range results {
+		rh.results[taskID] = result
+	}
+}
+
+// restoreUnpaid rebuilds the accepted results awaiting payment from the
+// restored results: completed results whose task has no payment attempt
+// and, when payment waits for completion, is in review or complete.
+func (rh *ResultHandler) restoreUnpaid() {
+	if rh.payment == nil || rh.assignments == nil {
+		return
+	}
+	rh.mu.RLock()
+	var completed []string
+	for taskID, result := range rh.results {
+		if result.Status == "completed" {
+			completed = append(completed, taskID)
+		}
+	}
+	rh.mu.RUnlock()
+
+	for _, taskID := range completed {
+		agentID := rh.assignments.Assignment(taskID)
+		if agentID == "" {
+			continue
+		}
+		if _, err := rh.payment.PaymentStatus(taskID); err == nil {
+			continue // already paid, pending or left to the payment retrier
+		}
+		if rh.gatesPayment() {
+			status, err := rh.monitor.TaskState(taskID)
+			if err != nil || (status != StatusReview && status != StatusComplete) {
+				continue
+			}
+		}
+		rh.mu.Lock()
+		rh.unpaid[taskID] = agentID
+		rh.mu.Unlock()
+	}
+}
+
+// PayRecovered pays for restored results whose tasks completed before a
+// restart without being paid, and returns how many it paid. Recovered tasks
+// still in review have their quality gate evaluated again; they are paid
+// when it completes them. Call it after SetPlan, so payments are priced
+// against the plan.
+func (rh *ResultHandler) PayRecovered(ctx context.Context) int {
+	rh.mu.RLock()
+	var taskIDs []string
+	for taskID := range rh.unpaid {
+		taskIDs = append(taskIDs, taskID)
+	}
+	rh.mu.RUnlock()
+	sort.Strings(taskIDs)
+
+	var paid int
+	for _, taskID := range taskIDs {
+		if rh.gatesPayment() {
+			status, err := rh.monitor.TaskState(taskID)
+			if err == nil && status == StatusReview {
+				rh.monitor.recheckGate(ctx, taskID)
+			}
+			if err != nil || status != StatusComplete {
+				continue
+			}
+		}
+		rh.payUnpaid(ctx, taskID)
+		paid++
+	}
+	return paid
+}
+
 func (rh *ResultHandler) processMessage(ctx context.Context, msg hcs.Envelope) {
 	switch msg.Type {
 	case hcs.MessageTypeTaskResult:
@@ -112,42 +286,282 @@ func (rh *ResultHandler) handleTaskResult(ctx context.Context, msg hcs.Envelope)
 		return
 	}
 
+	// Only the agent the task was assigned to may report its result.
+	assigned := ""
+	if rh.assignments != nil {
+		assigned = rh.assignments.Assignment(result.TaskID)
+	}
+	if assigned == "" || assigned != msg.Sender {
+		reason := ViolationWrongAgent
+		if assigned == "" {
+			reason = ViolationTaskNotAssigned
+		}
+		rh.log.Warn("rejecting task result",
+			"task_id", result.TaskID,
+			"sender", msg.Sender,
+			"assigned_agent", assigned,
+			"reason", reason)
+		rh.publishViolation(ctx, msg, result.TaskID, assigned, reason)
+		return
+	}
+
 	rh.mu.Lock()
 	rh.results[result.TaskID] = result
 	rh.mu.Unlock()
 
+	if rh.store != nil {
+		if err := rh.store.SaveResult(result); err != nil {
+			rh.log.Warn("failed to persist task result", "task_id", result.TaskID, "error", err)
+		}
+	}
+
 	rh.log.Info("task result received",
 		"task_id", result.TaskID,
 		"status", result.Status,
 		"sender", msg.Sender,
 		"duration_ms", result.DurationMs)
 
+	if rh.retrier != nil {
+		rh.retrier.HandleResult(ctx, assigned, result)
+	}
+
 	if result.Status != "completed" {
+		rh.mu.Lock()
+		delete(rh.unpaid, result.TaskID)
+		rh.mu.Unlock()
+		rh.events.Publish(ResultReceived{TaskID: result.TaskID, AgentID: assigned, Result: result})
+		return
+	}
+
+	if rh.outputGate != nil {
+		if err := rh.outputGate.Check(result); err != nil {
+			rh.mu.Lock()
+			delete(rh.unpaid, result.TaskID)
+			rh.mu.Unlock()
+			rh.events.Publish(ResultReceived{TaskID: result.TaskID, AgentID: assigned, Result: result, Reason: err.Error()})
+			rh.rejectOutput(ctx, assigned, result, err)
+			return
+		}
+	}
+	rh.events.Publish(ResultReceived{TaskID: result.TaskID, AgentID: assigned, Result: result, Accepted: true})
+	if rh.monitor != nil {
+		// A completion claimed before the result arrived is held in review
+		// until the gate can see the result.
+		rh.monitor.recheckGate(ctx, result.TaskID)
+	}
+
+	if !rh.gatesPayment() {
+		rh.pay(ctx, assigned, result)
 		return
 	}
 
-	// Resolve agent account ID for payment.
-	agentAccountID, ok := rh.agentAccounts[msg.Sender]
+	// Record the result as unpaid before checking the monitor, so a
+	// completion racing with this result is seen by one side or the other.
+	rh.mu.Lock()
+	rh.unpaid[result.TaskID] = assigned
+	rh.mu.Unlock()
+	if status, err := rh.monitor.TaskState(result.TaskID); err == nil && status == StatusComplete {
+		rh.payUnpaid(ctx, result.TaskID)
+		return
+	}
+	rh.log.Info("payment waiting for task completion", "task_id", result.TaskID, "agent_id", assigned)
+}
+
+// gatesPayment reports whether payments wait for the monitor to report
+// tasks complete.
+func (rh *ResultHandler) gatesPayment() bool {
+	return rh.events != nil && rh.monitor != nil
+}
+
+// handleEvent pays for a task with an accepted result once the monitor
+// reports it complete, and cancels the escrowed payment of a task that fails.
+func (rh *ResultHandler) handleEvent(ctx context.Context, e Event) {
+	switch e := e.(type) {
+	case TaskStatusChanged:
+		switch {
+		case e.To == StatusComplete && rh.gatesPayment():
+			rh.payUnpaid(ctx, e.TaskID)
+		case e.To == StatusFailed && rh.escrow != nil:
+			if err := rh.escrow.CancelEscrow(ctx, e.TaskID); err != nil {
+				rh.log.Error("escrow cancellation failed", "task_id", e.TaskID, "error", err)
+			}
+		}
+	}
+}
+
+// EscrowAssignment commits the task's base payment to the agent being
+// assigned it until the task's timeout. The base amount ignores
+// result-dependent pricing such as PerSecond, which is unknown until the task
+// completes and is paid from the treasury on release.
+func (rh *ResultHandler) EscrowAssignment(ctx context.Context, taskID, agentID string) error {
+	if rh.escrow == nil {
+		return fmt.Errorf("escrow assignment of task %s to %s: %w", taskID, agentID, ErrNoEscrow)
+	}
+	agentAccountID, ok := rh.agentAccounts[agentID]
 	if !ok {
-		rh.log.Warn("no account mapping for agent, skipping payment", "agent_id", msg.Sender)
+		return fmt.Errorf("escrow assignment of task %s to %s: no account mapping for agent", taskID, agentID)
+	}
+
+	rh.mu.RLock()
+	task := rh.plan.TaskByID(taskID)
+	var expiry time.Duration
+	if task != nil {
+		expiry = rh.plan.TaskTimeout(*task)
+	}
+	rh.mu.RUnlock()
+	amount := rh.pricing.Amount(task, TaskResultPayload{TaskID: taskID}, rh.config.DefaultPaymentAmount)
+
+	if err := rh.escrow.EscrowForTask(ctx, taskID, agentAccountID, amount, expiry); err != nil {
+		return fmt.Errorf("escrow assignment of task %s to %s: %w", taskID, agentID, err)
+	}
+	rh.log.Info("payment escrowed", "task_id", taskID, "agent_id", agentID, "amount", amount)
+	return nil
+}
+
+// payUnpaid pays for a task's accepted result if it has not been paid yet.
+func (rh *ResultHandler) payUnpaid(ctx context.Context, taskID string) {
+	rh.mu.Lock()
+	agentID, ok := rh.unpaid[taskID]
+	delete(rh.unpaid, taskID)
+	result := rh.results[taskID]
+	rh.mu.Unlock()
+	if ok {
+		rh.pay(ctx, agentID, result)
+	}
+}
+
+// pay transfers the task's price to the agent that completed it.
+func (rh *ResultHandler) pay(ctx context.Context, agentID string, result TaskResultPayload) {
+	agentAccountID, ok := rh.agentAccounts[agentID]
+	if !ok {
+		rh.log.Warn("no account mapping for agent, skipping payment", "agent_id", agentID)
 		return
 	}
 
-	amount := rh.config.DefaultPaymentAmount
+	amount := rh.paymentAmount(result)
 	if err := rh.payment.PayForTask(ctx, result.TaskID, agentAccountID, amount); err != nil {
 		rh.log.Error("payment failed",
 			"task_id", result.TaskID,
-			"agent_id", msg.Sender,
+			"agent_id", agentID,
 			"amount", amount,
 			"error", err)
 	} else {
 		rh.log.Info("payment settled",
 			"task_id", result.TaskID,
-			"agent_id", msg.Sender,
+			"agent_id", agentID,
 			"amount", amount)
 	}
 }
 
+// rejectOutput sends a task whose result failed validation back to in
+// progress and tells the agent why, withholding payment.
+func (rh *ResultHandler) rejectOutput(ctx context.Context, agentID string, result TaskResultPayload, reason error) {
+	rh.log.Warn("task output rejected", "task_id", result.TaskID, "agent_id", agentID, "reason", reason)
+
+	if rh.monitor != nil {
+		if err := rh.monitor.reopenTask(result.TaskID); err != nil {
+			rh.log.Warn("failed to reopen rejected task", "task_id", result.TaskID, "error", err)
+		}
+	}
+	if rh.publisher == nil {
+		return
+	}
+
+	rh.mu.RLock()
+	var taskType string
+	if task := rh.plan.TaskByID(result.TaskID); task != nil {
+		taskType = task.TaskType
+	}
+	rh.mu.RUnlock()
+
+	rh.mu.Lock()
+	rh.seqNum++
+	seqNum := rh.seqNum
+	rh.mu.Unlock()
+
+	env, err := outputRejectedEnvelope(OutputRejectedPayload{
+		TaskID:   result.TaskID,
+		AgentID:  agentID,
+		TaskType: taskType,
+		Reason:   reason.Error(),
+	}, seqNum)
+	if err != nil {
+		rh.log.Warn("failed to marshal output rejection", "task_id", result.TaskID, "error", err)
+		return
+	}
+	if err := rh.publisher.Publish(ctx, rh.config.TaskTopicID, env); err != nil {
+		rh.log.Warn("failed to publish output rejection", "task_id", result.TaskID, "error", err)
+	}
+}
+
+// outputRejectedEnvelope builds the output_rejected message sent to the
+// agent whose output was rejected.
+func outputRejectedEnvelope(rejection OutputRejectedPayload, seqNum uint64) (hcs.Envelope, error) {
+	payload, err := json.Marshal(rejection)
+	if err != nil {
+		return hcs.Envelope{}, fmt.Errorf("marshal output rejection for task %s: %w", rejection.TaskID, err)
+	}
+	return hcs.Envelope{
+		Type:        hcs.MessageTypeOutputRejected,
+		Sender:      "coordinator",
+		Recipient:   rejection.AgentID,
+		TaskID:      rejection.TaskID,
+		SequenceNum: seqNum,
+		Timestamp:   time.Now(),
+		Payload:     payload,
+	}, nil
+}
+
+// publishViolation emits an HCS message describing a rejected agent message.
+func (rh *ResultHandler) publishViolation(ctx context.Context, msg hcs.Envelope, taskID, assigned, reason string) {
+	if rh.publisher == nil {
+		return
+	}
+
+	payload, err := json.Marshal(ProtocolViolationPayload{
+		TaskID:        taskID,
+		Sender:        msg.Sender,
+		AssignedAgent: assigned,
+		MessageType:   string(msg.Type),
+		Reason:        reason,
+	})
+	if err != nil {
+		rh.log.Warn("failed to marshal protocol violation", "task_id", taskID, "error", err)
+		return
+	}
+
+	rh.mu.Lock()
+	rh.seqNum++
+	seqNum := rh.seqNum
+	rh.mu.Unlock()
+
+	env := hcs.Envelope{
+		Type:        hcs.MessageTypeProtocolViolation,
+		Sender:      "coordinator",
+		Recipient:   msg.Sender,
+		TaskID:      taskID,
+		SequenceNum: seqNum,
+		Timestamp:   time.Now(),
+		Payload:     payload,
+	}
+	if err := rh.publisher.Publish(ctx, rh.config.TaskTopicID, env); err != nil {
+		rh.log.Warn("failed to publish protocol violation", "task_id", taskID, "error", err)
+	}
+}
+
+// paymentAmount prices a completed task from its plan entry, falling back to
+// the configured default when the task is not in the plan.
+func (rh *ResultHandler) paymentAmount(result TaskResultPayload) int64 {
+	rh.mu.RLock()
+	task := rh.plan.TaskByID(result.TaskID)
+	rh.mu.RUnlock()
+
+	if task == nil {
+		rh.log.Warn("completed task not found in plan, paying default amount", "task_id", result.TaskID)
+	}
+	return rh.pricing.Amount(task, result, rh.config.DefaultPaymentAmount)
+}
+
 // PnLReportPayload is the payload agents send with P&L data.
 type PnLReportPayload struct {
 	AgentID          string  `json:"agent_id"`
@@ -172,3 +586,6 @@ func (rh *ResultHandler) handlePnLReport(msg hcs.Envelope) {
 		"self_sustaining", report.IsSelfSustaining,
 		"strategy", report.ActiveStrategy)
 }
+
+// Compile-time interface compliance check.
+var _ AssignmentEscrow = (*ResultHandler)(nil)
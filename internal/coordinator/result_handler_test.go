@@ -0,0 +1,183 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+)
+
+// recordingPayment records PayForTask calls.
+type recordingPayment struct {
+	calls []paymentCall
+}
+
+type paymentCall struct {
+	taskID  string
+	account string
+	amount  int64
+}
+
+func (p *recordingPayment) PayForTask(_ context.Context, taskID string, agentID string, amount int64) error {
+	p.calls = append(p.calls, paymentCall{taskID: taskID, account: agentID, amount: amount})
+	return nil
+}
+
+func (p *recordingPayment) PaymentStatus(string) (PaymentState, error) {
+	return PaymentPending, nil
+}
+
+// staticAssignments is a fixed AssignmentLookup.
+type staticAssignments map[string]string
+
+func (s staticAssignments) Assignment(taskID string) string { return s[taskID] }
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func taskResultEnvelope(t *testing.T, sender string, result TaskResultPayload) hcs.Envelope {
+	t.Helper()
+	raw, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("marshal result: %v", err)
+	}
+	return hcs.Envelope{Type: hcs.MessageTypeTaskResult, Sender: sender, TaskID: result.TaskID, Payload: raw}
+}
+
+func TestResultHandler_PaysPlanAmountToAssignedAgent(t *testing.T) {
+	payment := &recordingPayment{}
+	rh := NewResultHandler(ResultHandlerConfig{
+		Payment:       payment,
+		Config:        DefaultConfig(),
+		Log:           discardLogger(),
+		AgentAccounts: map[string]string{"inference-001": "0.0.201", "defi-001": "0.0.202"},
+		Assignments:   staticAssignments{"task-inference-01": "inference-001", "task-defi-01": "defi-001"},
+		Pricing: PaymentPricing{
+			"execute_trade": {Base: 40, PerSecond: 5, Max: 60},
+		},
+	})
+	plan := IntegrationCyclePlan()
+	plan.Sequences[0].Tasks[0].PaymentAmount = 250
+	rh.SetPlan(plan)
+
+	rh.processMessage(context.Background(), taskResultEnvelope(t, "inference-001",
+		TaskResultPayload{TaskID: "task-inference-01", Status: "completed"}))
+	rh.processMessage(context.Background(), taskResultEnvelope(t, "defi-001",
+		TaskResultPayload{TaskID: "task-defi-01", Status: "completed", DurationMs: 9500}))
+
+	want := []paymentCall{
+		{taskID: "task-inference-01", account: "0.0.201", amount: 250},
+		{taskID: "task-defi-01", account: "0.0.202", amount: 60},
+	}
+	if len(payment.calls) != len(want) {
+		t.Fatalf("payments = %+v, want %+v", payment.calls, want)
+	}
+	for i := range want {
+		if payment.calls[i] != want[i] {
+			t.Errorf("payment[%d] = %+v, want %+v", i, payment.calls[i], want[i])
+		}
+	}
+}
+
+func TestResultHandler_UnknownTaskPaysDefault(t *testing.T) {
+	payment := &recordingPayment{}
+	rh := NewResultHandler(ResultHandlerConfig{
+		Payment:       payment,
+		Config:        DefaultConfig(),
+		Log:           discardLogger(),
+		AgentAccounts: map[string]string{"inference-001": "0.0.201"},
+		Assignments:   staticAssignments{"adhoc": "inference-001"},
+	})
+
+	rh.processMessage(context.Background(), taskResultEnvelope(t, "inference-001",
+		TaskResultPayload{TaskID: "adhoc", Status: "completed"}))
+
+	if len(payment.calls) != 1 || payment.calls[0].amount != DefaultConfig().DefaultPaymentAmount {
+		t.Fatalf("payments = %+v, want one default payment", payment.calls)
+	}
+}
+
+func TestPaymentPricing_Amount(t *testing.T) {
+	pricing := PaymentPricing{
+		"inference_job": {PerThousandTokens: 20},
+		"execute_trade": {Base: 10, PerSecond: 3, Max: 25},
+	}
+
+	tests := []struct {
+		name   string
+		task   *PlanTask
+		result TaskResultPayload
+		want   int64
+	}{
+		{"no plan task uses fallback", nil, TaskResultPayload{}, 100},
+		{"no rule uses plan amount", &PlanTask{TaskType: "other", PaymentAmount: 70}, TaskResultPayload{}, 70},
+		{"zero plan amount uses fallback", &PlanTask{TaskType: "other"}, TaskResultPayload{}, 100},
+		{"token bonus on plan amount", &PlanTask{TaskType: "inference_job", PaymentAmount: 50, MaxTokens: 2048}, TaskResultPayload{}, 90},
+		{"duration bonus on rule base", &PlanTask{TaskType: "execute_trade", PaymentAmount: 500}, TaskResultPayload{DurationMs: 4999}, 22},
+		{"capped at max", &PlanTask{TaskType: "execute_trade"}, TaskResultPayload{DurationMs: 60000}, 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pricing.Amount(tt.task, tt.result, 100); got != tt.want {
+				t.Errorf("Amount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResultHandler_RejectsUnverifiedSender(t *testing.T) {
+	tests := []struct {
+		name       string
+		sender     string
+		taskID     string
+		wantReason string
+		wantAgent  string
+	}{
+		{"task never assigned", "inference-001", "task-unknown", ViolationTaskNotAssigned, ""},
+		{"task assigned to another agent", "defi-001", "task-inference-01", ViolationWrongAgent, "inference-001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment := &recordingPayment{}
+			pub := &mockPublisher{}
+			rh := NewResultHandler(ResultHandlerConfig{
+				Publisher:     pub,
+				Payment:       payment,
+				Config:        DefaultConfig(),
+				Log:           discardLogger(),
+				AgentAccounts: map[string]string{"inference-001": "0.0.201", "defi-001": "0.0.202"},
+				Assignments:   staticAssignments{"task-inference-01": "inference-001"},
+			})
+
+			rh.processMessage(context.Background(), taskResultEnvelope(t, tt.sender,
+				TaskResultPayload{TaskID: tt.taskID, Status: "completed"}))
+
+			if len(payment.calls) != 0 {
+				t.Fatalf("unverified result was paid: %+v", payment.calls)
+			}
+			if _, ok := rh.Result(tt.taskID); ok {
+				t.Error("unverified result should not be stored")
+			}
+			if len(pub.calls) != 1 || pub.calls[0].Type != hcs.MessageTypeProtocolViolation {
+				t.Fatalf("published = %+v, want one protocol_violation", pub.calls)
+			}
+			if pub.calls[0].Recipient != tt.sender {
+				t.Errorf("violation recipient = %q, want %q", pub.calls[0].Recipient, tt.sender)
+			}
+
+			var payload ProtocolViolationPayload
+			if err := json.Unmarshal(pub.calls[0].Payload, &payload); err != nil {
+				t.Fatalf("unmarshal violation: %v", err)
+			}
+			if payload.Reason != tt.wantReason || payload.AssignedAgent != tt.wantAgent || payload.Sender != tt.sender {
+				t.Errorf("violation = %+v, want reason %s assigned %q", payload, tt.wantReason, tt.wantAgent)
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Error classes agents report at the start of TaskResultPayload.Error, as in
+// "timeout: model did not respond". Errors without a recognisable class
+// prefix are ErrorClassUnknown.
+const (
+	ErrorClassTransient    = "transient"
+	ErrorClassTimeout      = "timeout"
+	ErrorClassRateLimited  = "rate_limited"
+	ErrorClassInvalidInput = "invalid_input"
+	ErrorClassUnknown      = "unknown"
+)
+
+// ErrorClass extracts the error class from a task result error: the text
+// before the first colon, if it is a single lower-case word.
+func ErrorClass(msg string) string {
+	class, _, ok := strings.Cut(msg, ":")
+	if !ok {
+		return ErrorClassUnknown
+	}
+	class = strings.TrimSpace(class)
+	if class == "" {
+		return ErrorClassUnknown
+	}
+	for _, r := range class {
+		if (r < 'a' || r > 'z') && r != '_' {
+			return ErrorClassUnknown
+		}
+	}
+	return class
+}
+
+// RetryPolicy decides whether and when a failed task is assigned again.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values of one or less disable retries.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry. It doubles for each
+	// further retry, up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// RetryOn lists the error classes that are retried. Empty retries
+	// every class.
+	RetryOn []string
+
+	// SwitchAgent prefers an agent that has not yet attempted the task.
+	// The previous agents are used only when no other can run it.
+	SwitchAgent bool
+}
+
+// DefaultRetryPolicy retries transient failures twice, on a different agent
+// where one is available.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     5 * time.Second,
+		MaxBackoff:  time.Minute,
+		RetryOn:     []string{ErrorClassTransient, ErrorClassTimeout, ErrorClassRateLimited, ErrorClassUnknown},
+		SwitchAgent: true,
+	}
+}
+
+// Validate checks the policy for negative limits.
+func (p RetryPolicy) Validate() error {
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("retry policy: max attempts must not be negative")
+	}
+	if p.Backoff < 0 || p.MaxBackoff < 0 {
+		return fmt.Errorf("retry policy: backoff must not be negative")
+	}
+	return nil
+}
+
+// shouldRetry reports whether a task that has failed attempts times with an
+// error of the given class gets another attempt.
+func (p RetryPolicy) shouldRetry(attempts int, class string) bool {
+	if attempts >= p.MaxAttempts {
+		return false
+	}
+	return len(p.RetryOn) == 0 || containsString(p.RetryOn, class)
+}
+
+// backoff returns the delay before the retry that follows the given number
+// of failed attempts.
+func (p RetryPolicy) backoff(attempts int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return delay
+}
+
+// TaskAttempt records the outcome of one agent's attempt at a task.
+type TaskAttempt struct {
+	TaskID     string    `json:"task_id"`
+	Attempt    int       `json:"attempt"`
+	AgentID    string    `json:"agent_id"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	ErrorClass string    `json:"error_class,omitempty"`
+	Retried    bool      `json:"retried,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+// Retrier records every task result as an attempt and re-queues failed tasks
+// through the assigner according to a RetryPolicy. A failed task moves
+// failed -> pending straight away so dependents keep waiting on it, and is
+// assigned again once the backoff has passed.
+type Retrier struct {
+	assigner *Assigner
+	monitor  *Monitor
+	policy   RetryPolicy
+	store    StateStore
+	logger   *slog.Logger
+	now      func() time.Time
+
+	mu        sync.RWMutex
+	plan      Plan
+	history   map[string][]TaskAttempt
+	abandoned map[string]bool // retries that could not be assigned
+}
+
+// NewRetrier creates a retrier that re-queues failed tasks through assigner.
+func NewRetrier(assigner *Assigner, monitor *Monitor, policy RetryPolicy) *Retrier {
+	return &Retrier{
+		assigner:  assigner,
+		monitor:   monitor,
+		policy:    policy,
+		logger:    slog.Default(),
+		now:       time.Now,
+		history:   make(map[string][]TaskAttempt),
+		abandoned: make(map[string]bool),
+	}
+}
+
+// SetPlan sets the plan that retried tasks are read from.
+func (r *Retrier) SetPlan(plan Plan) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.plan = plan
+}
+
+// SetStore configures a state store that every attempt is written to.
+func (r *Retrier) SetStore(store StateStore) {
+	r.store = store
+}
+
+// History returns every recorded attempt at a task, oldest first.
+func (r *Retrier) History(taskID string) []TaskAttempt {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]TaskAttempt(nil), r.history[taskID]...)
+}
+
+// Exhausted reports whether a task will not be attempted again: its latest
+// attempt failed and was not retried, or its retry could not be assigned. A
+// task whose failure the retrier has not seen a result for yet is not
+// exhausted.
+func (r *Retrier) Exhausted(taskID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.abandoned[taskID] {
+		return true
+	}
+	history := r.history[taskID]
+	if len(history) == 0 {
+		return false
+	}
+	last := history[len(history)-1]
+	return last.Status != "completed" && !last.Retried
+}
+
+// HandleResult records an accepted task result from agentID. Failed results
+// are retried when the policy allows; otherwise the task is left failed.
+func (r *Retrier) HandleResult(ctx context.Context, agentID string, result TaskResultPayload) {
+	attempt := TaskAttempt{
+		TaskID:  result.TaskID,
+		AgentID: agentID,
+		Status:  result.Status,
+		Error:   result.Error,
+		Time:    r.now(),
+	}
+	failed := result.Status != "completed"
+	if failed {
+		attempt.ErrorClass = ErrorClass(result.Error)
+	}
+
+	r.mu.Lock()
+	attempt.Attempt = len(r.history[result.TaskID]) + 1
+	task := r.plan.TaskByID(result.TaskID)
+	retry := failed && task != nil && r.policy.shouldRetry(attempt.Attempt, attempt.ErrorClass)
+	attempt.Retried = retry
+	r.history[result.TaskID] = append(r.history[result.TaskID], attempt)
+	delete(r.abandoned, result.TaskID)
+	var tried []string
+	for _, prev := range r.history[result.TaskID] {
+		tried = append(tried, prev.AgentID)
+	}
+	var timeout time.Duration
+	if task != nil {
+		timeout = r.plan.TaskTimeout(*task)
+	}
+	r.mu.Unlock()
+
+	if r.store != nil {
+		if err := r.store.SaveAttempt(attempt); err != nil {
+			r.logger.Warn("failed to persist task attempt", "task_id", result.TaskID, "error", err)
+		}
+	}
+	if !failed {
+		return
+	}
+
+	if w := r.assigner.watchdog; w != nil {
+		w.Release(result.TaskID)
+	}
+	if err := r.monitor.failTask(result.TaskID, retry); err != nil {
+		r.logger.Warn("failed to mark task failed", "task_id", result.TaskID, "error", err)
+		return
+	}
+	if !retry {
+		r.logger.Warn("task failed, not retrying",
+			"task_id", result.TaskID,
+			"agent_id", agentID,
+			"attempt", attempt.Attempt,
+			"error_class", attempt.ErrorClass,
+			"in_plan", task != nil)
+		return
+	}
+
+	delay := r.policy.backoff(attempt.Attempt)
+	r.logger.Info("task failed, retrying",
+		"task_id", result.TaskID,
+		"agent_id", agentID,
+		"attempt", attempt.Attempt,
+		"error_class", attempt.ErrorClass,
+		"backoff", delay)
+
+	retryTask := *task
+	if delay <= 0 {
+		r.retry(ctx, retryTask, tried, timeout)
+		return
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+			r.retry(ctx, retryTask, tried, timeout)
+		}
+	}()
+}
+
+// retry assigns a re-queued task, preferring agents not in tried when the
+// policy asks to switch agents.
+func (r *Retrier) retry(ctx context.Context, task PlanTask, tried []string, timeout time.Duration) {
+	var agentID string
+	var err error
+	if r.policy.SwitchAgent {
+		agentID, err = r.assigner.selectAgent(task, tried...)
+	}
+	if !r.policy.SwitchAgent || err != nil {
+		agentID, err = r.assigner.selectAgent(task)
+	}
+	if err != nil {
+		r.logger.Warn("no agent can retry task", "task_id", task.ID, "error", err)
+		r.abandon(task.ID)
+		return
+	}
+
+	assigned, err := r.assigner.assignPlanTask(ctx, task, agentID)
+	if err != nil || !assigned {
+		r.logger.Warn("failed to reassign task for retry", "task_id", task.ID, "agent_id", agentID, "error", err)
+		r.abandon(task.ID)
+		return
+	}
+	if w := r.assigner.watchdog; w != nil {
+		w.Track(task, agentID, timeout)
+	}
+	r.logger.Info("task reassigned for retry", "task_id", task.ID, "agent_id", agentID)
+}
+
+// abandon leaves a re-queued task failed when it cannot be assigned again.
+func (r *Retrier) abandon(taskID string) {
+	r.mu.Lock()
+	r.abandoned[taskID] = true
+	r.mu.Unlock()
+	if err := r.monitor.failTask(taskID, false); err != nil {
+		r.logger.Warn("failed to mark task failed", "task_id", taskID, "error", err)
+	}
+}
+
+// restore replaces recorded attempts with those recovered from a store.
+func (r *Retrier) restore(attempts map[string][]TaskAttempt) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for taskID, history := range attempts {
+		r.history[taskID] = append([]TaskAttempt(nil), history...)
+	}
+}
@@ -0,0 +1,173 @@
+package coordinator
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{"timeout: model did not respond", ErrorClassTimeout},
+		{"rate_limited: 429 from provider", ErrorClassRateLimited},
+		{"invalid_input:empty prompt", ErrorClassInvalidInput},
+		{"", ErrorClassUnknown},
+		{"something broke", ErrorClassUnknown},
+		{"Provider Error: 500", ErrorClassUnknown},
+		{": no class", ErrorClassUnknown},
+	}
+	for _, tt := range tests {
+		if got := ErrorClass(tt.msg); got != tt.want {
+			t.Errorf("ErrorClass(%q) = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := p.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, RetryOn: []string{ErrorClassTransient}}
+	if !p.shouldRetry(1, ErrorClassTransient) {
+		t.Error("first transient failure should be retried")
+	}
+	if p.shouldRetry(3, ErrorClassTransient) {
+		t.Error("failure on the last attempt should not be retried")
+	}
+	if p.shouldRetry(1, ErrorClassInvalidInput) {
+		t.Error("class not in RetryOn should not be retried")
+	}
+	if !(RetryPolicy{MaxAttempts: 2}).shouldRetry(1, ErrorClassInvalidInput) {
+		t.Error("empty RetryOn should retry every class")
+	}
+}
+
+// retryFixture wires an assigner, monitor and retrier over two
+// interchangeable inference agents with task-1 already assigned.
+type retryFixture struct {
+	*coordinatorFixture
+	retrier *Retrier
+}
+
+func newRetryFixture(t *testing.T, policy RetryPolicy) *retryFixture {
+	t.Helper()
+	plan := singleTaskPlan("fest-retry")
+
+	f := &retryFixture{coordinatorFixture: newCoordinatorFixture(t)}
+	f.retrier = NewRetrier(f.assigner, f.monitor, policy)
+	f.retrier.SetPlan(plan)
+
+	if _, err := f.assigner.AssignTasks(context.Background(), plan); err != nil {
+		t.Fatalf("AssignTasks: %v", err)
+	}
+	return f
+}
+
+func (f *retryFixture) fail(agentID, errMsg string) {
+	f.retrier.HandleResult(context.Background(), agentID, TaskResultPayload{
+		TaskID: "task-1", Status: "failed", Error: errMsg,
+	})
+}
+
+func TestRetrier_RetriesOnAnotherAgent(t *testing.T) {
+	f := newRetryFixture(t, RetryPolicy{MaxAttempts: 3, SwitchAgent: true})
+	first := f.assigner.Assignment("task-1")
+
+	f.fail(first, "transient: provider unavailable")
+
+	second := f.assigner.Assignment("task-1")
+	if second == first {
+		t.Fatalf("retry assigned to %q again, want a different agent", second)
+	}
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusAssigned {
+		t.Errorf("status = %s, want assigned", status)
+	}
+
+	history := f.retrier.History("task-1")
+	if len(history) != 1 {
+		t.Fatalf("history = %+v, want 1 attempt", history)
+	}
+	if h := history[0]; h.Attempt != 1 || h.AgentID != first || h.ErrorClass != ErrorClassTransient || !h.Retried {
+		t.Errorf("attempt = %+v", h)
+	}
+
+	// The second agent fails too; with both agents tried, the retry falls
+	// back to one that has already attempted the task.
+	f.fail(second, "transient: provider unavailable")
+	if got := f.assigner.Assignment("task-1"); got == "" {
+		t.Fatal("third attempt not assigned")
+	}
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusAssigned {
+		t.Errorf("status = %s, want assigned", status)
+	}
+
+	// The third attempt is the last one.
+	f.fail(f.assigner.Assignment("task-1"), "transient: provider unavailable")
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusFailed {
+		t.Errorf("status after last attempt = %s, want failed", status)
+	}
+	if history := f.retrier.History("task-1"); len(history) != 3 || history[2].Retried {
+		t.Errorf("history = %+v, want 3 attempts with the last not retried", history)
+	}
+}
+
+func TestRetrier_SkipsNonRetryableClass(t *testing.T) {
+	f := newRetryFixture(t, DefaultRetryPolicy())
+	first := f.assigner.Assignment("task-1")
+
+	f.fail(first, "invalid_input: prompt is empty")
+
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusFailed {
+		t.Errorf("status = %s, want failed", status)
+	}
+	if got := f.assigner.Assignment("task-1"); got != first {
+		t.Errorf("assignment = %q, want unchanged %q", got, first)
+	}
+	if history := f.retrier.History("task-1"); len(history) != 1 || history[0].Retried {
+		t.Errorf("history = %+v, want one attempt not retried", history)
+	}
+}
+
+func TestRetrier_RecordsCompletedAttempts(t *testing.T) {
+	f := newRetryFixture(t, DefaultRetryPolicy())
+	agentID := f.assigner.Assignment("task-1")
+
+	f.retrier.HandleResult(context.Background(), agentID, TaskResultPayload{TaskID: "task-1", Status: "completed"})
+
+	history := f.retrier.History("task-1")
+	if len(history) != 1 || history[0].Status != "completed" || history[0].ErrorClass != "" {
+		t.Errorf("history = %+v, want one completed attempt", history)
+	}
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusAssigned {
+		t.Errorf("status = %s, want unchanged assigned", status)
+	}
+}
+
+func TestRetrier_HistorySurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coordinator.jsonl")
+	store := openTestStore(t, path)
+
+	f := newRetryFixture(t, RetryPolicy{MaxAttempts: 1})
+	f.retrier.SetStore(store)
+	f.fail(f.assigner.Assignment("task-1"), "transient: flake")
+
+	r2 := NewRetrier(nil, nil, DefaultRetryPolicy())
+	if _, err := Recover(openTestStore(t, path), nil, nil, nil, nil, r2); err != nil {
+		t.Fatalf("Recover() error = %v", err)
+	}
+	history := r2.History("task-1")
+	if len(history) != 1 || history[0].ErrorClass != ErrorClassTransient {
+		t.Errorf("recovered history = %+v, want one transient attempt", history)
+	}
+}
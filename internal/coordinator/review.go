@@ -0,0 +1,345 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+)
+
+// ReviewVerdict is a reviewer's decision on another agent's output. Reviewers
+// return it as the JSON output of the verification task; a plain-text output
+// starting with APPROVE or REJECT is also accepted, with the rest as reason.
+type ReviewVerdict struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// parseVerdict reads a verdict from a verification task's output.
+func parseVerdict(output string) (ReviewVerdict, error) {
+	var raw struct {
+		Approved *bool  `json:"approved"`
+		Reason   string `json:"reason"`
+	}
+	if err := json.Unmarshal([]byte(output), &raw); err == nil && raw.Approved != nil {
+		return ReviewVerdict{Approved: *raw.Approved, Reason: raw.Reason}, nil
+	}
+
+	word, rest, _ := strings.Cut(strings.TrimSpace(output), " ")
+	reason := strings.TrimSpace(strings.TrimLeft(rest, ":-"))
+	switch strings.ToUpper(strings.TrimRight(word, ":.,")) {
+	case "APPROVE", "APPROVED":
+		return ReviewVerdict{Approved: true, Reason: reason}, nil
+	case "REJECT", "REJECTED":
+		return ReviewVerdict{Approved: false, Reason: reason}, nil
+	}
+	return ReviewVerdict{}, fmt.Errorf("parse verdict: output is neither a JSON verdict nor APPROVE/REJECT")
+}
+
+// peerReview tracks one round of review of a task's output.
+type peerReview struct {
+	taskID       string
+	author       string
+	round        int
+	reviewTaskID string
+	reviewer     string
+	result       TaskResultPayload
+
+	// reviewers lists every agent asked to review this round.
+	reviewers []string
+
+	decided bool
+	verdict ReviewVerdict
+
+	// reopened is set once a rejection has sent the task back to its
+	// author. The task's next review waits for a new round.
+	reopened bool
+}
+
+// PeerReviewGate is a QualityGateEnforcer that has a second agent verify a
+// task's output. When the author's completed result arrives, the gate assigns
+// a verification task carrying the original input and output to a different
+// capable agent. The author's task is held in StatusReview until the
+// reviewer's verdict arrives as the verification task's result, and is then
+// moved to StatusComplete or back to StatusInProgress. Only tasks whose
+// TaskType is listed are reviewed; all others pass.
+type PeerReviewGate struct {
+	assigner  *Assigner
+	monitor   *Monitor
+	publisher hcs.MessagePublisher
+	topicID   hiero.TopicID
+	taskTypes []string
+	logger    *slog.Logger
+
+	mu       sync.Mutex
+	plan     Plan
+	reviews  map[string]*peerReview // original task ID -> latest round
+	reviewOf map[string]string      // verification task ID -> original task ID
+	seqNum   uint64
+}
+
+// NewPeerReviewGate creates a gate that reviews tasks of the given types,
+// assigning verification tasks through assigner and notifying authors of
+// rejections on topicID.
+func NewPeerReviewGate(assigner *Assigner, monitor *Monitor, publisher hcs.MessagePublisher, topicID hiero.TopicID, taskTypes []string) *PeerReviewGate {
+	return &PeerReviewGate{
+		assigner:  assigner,
+		monitor:   monitor,
+		publisher: publisher,
+		topicID:   topicID,
+		taskTypes: taskTypes,
+		logger:    slog.Default(),
+		reviews:   make(map[string]*peerReview),
+		reviewOf:  make(map[string]string),
+	}
+}
+
+// SetPlan sets the plan whose task types select the tasks to review.
+func (g *PeerReviewGate) SetPlan(plan Plan) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.plan = plan
+}
+
+// Evaluate implements QualityGateEnforcer. It returns ErrGatePending while a
+// review is outstanding, and the reviewer's verdict once it has arrived. A
+// rejection is returned once; a task resubmitted after it stays pending
+// until the next round is decided.
+func (g *PeerReviewGate) Evaluate(ctx context.Context, taskID string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, fmt.Errorf("evaluate peer review for task %s: %w", taskID, err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.reviewableLocked(taskID) {
+		return true, nil
+	}
+	review, ok := g.reviews[taskID]
+	if !ok || !review.decided || review.reopened {
+		return false, fmt.Errorf("evaluate peer review for task %s: %w", taskID, ErrGatePending)
+	}
+	if !review.verdict.Approved {
+		review.reopened = true
+	}
+	return review.verdict.Approved, nil
+}
+
+// Start listens for task results on the status topic until the context is
+// cancelled, starting reviews for completed results and recording verdicts.
+func (g *PeerReviewGate) Start(ctx context.Context, subscriber hcs.MessageSubscriber, topicID hiero.TopicID) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("peer review gate start: %w", err)
+	}
+
+	msgCh, errCh := subscriber.Subscribe(ctx, topicID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-msgCh:
+			if !ok {
+				return nil
+			}
+			if msg.Type == hcs.MessageTypeTaskResult {
+				g.handleResult(ctx, msg)
+			}
+		case _, ok := <-errCh:
+			if !ok {
+				errCh = nil // prevent spin on closed channel
+				continue
+			}
+		}
+	}
+}
+
+// handleResult routes a task result from its assigned agent to the review
+// it starts or decides.
+func (g *PeerReviewGate) handleResult(ctx context.Context, msg hcs.Envelope) {
+	var result TaskResultPayload
+	if err := json.Unmarshal(msg.Payload, &result); err != nil {
+		return
+	}
+	if assigned := g.assigner.Assignment(result.TaskID); assigned == "" || assigned != msg.Sender {
+		return
+	}
+
+	g.mu.Lock()
+	originalID, isReview := g.reviewOf[result.TaskID]
+	g.mu.Unlock()
+
+	if isReview {
+		g.handleVerdict(ctx, originalID, msg.Sender, result)
+		return
+	}
+	if result.Status == "completed" {
+		g.startReview(ctx, msg.Sender, result)
+	}
+}
+
+// startReview opens a new review round for a completed result, unless one is
+// already outstanding or this output was already approved.
+func (g *PeerReviewGate) startReview(ctx context.Context, author string, result TaskResultPayload) {
+	g.mu.Lock()
+	if !g.reviewableLocked(result.TaskID) {
+		g.mu.Unlock()
+		return
+	}
+	round := 1
+	if prev, ok := g.reviews[result.TaskID]; ok {
+		if !prev.decided || (prev.verdict.Approved && prev.result.Output == result.Output) {
+			g.mu.Unlock()
+			return
+		}
+		round = prev.round + 1
+	}
+	review := &peerReview{taskID: result.TaskID, author: author, round: round, result: result}
+	g.reviews[result.TaskID] = review
+	g.mu.Unlock()
+
+	g.assignReviewer(ctx, review)
+}
+
+// assignReviewer hands the review to an agent that is neither the author nor
+// a previous reviewer of this round. If none is available the review is
+// rejected, since the output cannot be verified.
+func (g *PeerReviewGate) assignReviewer(ctx context.Context, review *peerReview) {
+	g.mu.Lock()
+	original := g.plan.TaskByID(review.taskID)
+	task := PlanTask{
+		ID:        fmt.Sprintf("%s/review-%d-%d", review.taskID, review.round, len(review.reviewers)+1),
+		Name:      "review " + review.taskID,
+		TaskType:  TaskTypeInference, // reviewers run verification as an inference job
+		Priority:  original.Priority,
+		MaxTokens: original.MaxTokens,
+		ModelID:   original.ModelID,
+		Input:     reviewPrompt(*original, review.result),
+	}
+	exclude := append([]string{review.author}, review.reviewers...)
+	g.mu.Unlock()
+
+	reviewer, err := g.assigner.selectAgent(task, exclude...)
+	if err == nil && reviewer == "" {
+		err = ErrNoCapableAgent
+	}
+	if err != nil {
+		g.logger.Warn("no reviewer available for task", "task_id", review.taskID, "error", err)
+		g.decide(ctx, review, ReviewVerdict{Reason: "no second agent available to review the output"})
+		return
+	}
+
+	g.mu.Lock()
+	review.reviewTaskID = task.ID
+	review.reviewer = reviewer
+	review.reviewers = append(review.reviewers, reviewer)
+	g.reviewOf[task.ID] = review.taskID
+	g.mu.Unlock()
+
+	assigned, err := g.assigner.assignPlanTask(ctx, task, reviewer)
+	if err != nil || !assigned {
+		g.logger.Warn("failed to assign review task",
+			"task_id", review.taskID, "review_task_id", task.ID, "reviewer", reviewer, "error", err)
+		g.decide(ctx, review, ReviewVerdict{Reason: "review task could not be assigned"})
+		return
+	}
+	g.logger.Info("peer review assigned",
+		"task_id", review.taskID, "author", review.author, "review_task_id", task.ID, "reviewer", reviewer)
+}
+
+// handleVerdict records the reviewer's verdict. A failed or unreadable
+// review is handed to another reviewer.
+func (g *PeerReviewGate) handleVerdict(ctx context.Context, taskID, reviewer string, result TaskResultPayload) {
+	g.mu.Lock()
+	review, ok := g.reviews[taskID]
+	current := ok && !review.decided && review.reviewTaskID == result.TaskID
+	g.mu.Unlock()
+	if !current {
+		return
+	}
+
+	verdict, err := parseVerdict(result.Output)
+	if result.Status != "completed" || err != nil {
+		g.logger.Warn("peer review produced no verdict, reassigning",
+			"task_id", taskID, "reviewer", reviewer, "status", result.Status, "error", err)
+		g.assignReviewer(ctx, review)
+		return
+	}
+	g.decide(ctx, review, verdict)
+}
+
+// decide records a verdict and, if the author's task is waiting in review,
+// moves it on. Rejections are reported to the author.
+func (g *PeerReviewGate) decide(ctx context.Context, review *peerReview, verdict ReviewVerdict) {
+	g.mu.Lock()
+	review.decided = true
+	review.verdict = verdict
+	g.mu.Unlock()
+
+	g.logger.Info("peer review decided",
+		"task_id", review.taskID, "reviewer", review.reviewer, "approved", verdict.Approved, "reason", verdict.Reason)
+
+	applied, err := g.monitor.resolveReview(review.taskID, verdict.Approved, "peer review rejected: "+verdict.Reason)
+	if err != nil {
+		g.logger.Warn("failed to apply peer review verdict", "task_id", review.taskID, "error", err)
+	}
+	if verdict.Approved {
+		return
+	}
+
+	g.mu.Lock()
+	if applied {
+		review.reopened = true
+	}
+	g.seqNum++
+	seqNum := g.seqNum
+	taskType := ""
+	if task := g.plan.TaskByID(review.taskID); task != nil {
+		taskType = task.TaskType
+	}
+	g.mu.Unlock()
+
+	env, err := outputRejectedEnvelope(OutputRejectedPayload{
+		TaskID:   review.taskID,
+		AgentID:  review.author,
+		TaskType: taskType,
+		Reason:   "peer review rejected: " + verdict.Reason,
+	}, seqNum)
+	if err != nil {
+		g.logger.Warn("failed to marshal output rejection", "task_id", review.taskID, "error", err)
+		return
+	}
+	if err := g.publisher.Publish(ctx, g.topicID, env); err != nil {
+		g.logger.Warn("failed to publish output rejection", "task_id", review.taskID, "error", err)
+	}
+}
+
+// reviewableLocked reports whether a plan task's type is reviewed. g.mu must
+// be held.
+func (g *PeerReviewGate) reviewableLocked(taskID string) bool {
+	task := g.plan.TaskByID(taskID)
+	return task != nil && containsString(g.taskTypes, task.TaskType)
+}
+
+// reviewPrompt builds the verification task input from the original task and
+// its output.
+func reviewPrompt(task PlanTask, result TaskResultPayload) string {
+	return fmt.Sprintf(`Review another agent's output for the task below.
+Reply with JSON {"approved": true|false, "reason": "..."}.
+
+Task: %s
+Input:
+%s
+
+Output:
+%s`, task.Name, task.Input, result.Output)
+}
+
+// Compile-time interface compliance check.
+var _ QualityGateEnforcer = (*PeerReviewGate)(nil)
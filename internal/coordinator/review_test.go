@@ -0,0 +1,197 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+)
+
+func TestParseVerdict(t *testing.T) {
+	tests := []struct {
+		output   string
+		approved bool
+		reason   string
+		wantErr  bool
+	}{
+		{`{"approved": true}`, true, "", false},
+		{`{"approved": false, "reason": "summary contradicts input"}`, false, "summary contradicts input", false},
+		{"APPROVE", true, "", false},
+		{"Rejected: numbers are made up", false, "numbers are made up", false},
+		{`{"reason": "no decision"}`, false, "", true},
+		{"looks fine to me", false, "", true},
+	}
+	for _, tt := range tests {
+		got, err := parseVerdict(tt.output)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseVerdict(%q) error = %v, wantErr %v", tt.output, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (got.Approved != tt.approved || got.Reason != tt.reason) {
+			t.Errorf("parseVerdict(%q) = %+v, want approved=%v reason=%q", tt.output, got, tt.approved, tt.reason)
+		}
+	}
+}
+
+// reviewFixture wires a peer review gate over the given inference agents with
+// task-1 assigned to the first of them.
+type reviewFixture struct {
+	*coordinatorFixture
+	gate    *PeerReviewGate
+	gatePub *capturePublisher
+	author  string
+}
+
+func newReviewFixture(t *testing.T, agentIDs ...string) *reviewFixture {
+	t.Helper()
+	plan := singleTaskPlan("fest-review")
+	plan.Sequences[0].Tasks[0].Name = "summarize"
+	plan.Sequences[0].Tasks[0].Input = "summarize the market"
+
+	f := &reviewFixture{coordinatorFixture: newCoordinatorFixture(t, agentIDs...), gatePub: &capturePublisher{}}
+	f.gate = NewPeerReviewGate(f.assigner, f.monitor, f.gatePub, hiero.TopicID{Topic: 1}, []string{TaskTypeInference})
+	f.gate.SetPlan(plan)
+	f.monitor.SetGate(f.gate)
+
+	if _, err := f.assigner.AssignTasks(context.Background(), plan); err != nil {
+		t.Fatalf("AssignTasks: %v", err)
+	}
+	f.author = f.assigner.Assignment("task-1")
+	return f
+}
+
+func (f *reviewFixture) result(t *testing.T, sender, taskID, output string) {
+	t.Helper()
+	f.gate.handleResult(context.Background(), taskResultEnvelope(t, sender,
+		TaskResultPayload{TaskID: taskID, Status: "completed", Output: output}))
+}
+
+// reviewAssignment returns the latest verification task assignment.
+func (f *reviewFixture) reviewAssignment(t *testing.T) (hcs.Envelope, TaskAssignmentPayload) {
+	t.Helper()
+	for i := len(f.pub.calls) - 1; i >= 0; i-- {
+		env := f.pub.calls[i]
+		if env.Type != hcs.MessageTypeTaskAssignment || !strings.Contains(env.TaskID, "/review-") {
+			continue
+		}
+		var payload TaskAssignmentPayload
+		if err := json.Unmarshal(env.Payload, &payload); err != nil {
+			t.Fatalf("unmarshal assignment: %v", err)
+		}
+		return env, payload
+	}
+	t.Fatal("no review task assigned")
+	return hcs.Envelope{}, TaskAssignmentPayload{}
+}
+
+func TestPeerReviewGate_ApprovedReviewCompletesTask(t *testing.T) {
+	f := newReviewFixture(t, "inference-001", "inference-002")
+
+	advanceTask(t, f.monitor, "task-1", StatusInProgress, StatusReview, StatusComplete)
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusReview {
+		t.Fatalf("status before verdict = %s, want review", status)
+	}
+
+	f.result(t, f.author, "task-1", "markets rallied on rate cut hopes")
+	env, review := f.reviewAssignment(t)
+	if env.Recipient == f.author {
+		t.Fatalf("review assigned to the author %q", f.author)
+	}
+	if !strings.Contains(review.Input, "markets rallied on rate cut hopes") || !strings.Contains(review.Input, "summarize the market") {
+		t.Errorf("review input = %q, want original input and output", review.Input)
+	}
+
+	f.result(t, env.Recipient, env.TaskID, `{"approved": true, "reason": "accurate"}`)
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusComplete {
+		t.Errorf("status after approval = %s, want complete", status)
+	}
+	if len(f.gatePub.messages) != 0 {
+		t.Errorf("published %+v, want nothing on approval", f.gatePub.messages)
+	}
+}
+
+func TestPeerReviewGate_RejectedReviewReopensTask(t *testing.T) {
+	f := newReviewFixture(t, "inference-001", "inference-002")
+
+	// The result arrives before the author reports completion.
+	f.result(t, f.author, "task-1", "markets did things")
+	env, _ := f.reviewAssignment(t)
+	f.result(t, env.Recipient, env.TaskID, "REJECT: no figures given")
+
+	advanceTask(t, f.monitor, "task-1", StatusInProgress, StatusReview, StatusComplete)
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusInProgress {
+		t.Errorf("status after rejection = %s, want in_progress", status)
+	}
+	if len(f.gatePub.messages) != 1 || f.gatePub.messages[0].Type != hcs.MessageTypeOutputRejected ||
+		f.gatePub.messages[0].Recipient != f.author {
+		t.Fatalf("published %+v, want one output_rejected to the author", f.gatePub.messages)
+	}
+
+	// A corrected result starts a new round.
+	f.result(t, f.author, "task-1", "markets rose 2% on rate cut hopes")
+	next, _ := f.reviewAssignment(t)
+	if next.TaskID == env.TaskID {
+		t.Fatalf("no new review round, latest review task %s", next.TaskID)
+	}
+}
+
+func TestPeerReviewGate_SecondRoundDecidesResubmission(t *testing.T) {
+	f := newReviewFixture(t, "inference-001", "inference-002", "inference-003")
+
+	advanceTask(t, f.monitor, "task-1", StatusInProgress, StatusReview, StatusComplete)
+	f.result(t, f.author, "task-1", "markets did things")
+	first, _ := f.reviewAssignment(t)
+	f.result(t, first.Recipient, first.TaskID, "REJECT: no figures given")
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusInProgress {
+		t.Fatalf("status after rejection = %s, want in_progress", status)
+	}
+
+	// The resubmission is held until the second round is decided, not
+	// rejected again by the first round's verdict.
+	advanceTask(t, f.monitor, "task-1", StatusReview, StatusComplete)
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusReview {
+		t.Fatalf("status after resubmission = %s, want review", status)
+	}
+
+	f.result(t, f.author, "task-1", "markets rose 2% on rate cut hopes")
+	second, _ := f.reviewAssignment(t)
+	if second.TaskID == first.TaskID {
+		t.Fatalf("no new review round, latest review task %s", second.TaskID)
+	}
+	f.result(t, second.Recipient, second.TaskID, `{"approved": true}`)
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusComplete {
+		t.Errorf("status after second round approval = %s, want complete", status)
+	}
+}
+
+func TestPeerReviewGate_NoSecondAgentRejects(t *testing.T) {
+	f := newReviewFixture(t, "inference-001")
+
+	advanceTask(t, f.monitor, "task-1", StatusInProgress, StatusReview, StatusComplete)
+	f.result(t, f.author, "task-1", "markets rallied")
+
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusInProgress {
+		t.Errorf("status = %s, want in_progress when no reviewer exists", status)
+	}
+	if len(f.gatePub.messages) != 1 {
+		t.Errorf("published %d messages, want one output_rejected", len(f.gatePub.messages))
+	}
+}
+
+func TestPeerReviewGate_IgnoresOtherTaskTypesAndSenders(t *testing.T) {
+	f := newReviewFixture(t, "inference-001", "inference-002")
+
+	if passed, err := f.gate.Evaluate(context.Background(), "not-in-plan"); !passed || err != nil {
+		t.Errorf("Evaluate(not-in-plan) = %v, %v, want pass", passed, err)
+	}
+
+	published := len(f.pub.calls)
+	f.result(t, "intruder", "task-1", "spoofed output")
+	if len(f.pub.calls) != published {
+		t.Error("result from an unassigned sender started a review")
+	}
+}
@@ -0,0 +1,135 @@
+package coordinator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// jsonSchema is the subset of JSON Schema used to check agent outputs: type,
+// properties, required, items, enum, minLength, minimum and maximum.
+type jsonSchema struct {
+	Type       string                 `json:"type,omitempty"`
+	Properties map[string]*jsonSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+	Items      *jsonSchema            `json:"items,omitempty"`
+	Enum       []any                  `json:"enum,omitempty"`
+	MinLength  *int                   `json:"minLength,omitempty"`
+	Minimum    *float64               `json:"minimum,omitempty"`
+	Maximum    *float64               `json:"maximum,omitempty"`
+}
+
+// SchemaValidator requires the output to be JSON conforming to a schema.
+type SchemaValidator struct {
+	schema *jsonSchema
+}
+
+// NewSchemaValidator parses a JSON schema.
+func NewSchemaValidator(raw json.RawMessage) (*SchemaValidator, error) {
+	var schema jsonSchema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, fmt.Errorf("parse output schema: %w", err)
+	}
+	return &SchemaValidator{schema: &schema}, nil
+}
+
+// Name implements OutputValidator.
+func (*SchemaValidator) Name() string { return "json_schema" }
+
+// Validate implements OutputValidator.
+func (v *SchemaValidator) Validate(_ PlanTask, result TaskResultPayload) error {
+	var doc any
+	if err := json.Unmarshal([]byte(result.Output), &doc); err != nil {
+		return fmt.Errorf("output is not JSON: %w", err)
+	}
+	return v.schema.check("$", doc)
+}
+
+func (s *jsonSchema) check(path string, value any) error {
+	if s.Type != "" && !schemaTypeMatches(s.Type, value) {
+		return fmt.Errorf("%s: want %s, got %s", path, s.Type, schemaTypeOf(value))
+	}
+	if len(s.Enum) > 0 && !enumContains(s.Enum, value) {
+		return fmt.Errorf("%s: value not in enum", path)
+	}
+
+	switch v := value.(type) {
+	case string:
+		if s.MinLength != nil && len([]rune(v)) < *s.MinLength {
+			return fmt.Errorf("%s: length %d below minimum %d", path, len([]rune(v)), *s.MinLength)
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			return fmt.Errorf("%s: %g below minimum %g", path, v, *s.Minimum)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			return fmt.Errorf("%s: %g above maximum %g", path, v, *s.Maximum)
+		}
+	case map[string]any:
+		var errs []error
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				errs = append(errs, fmt.Errorf("%s: missing required property %q", path, name))
+			}
+		}
+		for name, prop := range s.Properties {
+			if field, ok := v[name]; ok {
+				if err := prop.check(path+"."+name, field); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+		return errors.Join(errs...)
+	case []any:
+		if s.Items == nil {
+			return nil
+		}
+		for i, item := range v {
+			if err := s.Items.check(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func schemaTypeMatches(want string, value any) bool {
+	got := schemaTypeOf(value)
+	if want == "number" && got == "integer" {
+		return true
+	}
+	return strings.EqualFold(want, got)
+}
+
+func schemaTypeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func enumContains(enum []any, value any) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(value) && schemaTypeOf(e) == schemaTypeOf(value) {
+			return true
+		}
+	}
+	return false
+}
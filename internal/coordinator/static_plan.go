@@ -1,10 +1,12 @@
 package coordinator
 
 // IntegrationCyclePlan returns a hardcoded plan for the three-agent
-// integration cycle. Task 1 goes to the inference agent, task 2 to defi.
-func IntegrationCyclePlan(inferenceAgentID, defiAgentID string) Plan {
+// integration cycle: an inference task followed by a trade that depends on it.
+// Tasks are routed by type to capable agents.
+func IntegrationCyclePlan() Plan {
 	return Plan{
-		FestivalID: "integration-cycle-001",
+		FestivalID:            "integration-cycle-001",
+		DefaultTimeoutSeconds: 300,
 		Sequences: []PlanSequence{
 			{
 				ID: "seq-01",
@@ -12,7 +14,7 @@ func IntegrationCyclePlan(inferenceAgentID, defiAgentID string) Plan {
 					{
 						ID:            "task-inference-01",
 						Name:          "market_sentiment_analysis",
-						AssignTo:      inferenceAgentID,
+						TaskType:      TaskTypeInference,
 						ModelID:       "qwen/qwen-2.5-7b-instruct",
 						Input:         "Analyze market sentiment for ETH",
 						Priority:      1,
@@ -22,8 +24,7 @@ func IntegrationCyclePlan(inferenceAgentID, defiAgentID string) Plan {
 					{
 						ID:            "task-defi-01",
 						Name:          "execute_trade",
-						TaskType:      "execute_trade",
-						AssignTo:      defiAgentID,
+						TaskType:      TaskTypeTrade,
 						Priority:      1,
 						PaymentAmount: 100,
 						Dependencies:  []string{"task-inference-01"},
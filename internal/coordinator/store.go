@@ -0,0 +1,272 @@
+package coordinator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// StateStore persists coordinator task state so it survives restarts.
+// Components write through to the store on every state change, and
+// Recover replays the store into them at startup.
+type StateStore interface {
+	// SaveTaskStatus records a task's lifecycle status.
+	SaveTaskStatus(taskID string, status TaskStatus) error
+
+	// SaveAssignment records which agent a task was assigned to.
+	SaveAssignment(taskID string, agentID string) error
+
+	// SavePaymentState records a task's payment status.
+	SavePaymentState(taskID string, state PaymentState) error
+
+	// SaveResult records the result an agent reported for a task.
+	SaveResult(result TaskResultPayload) error
+
+	// SaveAttempt records the outcome of one attempt at a task.
+	SaveAttempt(attempt TaskAttempt) error
+
+	// SavePaymentRecord records a payment attempt in the payment ledger.
+	SavePaymentRecord(record PaymentRecord) error
+
+	// SaveEscrow records a change to a task's escrowed payment.
+	SaveEscrow(escrow TaskEscrow) error
+
+	// Load returns the latest persisted state for every task.
+	Load() (StateSnapshot, error)
+}
+
+// StateSnapshot is the latest persisted state for every task.
+type StateSnapshot struct {
+	TaskStatuses map[string]TaskStatus
+	Assignments  map[string]string
+	Payments     map[string]PaymentState
+	Results      map[string]TaskResultPayload
+
+	// Attempts holds every recorded attempt per task, oldest first.
+	Attempts map[string][]TaskAttempt
+
+	// PaymentRecords holds the latest record of every payment attempt per
+	// task, oldest first.
+	PaymentRecords map[string][]PaymentRecord
+
+	// Escrows holds the latest escrow per task.
+	Escrows map[string]TaskEscrow
+}
+
+func newStateSnapshot() StateSnapshot {
+	return StateSnapshot{
+		TaskStatuses: make(map[string]TaskStatus),
+		Assignments:  make(map[string]string),
+		Payments:     make(map[string]PaymentState),
+		Results:      make(map[string]TaskResultPayload),
+		Attempts:     make(map[string][]TaskAttempt),
+
+		PaymentRecords: make(map[string][]PaymentRecord),
+		Escrows:        make(map[string]TaskEscrow),
+	}
+}
+
+// stateRecordKind identifies what a persisted state record describes.
+type stateRecordKind string
+
+const (
+	recordTaskStatus stateRecordKind = "task_status"
+	recordAssignment stateRecordKind = "assignment"
+	recordPayment    stateRecordKind = "payment"
+	recordResult     stateRecordKind = "result"
+	recordAttempt    stateRecordKind = "attempt"
+
+	recordPaymentRecord stateRecordKind = "payment_record"
+	recordEscrow        stateRecordKind = "escrow"
+)
+
+// stateRecord is one line of the append-only state log.
+type stateRecord struct {
+	Kind    stateRecordKind    `json:"kind"`
+	TaskID  string             `json:"task_id"`
+	Status  TaskStatus         `json:"status,omitempty"`
+	AgentID string             `json:"agent_id,omitempty"`
+	Payment PaymentState       `json:"payment,omitempty"`
+	Result  *TaskResultPayload `json:"result,omitempty"`
+	Attempt *TaskAttempt       `json:"attempt,omitempty"`
+	Record  *PaymentRecord     `json:"record,omitempty"`
+	Escrow  *TaskEscrow        `json:"escrow,omitempty"`
+	Time    time.Time          `json:"time"`
+}
+
+// FileStore implements StateStore as an append-only JSON-lines log. Each
+// change is appended and fsynced before the write returns; Load replays the
+// log with the last record for each task winning; attempts and payment
+// records accumulate.
+type FileStore struct {
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileStore opens (or creates) the state log at path.
+func NewFileStore(path string) (*FileStore, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("open state store %s: create dir: %w", path, err)
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open state store %s: %w", path, err)
+	}
+	return &FileStore{path: path, file: f}, nil
+}
+
+// Close closes the underlying log file.
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// SaveTaskStatus records a task's lifecycle status.
+func (s *FileStore) SaveTaskStatus(taskID string, status TaskStatus) error {
+	return s.append(stateRecord{Kind: recordTaskStatus, TaskID: taskID, Status: status})
+}
+
+// SaveAssignment records which agent a task was assigned to.
+func (s *FileStore) SaveAssignment(taskID string, agentID string) error {
+	return s.append(stateRecord{Kind: recordAssignment, TaskID: taskID, AgentID: agentID})
+}
+
+// SavePaymentState records a task's payment status.
+func (s *FileStore) SavePaymentState(taskID string, state PaymentState) error {
+	return s.append(stateRecord{Kind: recordPayment, TaskID: taskID, Payment: state})
+}
+
+// SaveResult records the result an agent reported for a task.
+func (s *FileStore) SaveResult(result TaskResultPayload) error {
+	return s.append(stateRecord{Kind: recordResult, TaskID: result.TaskID, Result: &result})
+}
+
+// SaveAttempt records the outcome of one attempt at a task.
+func (s *FileStore) SaveAttempt(attempt TaskAttempt) error {
+	return s.append(stateRecord{Kind: recordAttempt, TaskID: attempt.TaskID, AgentID: attempt.AgentID, Attempt: &attempt})
+}
+
+// SavePaymentRecord records a payment attempt in the payment ledger.
+func (s *FileStore) SavePaymentRecord(record PaymentRecord) error {
+	return s.append(stateRecord{Kind: recordPaymentRecord, TaskID: record.TaskID, AgentID: record.AgentID, Record: &record})
+}
+
+// SaveEscrow records a change to a task's escrowed payment.
+func (s *FileStore) SaveEscrow(escrow TaskEscrow) error {
+	return s.append(stateRecord{Kind: recordEscrow, TaskID: escrow.TaskID, AgentID: escrow.AgentID, Escrow: &escrow})
+}
+
+// Load replays the log and returns the latest state for every task. A
+// truncated final line, left by a crash mid-write, is ignored.
+func (s *FileStore) Load() (StateSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap := newStateSnapshot()
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return snap, fmt.Errorf("load state store %s: %w", s.path, err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	complete := bytes.HasSuffix(data, []byte("\n"))
+	line := 0
+	lines := bytes.Count(data, []byte("\n"))
+
+	for scanner.Scan() {
+		line++
+		raw := scanner.Bytes()
+		if len(bytes.TrimSpace(raw)) == 0 {
+			continue
+		}
+
+		var rec stateRecord
+		if err := json.Unmarshal(raw, &rec); err != nil {
+			if !complete && line == lines+1 {
+				break
+			}
+			return snap, fmt.Errorf("load state store %s: line %d: %w", s.path, line, err)
+		}
+		snap.apply(rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return snap, fmt.Errorf("load state store %s: %w", s.path, err)
+	}
+
+	return snap, nil
+}
+
+func (s *FileStore) append(rec stateRecord) error {
+	rec.Time = time.Now().UTC()
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("persist %s for task %s: marshal: %w", rec.Kind, rec.TaskID, err)
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(data); err != nil {
+		return fmt.Errorf("persist %s for task %s: write: %w", rec.Kind, rec.TaskID, err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("persist %s for task %s: sync: %w", rec.Kind, rec.TaskID, err)
+	}
+	return nil
+}
+
+func (snap StateSnapshot) apply(rec stateRecord) {
+	switch rec.Kind {
+	case recordTaskStatus:
+		snap.TaskStatuses[rec.TaskID] = rec.Status
+	case recordAssignment:
+		snap.Assignments[rec.TaskID] = rec.AgentID
+	case recordPayment:
+		snap.Payments[rec.TaskID] = rec.Payment
+	case recordResult:
+		if rec.Result != nil {
+			snap.Results[rec.TaskID] = *rec.Result
+		}
+	case recordAttempt:
+		if rec.Attempt != nil {
+			snap.Attempts[rec.TaskID] = append(snap.Attempts[rec.TaskID], *rec.Attempt)
+		}
+	case recordPaymentRecord:
+		if rec.Record != nil {
+			snap.PaymentRecords[rec.TaskID] = mergePaymentRecord(snap.PaymentRecords[rec.TaskID], *rec.Record)
+		}
+	case recordEscrow:
+		if rec.Escrow != nil {
+			snap.Escrows[rec.TaskID] = *rec.Escrow
+		}
+	}
+}
+
+// mergePaymentRecord replaces the record for the same attempt, since each
+// attempt is rewritten as it progresses, or appends a new attempt.
+func mergePaymentRecord(records []PaymentRecord, record PaymentRecord) []PaymentRecord {
+	for i := range records {
+		if records[i].Attempt == record.Attempt {
+			records[i] = record
+			return records
+		}
+	}
+	return append(records, record)
+}
+
+// Compile-time interface compliance check.
+var _ StateStore = (*FileStore)(nil)
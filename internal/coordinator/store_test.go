@@ -0,0 +1,246 @@
+package coordinator
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+)
+
+func openTestStore(t *testing.T, path string) *FileStore {
+	t.Helper()
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestFileStore_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "coordinator.jsonl")
+	store := openTestStore(t, path)
+
+	if err := store.SaveTaskStatus("task-1", StatusAssigned); err != nil {
+		t.Fatalf("SaveTaskStatus() error = %v", err)
+	}
+	if err := store.SaveTaskStatus("task-1", StatusInProgress); err != nil {
+		t.Fatalf("SaveTaskStatus() error = %v", err)
+	}
+	if err := store.SaveAssignment("task-1", "agent-1"); err != nil {
+		t.Fatalf("SaveAssignment() error = %v", err)
+	}
+	if err := store.SavePaymentState("task-1", PaymentProcessed); err != nil {
+		t.Fatalf("SavePaymentState() error = %v", err)
+	}
+	if err := store.SaveResult(TaskResultPayload{TaskID: "task-1", Status: "completed"}); err != nil {
+		t.Fatalf("SaveResult() error = %v", err)
+	}
+	for _, rec := range []PaymentRecord{
+		{TaskID: "task-1", Attempt: 1, State: PaymentPending},
+		{TaskID: "task-1", Attempt: 1, State: PaymentFailed},
+		{TaskID: "task-1", Attempt: 2, State: PaymentProcessed, TransactionID: "0.0.2@1700000000.000000001"},
+	} {
+		if err := store.SavePaymentRecord(rec); err != nil {
+			t.Fatalf("SavePaymentRecord() error = %v", err)
+		}
+	}
+	for _, state := range []EscrowState{EscrowHeld, EscrowReleased} {
+		if err := store.SaveEscrow(TaskEscrow{TaskID: "task-1", ScheduleID: "0.0.9", State: state}); err != nil {
+			t.Fatalf("SaveEscrow() error = %v", err)
+		}
+	}
+
+	snap, err := openTestStore(t, path).Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if snap.TaskStatuses["task-1"] != StatusInProgress {
+		t.Errorf("status = %s, want in_progress (last write wins)", snap.TaskStatuses["task-1"])
+	}
+	if snap.Assignments["task-1"] != "agent-1" {
+		t.Errorf("assignment = %q, want agent-1", snap.Assignments["task-1"])
+	}
+	if snap.Payments["task-1"] != PaymentProcessed {
+		t.Errorf("payment = %s, want processed", snap.Payments["task-1"])
+	}
+	if snap.Results["task-1"].Status != "completed" {
+		t.Errorf("result status = %q, want completed", snap.Results["task-1"].Status)
+	}
+	records := snap.PaymentRecords["task-1"]
+	if len(records) != 2 || records[0].State != PaymentFailed || records[1].TransactionID == "" {
+		t.Errorf("payment records = %+v, want failed attempt 1 then settled attempt 2", records)
+	}
+	if escrow := snap.Escrows["task-1"]; escrow.State != EscrowReleased || escrow.ScheduleID != "0.0.9" {
+		t.Errorf("escrow = %+v, want released (last write wins)", escrow)
+	}
+}
+
+func TestFileStore_IgnoresTruncatedTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coordinator.jsonl")
+	store := openTestStore(t, path)
+	if err := store.SaveAssignment("task-1", "agent-1"); err != nil {
+		t.Fatalf("SaveAssignment() error = %v", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	if _, err := f.WriteString(`{"kind":"assignment","task_id":"task-2"`); err != nil {
+		t.Fatalf("write partial record: %v", err)
+	}
+	f.Close()
+
+	snap, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(snap.Assignments) != 1 || snap.Assignments["task-1"] != "agent-1" {
+		t.Errorf("assignments = %v, want only task-1", snap.Assignments)
+	}
+}
+
+func TestFileStore_RejectsCorruptRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coordinator.jsonl")
+	if err := os.WriteFile(path, []byte("not json\n"), 0o644); err != nil {
+		t.Fatalf("write log: %v", err)
+	}
+
+	if _, err := openTestStore(t, path).Load(); err == nil {
+		t.Error("expected error for corrupt record")
+	}
+}
+
+func TestRecover_SkipsAssignedTasksAndKeepsPaymentGuard(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coordinator.jsonl")
+
+	// First run: assign one task and record a processed payment.
+	store := openTestStore(t, path)
+	m := NewMonitor(nil, hiero.TopicID{}, nil)
+	m.SetStore(store)
+	a := NewAssigner(&mockPublisher{}, hiero.TopicID{Topic: 1}, []string{"agent-1"})
+	a.SetStore(store)
+	a.SetMonitor(m, 0)
+	plan := Plan{
+		FestivalID: "test-fest",
+		Sequences:  []PlanSequence{{ID: "seq-1", Tasks: []PlanTask{{ID: "task-1"}}}},
+	}
+	if _, err := a.AssignTasks(context.Background(), plan); err != nil {
+		t.Fatalf("AssignTasks() error = %v", err)
+	}
+	if err := store.SavePaymentState("task-1", PaymentProcessed); err != nil {
+		t.Fatalf("SavePaymentState() error = %v", err)
+	}
+
+	// Second run: recover into fresh components.
+	store2 := openTestStore(t, path)
+	pub := &mockPublisher{}
+	m2 := NewMonitor(nil, hiero.TopicID{}, nil)
+	a2 := NewAssigner(pub, hiero.TopicID{Topic: 1}, []string{"agent-1"})
+	a2.SetMonitor(m2, 0)
+	p2 := NewPayment(nil, nil, DefaultConfig())
+
+	if _, err := Recover(store2, m2, a2, p2, nil, nil); err != nil {
+		t.Fatalf("Recover() error = %v", err)
+	}
+
+	if status, _ := m2.TaskState("task-1"); status != StatusAssigned {
+		t.Errorf("recovered status = %s, want assigned", status)
+	}
+	if got := a2.Assignment("task-1"); got != "agent-1" {
+		t.Errorf("recovered assignment = %q, want agent-1", got)
+	}
+
+	assigned, err := a2.AssignTasks(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("AssignTasks() after recovery error = %v", err)
+	}
+	if len(assigned) != 0 || len(pub.calls) != 0 {
+		t.Errorf("recovered task dispatched again: assigned=%v publishes=%d", assigned, len(pub.calls))
+	}
+
+	if err := p2.PayForTask(context.Background(), "task-1", "0.0.100", 100); err == nil {
+		t.Error("expected double-payment rejection after recovery")
+	}
+}
+
+func TestRecover_ResumesInterruptedPayments(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "coordinator.jsonl")
+
+	// First run: task-1's transfer executes but the coordinator stops before
+	// settling it, and task-2 completes without being paid.
+	p, network, _, agents := newTestPayment(t, testPaymentOptions{agents: 1, transfer: &flakyTransfer{}})
+	agent := agents[0]
+	store := openTestStore(t, path)
+	p.SetStore(store)
+	if err := p.begin("task-1", false); err != nil {
+		t.Fatalf("begin() error = %v", err)
+	}
+	p.openRecord("task-1", agent.String(), 100)
+	if _, err := network.Transfer(ctx, hts.TransferRequest{
+		TokenID: p.config.PaymentTokenID, FromAccountID: p.config.TreasuryAccountID,
+		ToAccountID: agent, Amount: 100, Memo: paymentMemo("task-1"),
+	}); err != nil {
+		t.Fatalf("Transfer() error = %v", err)
+	}
+	for _, taskID := range []string{"task-1", "task-2"} {
+		for _, err := range []error{
+			store.SaveAssignment(taskID, "agent-1"),
+			store.SaveTaskStatus(taskID, StatusComplete),
+			store.SaveResult(TaskResultPayload{TaskID: taskID, Status: "completed", Output: "done"}),
+		} {
+			if err != nil {
+				t.Fatalf("save state: %v", err)
+			}
+		}
+	}
+
+	// Second run.
+	store2 := openTestStore(t, path)
+	p2 := NewPayment(network, &mockPublisher{}, p.config)
+	p2.SetBalances(network)
+	m2 := NewMonitor(nil, hiero.TopicID{}, nil)
+	a2 := NewAssigner(&mockPublisher{}, hiero.TopicID{Topic: 1}, []string{"agent-1"})
+	rh := NewResultHandler(ResultHandlerConfig{
+		Payment:       p2,
+		Config:        p.config,
+		Log:           slog.Default(),
+		AgentAccounts: map[string]string{"agent-1": agent.String()},
+		Assignments:   a2,
+		Monitor:       m2,
+		Events:        NewEventBus(),
+	})
+	if _, err := Recover(store2, m2, a2, p2, rh, nil); err != nil {
+		t.Fatalf("Recover() error = %v", err)
+	}
+
+	records := p2.PaymentRecords("task-1")
+	if state, _ := p2.PaymentStatus("task-1"); state != PaymentFailed || len(records) != 1 ||
+		records[0].Error != errPaymentInterrupted.Error() {
+		t.Fatalf("task-1 payment = %s, %+v; want the interrupted attempt failed", state, records)
+	}
+	if n := newTestPaymentRetrier(p2, network).RetryDue(ctx); n != 1 {
+		t.Fatalf("RetryDue() = %d, want task-1 settled from chain", n)
+	}
+	if state, _ := p2.PaymentStatus("task-1"); state != PaymentProcessed {
+		t.Errorf("task-1 payment after retry = %s, want processed", state)
+	}
+
+	rh.SetPlan(Plan{Sequences: []PlanSequence{{Tasks: []PlanTask{{ID: "task-1"}, {ID: "task-2"}}}}})
+	if n := rh.PayRecovered(ctx); n != 1 {
+		t.Fatalf("PayRecovered() = %d, want task-2 paid", n)
+	}
+	if state, _ := p2.PaymentStatus("task-2"); state != PaymentProcessed {
+		t.Errorf("task-2 payment = %s, want processed", state)
+	}
+	if n := len(network.Transfers()); n != 2 {
+		t.Errorf("transfers = %d, want one per task", n)
+	}
+}
@@ -0,0 +1,282 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrOutputRejected is wrapped by every output validation failure.
+var ErrOutputRejected = errors.New("output rejected")
+
+// OutputValidator inspects a completed task's result before it is accepted.
+type OutputValidator interface {
+	// Name identifies the validator in rejection reasons.
+	Name() string
+
+	// Validate returns an error describing why the result is unacceptable.
+	Validate(task PlanTask, result TaskResultPayload) error
+}
+
+// ValidatorSet maps task types to the validators their results must pass.
+type ValidatorSet map[string][]OutputValidator
+
+// Add appends validators for a task type.
+func (s ValidatorSet) Add(taskType string, validators ...OutputValidator) {
+	s[taskType] = append(s[taskType], validators...)
+}
+
+// Validate runs the task type's validators in order and returns the first
+// failure, wrapping ErrOutputRejected.
+func (s ValidatorSet) Validate(task PlanTask, result TaskResultPayload) error {
+	for _, v := range s[task.TaskType] {
+		if err := v.Validate(task, result); err != nil {
+			return fmt.Errorf("validate task %s with %s: %w: %w", task.ID, v.Name(), ErrOutputRejected, err)
+		}
+	}
+	return nil
+}
+
+// ValidationRule configures the built-in validators for one task type.
+type ValidationRule struct {
+	// Schema is a JSON schema the output must parse as and conform to.
+	Schema json.RawMessage `json:"schema,omitempty"`
+
+	// MinLength is the minimum output length in characters.
+	MinLength int `json:"min_length,omitempty"`
+
+	// ForbiddenPatterns are regular expressions the output must not match.
+	ForbiddenPatterns []string `json:"forbidden_patterns,omitempty"`
+
+	// RequireTxHash rejects results without a TxHash.
+	RequireTxHash bool `json:"require_tx_hash,omitempty"`
+
+	// PnL checks a numeric profit-and-loss field in the output.
+	PnL *PnLRule `json:"pnl,omitempty"`
+}
+
+// OutputValidation maps task types to validation rules, as configured by the
+// OUTPUT_VALIDATION environment variable.
+type OutputValidation map[string]ValidationRule
+
+// Validators compiles the rules into a ValidatorSet.
+func (o OutputValidation) Validators() (ValidatorSet, error) {
+	set := make(ValidatorSet)
+	types := make([]string, 0, len(o))
+	for taskType := range o {
+		types = append(types, taskType)
+	}
+	sort.Strings(types)
+
+	for _, taskType := range types {
+		rule := o[taskType]
+		if rule.MinLength > 0 {
+			set.Add(taskType, MinLengthValidator{Min: rule.MinLength})
+		}
+		if len(rule.ForbiddenPatterns) > 0 {
+			v, err := NewForbiddenPatternValidator(rule.ForbiddenPatterns...)
+			if err != nil {
+				return nil, fmt.Errorf("output validation for %s: %w", taskType, err)
+			}
+			set.Add(taskType, v)
+		}
+		if len(rule.Schema) > 0 {
+			v, err := NewSchemaValidator(rule.Schema)
+			if err != nil {
+				return nil, fmt.Errorf("output validation for %s: %w", taskType, err)
+			}
+			set.Add(taskType, v)
+		}
+		if rule.RequireTxHash {
+			set.Add(taskType, TxHashValidator{})
+		}
+		if rule.PnL != nil {
+			set.Add(taskType, *rule.PnL)
+		}
+	}
+	return set, nil
+}
+
+// MinLengthValidator rejects outputs shorter than Min characters, ignoring
+// surrounding whitespace.
+type MinLengthValidator struct {
+	Min int
+}
+
+// Name implements OutputValidator.
+func (MinLengthValidator) Name() string { return "min_length" }
+
+// Validate implements OutputValidator.
+func (v MinLengthValidator) Validate(_ PlanTask, result TaskResultPayload) error {
+	if n := len([]rune(strings.TrimSpace(result.Output))); n < v.Min {
+		return fmt.Errorf("output is %d characters, want at least %d", n, v.Min)
+	}
+	return nil
+}
+
+// ForbiddenPatternValidator rejects outputs matching any of its patterns.
+type ForbiddenPatternValidator struct {
+	patterns []*regexp.Regexp
+}
+
+// NewForbiddenPatternValidator compiles the given regular expressions.
+func NewForbiddenPatternValidator(patterns ...string) (*ForbiddenPatternValidator, error) {
+	v := &ForbiddenPatternValidator{}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("compile forbidden pattern %q: %w", p, err)
+		}
+		v.patterns = append(v.patterns, re)
+	}
+	return v, nil
+}
+
+// Name implements OutputValidator.
+func (*ForbiddenPatternValidator) Name() string { return "forbidden_pattern" }
+
+// Validate implements OutputValidator.
+func (v *ForbiddenPatternValidator) Validate(_ PlanTask, result TaskResultPayload) error {
+	for _, re := range v.patterns {
+		if re.MatchString(result.Output) {
+			return fmt.Errorf("output matches forbidden pattern %q", re.String())
+		}
+	}
+	return nil
+}
+
+var txHashPattern = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{64}$`)
+
+// TxHashValidator requires trade results to carry the hash of the executed
+// transaction.
+type TxHashValidator struct{}
+
+// Name implements OutputValidator.
+func (TxHashValidator) Name() string { return "tx_hash" }
+
+// Validate implements OutputValidator.
+func (TxHashValidator) Validate(_ PlanTask, result TaskResultPayload) error {
+	if result.TxHash == "" {
+		return errors.New("result has no tx_hash")
+	}
+	if !txHashPattern.MatchString(result.TxHash) {
+		return fmt.Errorf("tx_hash %q is not a 32-byte hex hash", result.TxHash)
+	}
+	return nil
+}
+
+// PnLRule checks that the output is a JSON object whose PnL field is a finite
+// number within bounds.
+type PnLRule struct {
+	// Field names the PnL field in the output object. Defaults to "net_pnl".
+	Field string `json:"field,omitempty"`
+
+	// MaxAbs rejects PnL values whose magnitude exceeds it when positive.
+	MaxAbs float64 `json:"max_abs,omitempty"`
+}
+
+// Name implements OutputValidator.
+func (PnLRule) Name() string { return "pnl" }
+
+// Validate implements OutputValidator.
+func (r PnLRule) Validate(_ PlanTask, result TaskResultPayload) error {
+	field := r.Field
+	if field == "" {
+		field = "net_pnl"
+	}
+
+	var output map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(result.Output), &output); err != nil {
+		return fmt.Errorf("output is not a JSON object: %w", err)
+	}
+	raw, ok := output[field]
+	if !ok {
+		return fmt.Errorf("output has no %s field", field)
+	}
+	var pnl float64
+	if err := json.Unmarshal(raw, &pnl); err != nil {
+		return fmt.Errorf("%s is not a number: %s", field, raw)
+	}
+	if math.IsNaN(pnl) || math.IsInf(pnl, 0) {
+		return fmt.Errorf("%s is not finite", field)
+	}
+	if r.MaxAbs > 0 && math.Abs(pnl) > r.MaxAbs {
+		return fmt.Errorf("%s %g exceeds bound %g", field, pnl, r.MaxAbs)
+	}
+	return nil
+}
+
+// OutputGate is a QualityGateEnforcer that validates a task's result output
+// against the validators for its TaskType. Tasks are matched to their plan
+// entries to find the type; tasks outside the plan pass. A task whose
+// completed result has not arrived yet is held with ErrGatePending, and the
+// result handler has the monitor evaluate the gate again once it arrives.
+type OutputGate struct {
+	validators ValidatorSet
+
+	mu      sync.RWMutex
+	plan    Plan
+	results ResultLookup
+}
+
+// NewOutputGate creates a gate that validates results against validators.
+func NewOutputGate(validators ValidatorSet) *OutputGate {
+	return &OutputGate{validators: validators}
+}
+
+// SetResults configures where Evaluate looks up task results. Without it,
+// Evaluate passes every task and results are only validated through Check.
+func (g *OutputGate) SetResults(results ResultLookup) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.results = results
+}
+
+// SetPlan sets the plan whose task types select the validators.
+func (g *OutputGate) SetPlan(plan Plan) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.plan = plan
+}
+
+// Check validates a result for the plan task it belongs to.
+func (g *OutputGate) Check(result TaskResultPayload) error {
+	g.mu.RLock()
+	task := g.plan.TaskByID(result.TaskID)
+	g.mu.RUnlock()
+	if task == nil {
+		return nil
+	}
+	return g.validators.Validate(*task, result)
+}
+
+// Evaluate implements QualityGateEnforcer.
+func (g *OutputGate) Evaluate(ctx context.Context, taskID string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, fmt.Errorf("evaluate output gate for task %s: %w", taskID, err)
+	}
+	g.mu.RLock()
+	results := g.results
+	inPlan := g.plan.TaskByID(taskID) != nil
+	g.mu.RUnlock()
+	if results == nil || !inPlan {
+		return true, nil
+	}
+	result, ok := results.Result(taskID)
+	if !ok || result.Status != "completed" {
+		return false, fmt.Errorf("evaluate output gate for task %s: no completed result: %w", taskID, ErrGatePending)
+	}
+	if err := g.Check(result); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Compile-time interface compliance check.
+var _ QualityGateEnforcer = (*OutputGate)(nil)
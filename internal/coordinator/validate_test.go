@@ -0,0 +1,189 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+)
+
+func TestOutputValidation_Validators(t *testing.T) {
+	var validation OutputValidation
+	raw := `{
+		"inference_job": {
+			"min_length": 10,
+			"forbidden_patterns": ["(?i)as an ai"],
+			"schema": {"type": "object", "required": ["summary"], "properties": {"summary": {"type": "string", "minLength": 5}}}
+		},
+		"execute_trade": {"require_tx_hash": true, "pnl": {"max_abs": 1000}}
+	}`
+	if err := json.Unmarshal([]byte(raw), &validation); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	validators, err := validation.Validators()
+	if err != nil {
+		t.Fatalf("Validators() error = %v", err)
+	}
+
+	inference := PlanTask{ID: "task-1", TaskType: TaskTypeInference}
+	trade := PlanTask{ID: "task-2", TaskType: TaskTypeTrade}
+	txHash := strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name      string
+		task      PlanTask
+		result    TaskResultPayload
+		validator string
+	}{
+		{"valid inference", inference, TaskResultPayload{Output: `{"summary": "markets rallied"}`}, ""},
+		{"too short", inference, TaskResultPayload{Output: `{}`}, "min_length"},
+		{"not json", inference, TaskResultPayload{Output: "plain text answer"}, "json_schema"},
+		{"missing required", inference, TaskResultPayload{Output: `{"other": "markets rallied"}`}, "json_schema"},
+		{"wrong property type", inference, TaskResultPayload{Output: `{"summary": 12345678}`}, "json_schema"},
+		{"forbidden phrase", inference, TaskResultPayload{Output: `{"summary": "As an AI I cannot"}`}, "forbidden_pattern"},
+		{"valid trade", trade, TaskResultPayload{TxHash: "0x" + txHash, Output: `{"net_pnl": -12.5}`}, ""},
+		{"missing tx hash", trade, TaskResultPayload{Output: `{"net_pnl": 1}`}, "tx_hash"},
+		{"malformed tx hash", trade, TaskResultPayload{TxHash: "0xnope", Output: `{"net_pnl": 1}`}, "tx_hash"},
+		{"pnl out of bounds", trade, TaskResultPayload{TxHash: txHash, Output: `{"net_pnl": 1e9}`}, "pnl"},
+		{"pnl not a number", trade, TaskResultPayload{TxHash: txHash, Output: `{"net_pnl": "lots"}`}, "pnl"},
+		{"untyped task passes", PlanTask{ID: "task-3", TaskType: "audit"}, TaskResultPayload{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validators.Validate(tt.task, tt.result)
+			if tt.validator == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrOutputRejected) {
+				t.Fatalf("Validate() error = %v, want ErrOutputRejected", err)
+			}
+			if !strings.Contains(err.Error(), "with "+tt.validator+":") {
+				t.Errorf("Validate() error = %v, want failure from %s", err, tt.validator)
+			}
+		})
+	}
+}
+
+func TestOutputValidation_RejectsBadRules(t *testing.T) {
+	for _, rule := range []ValidationRule{
+		{ForbiddenPatterns: []string{"("}},
+		{Schema: json.RawMessage(`[1, 2]`)},
+	} {
+		if _, err := (OutputValidation{"inference_job": rule}).Validators(); err == nil {
+			t.Errorf("Validators() with %+v: expected error", rule)
+		}
+	}
+}
+
+func TestResultHandler_RejectsInvalidOutput(t *testing.T) {
+	plan := Plan{Sequences: []PlanSequence{{ID: "seq-1", Tasks: []PlanTask{
+		{ID: "task-1", TaskType: TaskTypeInference, PaymentAmount: 10},
+	}}}}
+	validators := make(ValidatorSet)
+	validators.Add(TaskTypeInference, MinLengthValidator{Min: 20})
+	gate := NewOutputGate(validators)
+	gate.SetPlan(plan)
+
+	m := NewMonitor(nil, hiero.TopicID{}, gate)
+	m.InitTask("task-1")
+	m.MarkAssigned("task-1")
+	advanceTask(t, m, "task-1", StatusInProgress, StatusReview, StatusComplete)
+
+	payment := &recordingPayment{}
+	pub := &capturePublisher{}
+	rh := NewResultHandler(ResultHandlerConfig{
+		Publisher:     pub,
+		Payment:       payment,
+		Config:        DefaultConfig(),
+		Log:           discardLogger(),
+		AgentAccounts: map[string]string{"inference-001": "0.0.201"},
+		Assignments:   staticAssignments{"task-1": "inference-001"},
+		OutputGate:    gate,
+		Monitor:       m,
+	})
+	rh.SetPlan(plan)
+	gate.SetResults(rh)
+
+	rh.processMessage(context.Background(), taskResultEnvelope(t, "inference-001",
+		TaskResultPayload{TaskID: "task-1", Status: "completed", Output: "too short"}))
+
+	if len(payment.calls) != 0 {
+		t.Fatalf("payments = %+v, want none for rejected output", payment.calls)
+	}
+	if status, _ := m.TaskState("task-1"); status != StatusInProgress {
+		t.Errorf("status = %s, want in_progress", status)
+	}
+	if len(pub.messages) != 1 || pub.messages[0].Type != hcs.MessageTypeOutputRejected {
+		t.Fatalf("published = %+v, want one output_rejected", pub.messages)
+	}
+	var rejected OutputRejectedPayload
+	if err := json.Unmarshal(pub.messages[0].Payload, &rejected); err != nil {
+		t.Fatalf("unmarshal rejection: %v", err)
+	}
+	if pub.messages[0].Recipient != "inference-001" || rejected.TaskType != TaskTypeInference || !strings.Contains(rejected.Reason, "min_length") {
+		t.Errorf("rejection = %+v to %q", rejected, pub.messages[0].Recipient)
+	}
+
+	// Completing again against the rejected result is refused by the gate.
+	advanceTask(t, m, "task-1", StatusReview, StatusComplete)
+	if status, _ := m.TaskState("task-1"); status != StatusInProgress {
+		t.Errorf("status after re-complete = %s, want in_progress", status)
+	}
+
+	rh.processMessage(context.Background(), taskResultEnvelope(t, "inference-001",
+		TaskResultPayload{TaskID: "task-1", Status: "completed", Output: "a sufficiently long answer"}))
+	if len(payment.calls) != 1 {
+		t.Fatalf("payments = %+v, want one after corrected output", payment.calls)
+	}
+	advanceTask(t, m, "task-1", StatusReview, StatusComplete)
+	if status, _ := m.TaskState("task-1"); status != StatusComplete {
+		t.Errorf("status after corrected output = %s, want complete", status)
+	}
+}
+
+func TestOutputGate_HoldsCompletionUntilResult(t *testing.T) {
+	plan := Plan{Sequences: []PlanSequence{{ID: "seq-1", Tasks: []PlanTask{
+		{ID: "task-1", TaskType: TaskTypeInference, PaymentAmount: 10},
+	}}}}
+	validators := make(ValidatorSet)
+	validators.Add(TaskTypeInference, MinLengthValidator{Min: 20})
+	gate := NewOutputGate(validators)
+	gate.SetPlan(plan)
+
+	m := NewMonitor(nil, hiero.TopicID{}, gate)
+	m.InitTask("task-1")
+	m.MarkAssigned("task-1")
+	rh := NewResultHandler(ResultHandlerConfig{
+		Publisher:     &capturePublisher{},
+		Payment:       &recordingPayment{},
+		Config:        DefaultConfig(),
+		Log:           discardLogger(),
+		AgentAccounts: map[string]string{"inference-001": "0.0.201"},
+		Assignments:   staticAssignments{"task-1": "inference-001"},
+		OutputGate:    gate,
+		Monitor:       m,
+	})
+	rh.SetPlan(plan)
+	gate.SetResults(rh)
+
+	// The agent claims completion before its result has arrived.
+	advanceTask(t, m, "task-1", StatusInProgress, StatusComplete)
+	if status, _ := m.TaskState("task-1"); status != StatusReview {
+		t.Fatalf("status before result = %s, want review", status)
+	}
+
+	rh.processMessage(context.Background(), taskResultEnvelope(t, "inference-001",
+		TaskResultPayload{TaskID: "task-1", Status: "completed", Output: "a sufficiently long answer"}))
+	m.gateWG.Wait()
+	if status, _ := m.TaskState("task-1"); status != StatusComplete {
+		t.Errorf("status after result = %s, want complete", status)
+	}
+}
@@ -0,0 +1,240 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RevokeReasonLeaseExpired is the revocation reason for a task whose agent
+// stopped renewing its lease.
+const RevokeReasonLeaseExpired = "lease_expired"
+
+// TaskRevokedPayload is the payload for a task revocation message. The agent
+// named must stop work on the task; results it sends afterwards are rejected.
+type TaskRevokedPayload struct {
+	TaskID  string `json:"task_id"`
+	AgentID string `json:"agent_id"`
+	Reason  string `json:"reason"`
+
+	// Attempt counts the agents that have held the task, including this one.
+	Attempt int `json:"attempt"`
+}
+
+// taskLease is an agent's time-limited hold on an assigned task.
+type taskLease struct {
+	task     PlanTask
+	agentID  string
+	timeout  time.Duration
+	deadline time.Time
+
+	// holders lists every agent that has held the task, oldest first.
+	holders []string
+}
+
+// Watchdog enforces task deadlines. Each assigned task with a timeout is
+// leased to its agent; any status_update from that agent renews the lease.
+// When a lease expires the task is failed, the agent is sent a revocation,
+// and the task is reassigned to another capable agent until
+// Config.MaxReassignments is reached. Leases do not run while a task is in
+// review.
+type Watchdog struct {
+	assigner         *Assigner
+	monitor          *Monitor
+	interval         time.Duration
+	maxReassignments int
+	logger           *slog.Logger
+	now              func() time.Time
+
+	mu        sync.Mutex
+	leases    map[string]*taskLease
+	abandoned map[string]bool // expired tasks left failed
+}
+
+// NewWatchdog creates a watchdog that checks leases every
+// cfg.MonitorPollInterval and reassigns through the assigner.
+func NewWatchdog(assigner *Assigner, monitor *Monitor, cfg Config) *Watchdog {
+	return &Watchdog{
+		assigner:         assigner,
+		monitor:          monitor,
+		interval:         cfg.MonitorPollInterval,
+		maxReassignments: cfg.MaxReassignments,
+		logger:           slog.Default(),
+		now:              time.Now,
+		leases:           make(map[string]*taskLease),
+		abandoned:        make(map[string]bool),
+	}
+}
+
+// Track leases a newly assigned task to its agent for timeout. A zero
+// timeout means the task has no deadline and is not tracked.
+func (w *Watchdog) Track(task PlanTask, agentID string, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	w.track(task, agentID, timeout, []string{agentID})
+}
+
+func (w *Watchdog) track(task PlanTask, agentID string, timeout time.Duration, holders []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.abandoned, task.ID)
+	w.leases[task.ID] = &taskLease{
+		task:     task,
+		agentID:  agentID,
+		timeout:  timeout,
+		deadline: w.now().Add(timeout),
+		holders:  holders,
+	}
+}
+
+// Release stops tracking a task's lease.
+func (w *Watchdog) Release(taskID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.leases, taskID)
+}
+
+// Abandoned reports whether a task whose lease expired was left failed, with
+// no agent to take it over.
+func (w *Watchdog) Abandoned(taskID string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.abandoned[taskID]
+}
+
+// Renew extends the task's lease if agentID holds it. It reports false when
+// the task is leased to a different agent; tasks without a lease always
+// report true.
+func (w *Watchdog) Renew(taskID, agentID string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	lease, ok := w.leases[taskID]
+	if !ok {
+		return true
+	}
+	if lease.agentID != agentID {
+		return false
+	}
+	lease.deadline = w.now().Add(lease.timeout)
+	return true
+}
+
+// Start checks leases until the context is cancelled.
+func (w *Watchdog) Start(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("watchdog start: %w", err)
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			w.check(ctx)
+		}
+	}
+}
+
+// check drops leases on tasks that have finished and expires the rest once
+// their deadline passes. A task in review is waiting on the coordinator, not
+// its agent, so its lease is paused: the deadline restarts from now and the
+// agent gets a full timeout if the review sends the task back.
+func (w *Watchdog) check(ctx context.Context) {
+	// Read task states before taking w.mu: the monitor calls Renew while
+	// holding its own lock.
+	states := w.monitor.AllTaskStates()
+	now := w.now()
+
+	w.mu.Lock()
+	var expired []*taskLease
+	for taskID, lease := range w.leases {
+		switch states[taskID] {
+		case StatusComplete, StatusPaid, StatusFailed:
+			delete(w.leases, taskID)
+			continue
+		case StatusReview:
+			lease.deadline = now.Add(lease.timeout)
+			continue
+		}
+		if now.After(lease.deadline) {
+			expired = append(expired, lease)
+			delete(w.leases, taskID)
+		}
+	}
+	w.mu.Unlock()
+
+	sort.Slice(expired, func(i, j int) bool { return expired[i].task.ID < expired[j].task.ID })
+	for _, lease := range expired {
+		w.expire(ctx, lease)
+	}
+}
+
+// expire fails a task whose lease ran out, revokes it from its agent and
+// hands it to an agent that has not held it yet, if the retry limit allows.
+func (w *Watchdog) expire(ctx context.Context, lease *taskLease) {
+	taskID := lease.task.ID
+	w.logger.Warn("task lease expired",
+		"task_id", taskID, "agent_id", lease.agentID, "attempt", len(lease.holders))
+
+	if err := w.assigner.publishRevocation(ctx, TaskRevokedPayload{
+		TaskID:  taskID,
+		AgentID: lease.agentID,
+		Reason:  RevokeReasonLeaseExpired,
+		Attempt: len(lease.holders),
+	}); err != nil {
+		w.logger.Warn("failed to publish task revocation", "task_id", taskID, "error", err)
+	}
+
+	var next string
+	if len(lease.holders) <= w.maxReassignments {
+		agentID, err := w.assigner.selectAgent(lease.task, lease.holders...)
+		if err != nil {
+			w.logger.Warn("no other agent can take expired task", "task_id", taskID, "error", err)
+		}
+		next = agentID
+	} else {
+		w.logger.Warn("task reassignment limit reached, leaving task failed",
+			"task_id", taskID, "max_reassignments", w.maxReassignments)
+	}
+
+	if next == "" {
+		w.abandon(taskID)
+	}
+	if err := w.monitor.failTask(taskID, next != ""); err != nil {
+		w.logger.Warn("failed to fail expired task", "task_id", taskID, "error", err)
+		return
+	}
+	if next == "" {
+		return
+	}
+
+	assigned, err := w.assigner.assignPlanTask(ctx, lease.task, next)
+	if err != nil || !assigned {
+		w.logger.Warn("failed to reassign expired task",
+			"task_id", taskID, "agent_id", next, "error", err)
+		w.abandon(taskID)
+		if err := w.monitor.failTask(taskID, false); err != nil {
+			w.logger.Warn("failed to fail unassigned task", "task_id", taskID, "error", err)
+		}
+		return
+	}
+
+	w.logger.Info("expired task reassigned",
+		"task_id", taskID, "from_agent", lease.agentID, "to_agent", next)
+	w.track(lease.task, next, lease.timeout, append(lease.holders, next))
+}
+
+// abandon records that an expired task is left failed.
+func (w *Watchdog) abandon(taskID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.abandoned[taskID] = true
+}
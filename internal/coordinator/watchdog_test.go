@@ -0,0 +1,235 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+)
+
+// watchdogFixture wires an assigner, monitor and watchdog over two
+// interchangeable inference agents, with a clock the test controls.
+type watchdogFixture struct {
+	*coordinatorFixture
+	watchdog *Watchdog
+	now      time.Time
+}
+
+func newWatchdogFixture(t *testing.T, maxReassignments int) *watchdogFixture {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.MaxReassignments = maxReassignments
+
+	f := &watchdogFixture{coordinatorFixture: newCoordinatorFixture(t), now: time.Unix(1_700_000_000, 0)}
+	f.watchdog = NewWatchdog(f.assigner, f.monitor, cfg)
+	f.watchdog.now = func() time.Time { return f.now }
+	f.assigner.SetWatchdog(f.watchdog)
+	f.monitor.SetWatchdog(f.watchdog)
+	return f
+}
+
+func (f *watchdogFixture) assign(t *testing.T, timeoutSeconds int) {
+	t.Helper()
+	plan := singleTaskPlan("fest-lease")
+	plan.DefaultTimeoutSeconds = timeoutSeconds
+	if _, err := f.assigner.AssignTasks(context.Background(), plan); err != nil {
+		t.Fatalf("AssignTasks: %v", err)
+	}
+}
+
+func (f *watchdogFixture) statusUpdate(t *testing.T, sender string, status TaskStatus) {
+	t.Helper()
+	payload, err := json.Marshal(StatusUpdatePayload{TaskID: "task-1", AgentID: sender, NewStatus: status})
+	if err != nil {
+		t.Fatalf("marshal status update: %v", err)
+	}
+	f.monitor.processMessage(context.Background(), hcs.Envelope{
+		Type: hcs.MessageTypeStatusUpdate, Sender: sender, TaskID: "task-1", Payload: payload,
+	})
+}
+
+func (f *watchdogFixture) revocations(t *testing.T) []TaskRevokedPayload {
+	t.Helper()
+	var revoked []TaskRevokedPayload
+	for _, env := range f.pub.calls {
+		if env.Type != hcs.MessageTypeTaskRevoked {
+			continue
+		}
+		var payload TaskRevokedPayload
+		if err := json.Unmarshal(env.Payload, &payload); err != nil {
+			t.Fatalf("unmarshal revocation: %v", err)
+		}
+		if env.Recipient != payload.AgentID {
+			t.Errorf("revocation recipient = %q, want %q", env.Recipient, payload.AgentID)
+		}
+		revoked = append(revoked, payload)
+	}
+	return revoked
+}
+
+func TestWatchdog_ExpiredLeaseReassigns(t *testing.T) {
+	f := newWatchdogFixture(t, 2)
+	f.assign(t, 60)
+	first := f.assigner.Assignment("task-1")
+
+	f.now = f.now.Add(61 * time.Second)
+	f.watchdog.check(context.Background())
+
+	revoked := f.revocations(t)
+	if len(revoked) != 1 || revoked[0].AgentID != first || revoked[0].Reason != RevokeReasonLeaseExpired {
+		t.Fatalf("revocations = %+v, want one lease_expired revocation for %s", revoked, first)
+	}
+	second := f.assigner.Assignment("task-1")
+	if second == "" || second == first {
+		t.Fatalf("reassigned to %q, want the other agent than %q", second, first)
+	}
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusAssigned {
+		t.Errorf("status = %s, want assigned", status)
+	}
+
+	// The revoked agent can no longer move the task.
+	f.statusUpdate(t, first, StatusInProgress)
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusAssigned {
+		t.Errorf("status after revoked agent update = %s, want assigned", status)
+	}
+	f.statusUpdate(t, second, StatusInProgress)
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusInProgress {
+		t.Errorf("status after new agent update = %s, want in_progress", status)
+	}
+}
+
+func TestWatchdog_StatusUpdateRenewsLease(t *testing.T) {
+	f := newWatchdogFixture(t, 2)
+	f.assign(t, 60)
+	agentID := f.assigner.Assignment("task-1")
+
+	f.now = f.now.Add(50 * time.Second)
+	f.statusUpdate(t, agentID, StatusInProgress)
+	f.now = f.now.Add(50 * time.Second)
+	f.watchdog.check(context.Background())
+
+	if revoked := f.revocations(t); len(revoked) != 0 {
+		t.Fatalf("revocations = %+v, want none after renewal", revoked)
+	}
+	if got := f.assigner.Assignment("task-1"); got != agentID {
+		t.Errorf("assignment = %q, want %q", got, agentID)
+	}
+}
+
+func TestWatchdog_ReassignmentLimit(t *testing.T) {
+	f := newWatchdogFixture(t, 0)
+	f.assign(t, 60)
+
+	f.now = f.now.Add(time.Hour)
+	f.watchdog.check(context.Background())
+
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusFailed {
+		t.Errorf("status = %s, want failed", status)
+	}
+	if len(f.revocations(t)) != 1 {
+		t.Errorf("revocations = %d, want 1", len(f.revocations(t)))
+	}
+}
+
+func TestWatchdog_RunsOutOfAgents(t *testing.T) {
+	f := newWatchdogFixture(t, 5)
+	f.assign(t, 60)
+
+	for i := 0; i < 3; i++ {
+		f.now = f.now.Add(time.Hour)
+		f.watchdog.check(context.Background())
+	}
+
+	// Both agents have held the task once; there is nobody left to try.
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusFailed {
+		t.Errorf("status = %s, want failed", status)
+	}
+	if got := len(f.revocations(t)); got != 2 {
+		t.Errorf("revocations = %d, want 2", got)
+	}
+}
+
+func TestWatchdog_CompletedTaskReleasesLease(t *testing.T) {
+	f := newWatchdogFixture(t, 2)
+	f.assign(t, 60)
+	agentID := f.assigner.Assignment("task-1")
+
+	for _, status := range []TaskStatus{StatusInProgress, StatusReview, StatusComplete} {
+		f.statusUpdate(t, agentID, status)
+	}
+	f.now = f.now.Add(time.Hour)
+	f.watchdog.check(context.Background())
+
+	if revoked := f.revocations(t); len(revoked) != 0 {
+		t.Fatalf("revocations = %+v, want none for completed task", revoked)
+	}
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusComplete {
+		t.Errorf("status = %s, want complete", status)
+	}
+}
+
+func TestWatchdog_ReviewPausesLease(t *testing.T) {
+	f := newWatchdogFixture(t, 2)
+	f.assign(t, 60)
+	agentID := f.assigner.Assignment("task-1")
+
+	f.statusUpdate(t, agentID, StatusInProgress)
+	f.statusUpdate(t, agentID, StatusReview)
+	f.now = f.now.Add(time.Hour)
+	f.watchdog.check(context.Background())
+
+	if revoked := f.revocations(t); len(revoked) != 0 {
+		t.Fatalf("revocations = %+v, want none while task is in review", revoked)
+	}
+	if status, _ := f.monitor.TaskState("task-1"); status != StatusReview {
+		t.Errorf("status = %s, want review", status)
+	}
+}
+
+func TestWatchdog_RecoveredTaskLeaseRearmed(t *testing.T) {
+	f := newWatchdogFixture(t, 2)
+	f.assigner.restore(map[string]string{"task-1": "inference-001"})
+	f.monitor.restore(map[string]TaskStatus{"task-1": StatusInProgress})
+	f.assign(t, 60)
+
+	if revoked := f.revocations(t); len(revoked) != 0 {
+		t.Fatalf("revocations = %+v before the lease ran out", revoked)
+	}
+	f.now = f.now.Add(61 * time.Second)
+	f.watchdog.check(context.Background())
+
+	revoked := f.revocations(t)
+	if len(revoked) != 1 || revoked[0].AgentID != "inference-001" {
+		t.Fatalf("revocations = %+v, want one for inference-001", revoked)
+	}
+	if got := f.assigner.Assignment("task-1"); got != "inference-002" {
+		t.Errorf("reassigned to %q, want inference-002", got)
+	}
+}
+
+func TestWatchdog_NoTimeoutNotTracked(t *testing.T) {
+	f := newWatchdogFixture(t, 2)
+	f.assign(t, 0)
+
+	f.now = f.now.Add(24 * time.Hour)
+	f.watchdog.check(context.Background())
+
+	if revoked := f.revocations(t); len(revoked) != 0 {
+		t.Fatalf("revocations = %+v, want none without a timeout", revoked)
+	}
+}
+
+func TestPlan_TaskTimeout(t *testing.T) {
+	plan := Plan{DefaultTimeoutSeconds: 120}
+	if got := plan.TaskTimeout(PlanTask{}); got != 2*time.Minute {
+		t.Errorf("default timeout = %v, want 2m", got)
+	}
+	if got := plan.TaskTimeout(PlanTask{TimeoutSeconds: 30}); got != 30*time.Second {
+		t.Errorf("task override = %v, want 30s", got)
+	}
+	if got := (Plan{}).TaskTimeout(PlanTask{}); got != 0 {
+		t.Errorf("no timeout = %v, want 0", got)
+	}
+}
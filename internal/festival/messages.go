@@ -42,6 +42,8 @@ func BuildExecutionPlan(resp ShowRoadmapResponse) ExecutionPlan {
 						Status:       normalizeStatus(task.Status),
 						IsGate:       task.IsGate,
 						Dependencies: task.Dependencies,
+						TaskType:     task.TaskType,
+						ModelID:      task.ModelID,
 					})
 				}
 			}
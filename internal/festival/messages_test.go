@@ -37,6 +37,9 @@ func TestBuildExecutionPlan(t *testing.T) {
 	if plan.Sequences[0].Tasks[1].Status != "active" {
 		t.Fatalf("task status = %q, want active", plan.Sequences[0].Tasks[1].Status)
 	}
+	if plan.Sequences[0].Tasks[1].TaskType != "inference_job" {
+		t.Fatalf("task type = %q, want inference_job", plan.Sequences[0].Tasks[1].TaskType)
+	}
 	if !plan.Sequences[0].Tasks[2].IsGate {
 		t.Fatalf("expected third task to be gate")
 	}
@@ -105,6 +105,11 @@ type RoadmapTask struct {
 	Status       string   `json:"status"`
 	IsGate       bool     `json:"is_gate"`
 	Dependencies []string `json:"dependencies"`
+
+	// TaskType and ModelID come from the task's frontmatter and name the
+	// agent capability the task needs.
+	TaskType string `json:"task_type,omitempty"`
+	ModelID  string `json:"model_id,omitempty"`
 }
 
 // ExecutionPlan is the normalized plan produced from fest roadmap output.
@@ -124,6 +129,8 @@ type ExecutionTask struct {
 	Status       string   `json:"status"`
 	IsGate       bool     `json:"is_gate"`
 	Dependencies []string `json:"dependencies,omitempty"`
+	TaskType     string   `json:"task_type,omitempty"`
+	ModelID      string   `json:"model_id,omitempty"`
 }
 
 // ProgressSnapshot is the canonical payload source for dashboard festival progress.
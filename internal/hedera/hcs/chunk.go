@@ -0,0 +1,214 @@
+package hcs
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultMaxMessageSize is the HCS per-transaction message limit in bytes.
+	defaultMaxMessageSize = 1024
+
+	// chunkFrameOverhead reserves room in each message for the chunk frame's
+	// JSON keys, group ID and counters around the base64-encoded data.
+	chunkFrameOverhead = 128
+
+	// maxChunkTotal bounds how many chunks a group may declare, so a corrupt
+	// or hostile frame cannot make the subscriber buffer without limit.
+	maxChunkTotal = 1000
+)
+
+var (
+	// ErrChunkTimeout is reported when a chunk group does not complete in time.
+	ErrChunkTimeout = errors.New("chunk group incomplete before timeout")
+
+	// ErrInvalidChunk is reported for chunk frames with inconsistent metadata.
+	ErrInvalidChunk = errors.New("invalid chunk frame")
+)
+
+// chunkFrame carries one piece of an envelope too large for a single HCS
+// message. Frames are identified by the presence of chunk_group, which an
+// Envelope never has.
+type chunkFrame struct {
+	GroupID string `json:"chunk_group"`
+	Index   int    `json:"chunk_index"`
+	Total   int    `json:"chunk_total"`
+	Data    []byte `json:"chunk_data"`
+}
+
+// splitMessage returns data unchanged if it fits in maxSize, otherwise a
+// sequence of marshalled chunk frames that each fit.
+func splitMessage(data []byte, maxSize int) ([][]byte, error) {
+	if maxSize <= 0 {
+		maxSize = defaultMaxMessageSize
+	}
+	if len(data) <= maxSize {
+		return [][]byte{data}, nil
+	}
+
+	// base64 encodes every 3 bytes as 4 characters.
+	chunkSize := (maxSize - chunkFrameOverhead) / 4 * 3
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("split message: max size %d leaves no room for chunk data", maxSize)
+	}
+
+	total := (len(data) + chunkSize - 1) / chunkSize
+	if total > maxChunkTotal {
+		return nil, fmt.Errorf("split message: %d bytes needs %d chunks, limit is %d", len(data), total, maxChunkTotal)
+	}
+
+	groupID, err := newChunkGroupID()
+	if err != nil {
+		return nil, fmt.Errorf("split message: %w", err)
+	}
+
+	frames := make([][]byte, 0, total)
+	for i := 0; i < total; i++ {
+		end := (i + 1) * chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		frame, err := json.Marshal(chunkFrame{
+			GroupID: groupID,
+			Index:   i,
+			Total:   total,
+			Data:    data[i*chunkSize : end],
+		})
+		if err != nil {
+			return nil, fmt.Errorf("split message: marshal chunk %d: %w", i, err)
+		}
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
+
+func newChunkGroupID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("generate chunk group id: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// chunkGroup is a partially received chunked message.
+type chunkGroup struct {
+	total   int
+	parts   map[int][]byte
+	started time.Time
+}
+
+// chunkAssembler reassembles chunk frames into complete messages. It is
+// shared across reconnects of one subscription. Group age is measured from
+// when the first chunk arrived locally, not its consensus time, so replaying
+// old history does not expire groups that are still being delivered.
+type chunkAssembler struct {
+	timeout time.Duration
+	now     func() time.Time
+
+	mu     sync.Mutex
+	groups map[string]*chunkGroup
+}
+
+func newChunkAssembler(timeout time.Duration) *chunkAssembler {
+	return &chunkAssembler{timeout: timeout, now: time.Now, groups: make(map[string]*chunkGroup)}
+}
+
+// add accepts the contents of one topic message. It returns the complete
+// message and true when contents is an unchunked message or the last missing
+// chunk of a group; otherwise it returns false while the group is still
+// incomplete.
+func (a *chunkAssembler) add(contents []byte) ([]byte, bool, error) {
+	frame, ok := parseChunkFrame(contents)
+	if !ok {
+		return contents, true, nil
+	}
+
+	if frame.Total <= 0 || frame.Total > maxChunkTotal || frame.Index < 0 || frame.Index >= frame.Total {
+		return nil, false, fmt.Errorf("chunk group %s index %d of %d: %w", frame.GroupID, frame.Index, frame.Total, ErrInvalidChunk)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	group, exists := a.groups[frame.GroupID]
+	if !exists {
+		group = &chunkGroup{total: frame.Total, parts: make(map[int][]byte), started: a.now()}
+		a.groups[frame.GroupID] = group
+	}
+	if group.total != frame.Total {
+		delete(a.groups, frame.GroupID)
+		return nil, false, fmt.Errorf("chunk group %s: total changed from %d to %d: %w",
+			frame.GroupID, group.total, frame.Total, ErrInvalidChunk)
+	}
+
+	group.parts[frame.Index] = frame.Data
+	if len(group.parts) < group.total {
+		return nil, false, nil
+	}
+
+	delete(a.groups, frame.GroupID)
+	var buf bytes.Buffer
+	for i := 0; i < group.total; i++ {
+		buf.Write(group.parts[i])
+	}
+	return buf.Bytes(), true, nil
+}
+
+// expire drops groups whose first chunk arrived more than the timeout ago
+// and returns an error for each.
+func (a *chunkAssembler) expire() []error {
+	if a.timeout <= 0 {
+		return nil
+	}
+	now := a.now()
+	return a.drop(func(group *chunkGroup) bool {
+		return now.Sub(group.started) > a.timeout
+	})
+}
+
+// flush drops every incomplete group and returns an error for each. Used
+// when a bounded replay ends, since no further chunks can arrive.
+func (a *chunkAssembler) flush() []error {
+	return a.drop(func(*chunkGroup) bool { return true })
+}
+
+func (a *chunkAssembler) drop(match func(*chunkGroup) bool) []error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var ids []string
+	for id, group := range a.groups {
+		if match(group) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	errs := make([]error, 0, len(ids))
+	for _, id := range ids {
+		group := a.groups[id]
+		delete(a.groups, id)
+		errs = append(errs, fmt.Errorf("chunk group %s: received %d of %d chunks: %w",
+			id, len(group.parts), group.total, ErrChunkTimeout))
+	}
+	return errs
+}
+
+// parseChunkFrame reports whether contents is a chunk frame rather than an envelope.
+func parseChunkFrame(contents []byte) (chunkFrame, bool) {
+	if !bytes.Contains(contents, []byte(`"chunk_group"`)) {
+		return chunkFrame{}, false
+	}
+	var frame chunkFrame
+	if err := json.Unmarshal(contents, &frame); err != nil || frame.GroupID == "" {
+		return chunkFrame{}, false
+	}
+	return frame, true
+}
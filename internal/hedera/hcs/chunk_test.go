@@ -0,0 +1,203 @@
+package hcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+func largeEnvelope(t *testing.T, size int) (Envelope, []byte) {
+	t.Helper()
+	output, err := json.Marshal(map[string]string{"output": strings.Repeat("x", size)})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	env := Envelope{
+		Type:        MessageTypeTaskResult,
+		Sender:      "inference-001",
+		TaskID:      "task-1",
+		SequenceNum: 1,
+		Timestamp:   time.Date(2026, 2, 18, 14, 0, 0, 0, time.UTC),
+		Payload:     output,
+	}
+	data, err := env.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	return env, data
+}
+
+func TestSplitMessage(t *testing.T) {
+	_, data := largeEnvelope(t, 5000)
+
+	tests := []struct {
+		name       string
+		data       []byte
+		maxSize    int
+		wantFrames int
+	}{
+		{name: "fits in one message", data: []byte(`{"type":"heartbeat"}`), maxSize: 1024, wantFrames: 1},
+		{name: "chunked", data: data, maxSize: 1024, wantFrames: (len(data) + 671) / 672},
+		{name: "zero max size uses default", data: data, maxSize: 0, wantFrames: (len(data) + 671) / 672},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frames, err := splitMessage(tt.data, tt.maxSize)
+			if err != nil {
+				t.Fatalf("splitMessage: %v", err)
+			}
+			if len(frames) != tt.wantFrames {
+				t.Fatalf("frames = %d, want %d", len(frames), tt.wantFrames)
+			}
+			for i, frame := range frames {
+				if len(frame) > defaultMaxMessageSize {
+					t.Errorf("frame %d is %d bytes, limit %d", i, len(frame), defaultMaxMessageSize)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitMessage_TooManyChunks(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 300*maxChunkTotal)
+	if _, err := splitMessage(data, 256); err == nil {
+		t.Fatal("expected error when message needs more than maxChunkTotal chunks")
+	}
+}
+
+func TestChunkAssembler_ReassemblesOutOfOrder(t *testing.T) {
+	_, data := largeEnvelope(t, 3000)
+	frames, err := splitMessage(data, 1024)
+	if err != nil {
+		t.Fatalf("splitMessage: %v", err)
+	}
+
+	a := newChunkAssembler(time.Minute)
+	var got []byte
+	for i := len(frames) - 1; i >= 0; i-- {
+		out, complete, err := a.add(frames[i])
+		if err != nil {
+			t.Fatalf("add chunk %d: %v", i, err)
+		}
+		if complete != (i == 0) {
+			t.Fatalf("chunk %d: complete = %v", i, complete)
+		}
+		got = out
+	}
+
+	if !bytes.Equal(got, data) {
+		t.Error("reassembled message differs from original")
+	}
+	if len(a.groups) != 0 {
+		t.Errorf("pending groups = %d, want 0", len(a.groups))
+	}
+}
+
+func TestChunkAssembler_PassesThroughEnvelopes(t *testing.T) {
+	// A payload containing a chunk_group key must not be mistaken for a frame.
+	data := []byte(`{"type":"task_result","sender":"a","payload":{"chunk_group":"g"}}`)
+	out, complete, err := newChunkAssembler(time.Minute).add(data)
+	if err != nil || !complete || !bytes.Equal(out, data) {
+		t.Fatalf("add = (%q, %v, %v), want passthrough", out, complete, err)
+	}
+}
+
+func TestChunkAssembler_InvalidFrames(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []chunkFrame
+	}{
+		{name: "index out of range", frames: []chunkFrame{{GroupID: "g", Index: 2, Total: 2}}},
+		{name: "zero total", frames: []chunkFrame{{GroupID: "g", Index: 0, Total: 0}}},
+		{name: "total changes", frames: []chunkFrame{
+			{GroupID: "g", Index: 0, Total: 3},
+			{GroupID: "g", Index: 1, Total: 2},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newChunkAssembler(time.Minute)
+			var lastErr error
+			for _, frame := range tt.frames {
+				data, err := json.Marshal(frame)
+				if err != nil {
+					t.Fatalf("marshal frame: %v", err)
+				}
+				_, _, lastErr = a.add(data)
+			}
+			if !errors.Is(lastErr, ErrInvalidChunk) {
+				t.Errorf("error = %v, want ErrInvalidChunk", lastErr)
+			}
+		})
+	}
+}
+
+func TestChunkAssembler_ExpiresIncompleteGroups(t *testing.T) {
+	_, data := largeEnvelope(t, 3000)
+	frames, err := splitMessage(data, 1024)
+	if err != nil {
+		t.Fatalf("splitMessage: %v", err)
+	}
+
+	now := time.Date(2026, 2, 18, 14, 0, 0, 0, time.UTC)
+	a := newChunkAssembler(time.Minute)
+	a.now = func() time.Time { return now }
+
+	if _, complete, err := a.add(frames[0]); err != nil || complete {
+		t.Fatalf("add first chunk: complete=%v err=%v", complete, err)
+	}
+
+	now = now.Add(30 * time.Second)
+	if errs := a.expire(); len(errs) != 0 {
+		t.Fatalf("expired early: %v", errs)
+	}
+
+	now = now.Add(31 * time.Second)
+	errs := a.expire()
+	if len(errs) != 1 || !errors.Is(errs[0], ErrChunkTimeout) {
+		t.Fatalf("expire errors = %v, want one ErrChunkTimeout", errs)
+	}
+	if len(a.groups) != 0 {
+		t.Errorf("pending groups = %d after expiry, want 0", len(a.groups))
+	}
+}
+
+func TestDeliverMessage_ReassemblesChunks(t *testing.T) {
+	env, data := largeEnvelope(t, 3000)
+	frames, err := splitMessage(data, 1024)
+	if err != nil {
+		t.Fatalf("splitMessage: %v", err)
+	}
+
+	pos := newStreamPosition(ReplayOptions{})
+	chunks := newChunkAssembler(time.Minute)
+	msgCh := make(chan Envelope, 1)
+	errCh := make(chan error, 1)
+
+	for i, frame := range frames {
+		deliverMessage(context.Background(), hiero.TopicID{}, pos, chunks,
+			hiero.TopicMessage{SequenceNumber: uint64(i + 1), Contents: frame}, msgCh, errCh)
+		if i < len(frames)-1 && len(msgCh) != 0 {
+			t.Fatalf("envelope delivered after chunk %d of %d", i+1, len(frames))
+		}
+	}
+
+	if len(errCh) != 0 {
+		t.Fatalf("unexpected error: %v", <-errCh)
+	}
+	if len(msgCh) != 1 {
+		t.Fatal("expected reassembled envelope")
+	}
+	got := <-msgCh
+	if got.TaskID != env.TaskID || !bytes.Equal(got.Payload, env.Payload) {
+		t.Errorf("reassembled envelope = %+v, want %+v", got, env)
+	}
+}
@@ -36,6 +36,23 @@ type MessageSubscriber interface {
 	Subscribe(ctx context.Context, topicID hiero.TopicID) (<-chan Envelope, <-chan error)
 }
 
+// PositionedSubscriber starts live subscriptions from a given position in
+// the topic instead of the subscriber's default starting point.
+type PositionedSubscriber interface {
+	// SubscribeFrom delivers the topic's messages from the starting point in
+	// opts onwards until the context is cancelled. opts.EndTime is ignored.
+	SubscribeFrom(ctx context.Context, topicID hiero.TopicID, opts ReplayOptions) (<-chan Envelope, <-chan error)
+}
+
+// MessageReplayer replays historical messages from an HCS topic.
+// Used to rebuild coordinator state from the topic record.
+type MessageReplayer interface {
+	// Replay delivers the topic's messages from the starting point in opts up
+	// to opts.EndTime, in consensus order. Both channels are closed once the
+	// end is reached or the context is cancelled.
+	Replay(ctx context.Context, topicID hiero.TopicID, opts ReplayOptions) (<-chan Envelope, <-chan error)
+}
+
 // TopicMetadata holds information about an HCS topic.
 type TopicMetadata struct {
 	TopicID        hiero.TopicID
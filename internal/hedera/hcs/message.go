@@ -41,6 +41,18 @@ const (
 
 	// MessageTypeFestivalProgress is sent when the coordinator publishes fest-derived progress.
 	MessageTypeFestivalProgress MessageType = "festival_progress"
+
+	// MessageTypeProtocolViolation is sent by the coordinator when it rejects an agent message.
+	MessageTypeProtocolViolation MessageType = "protocol_violation"
+
+	// MessageTypeTaskRevoked is sent by the coordinator when it takes a task back from an agent.
+	MessageTypeTaskRevoked MessageType = "task_revoked"
+
+	// MessageTypeOutputRejected is sent by the coordinator when a task result fails output validation.
+	MessageTypeOutputRejected MessageType = "output_rejected"
+
+	// MessageTypePaymentEscrowed is sent by the coordinator when a task's payment is committed in a scheduled transfer.
+	MessageTypePaymentEscrowed MessageType = "payment_escrowed"
 )
 
 // Envelope is the standard message format for all festival protocol messages
@@ -66,6 +78,21 @@ type Envelope struct {
 
 	// Payload contains the type-specific message data as raw JSON.
 	Payload json.RawMessage `json:"payload,omitempty"`
+
+	// SignerAccount is the Hedera account whose key signed this envelope (if signed).
+	SignerAccount string `json:"signer_account,omitempty"`
+
+	// PublicKey is the DER-encoded public key the signature verifies against (if signed).
+	PublicKey string `json:"public_key,omitempty"`
+
+	// Signature is the hex-encoded signature over SigningBytes (if signed).
+	Signature string `json:"signature,omitempty"`
+
+	// ConsensusTimestamp and TopicSequenceNum locate the HCS message the
+	// envelope was received in. Subscribers set them on delivery; they are
+	// not part of the wire format.
+	ConsensusTimestamp time.Time `json:"-"`
+	TopicSequenceNum   uint64    `json:"-"`
 }
 
 // Marshal serializes the envelope to JSON bytes for publishing to HCS.
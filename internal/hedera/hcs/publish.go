@@ -19,14 +19,19 @@ type PublishConfig struct {
 	MaxRetries  int
 	BaseBackoff time.Duration
 	MaxBackoff  time.Duration
+
+	// MaxMessageSize is the largest message submitted in one transaction.
+	// Larger envelopes are split into chunk frames that Subscriber reassembles.
+	MaxMessageSize int
 }
 
 // DefaultPublishConfig returns sensible defaults for testnet usage.
 func DefaultPublishConfig() PublishConfig {
 	return PublishConfig{
-		MaxRetries:  defaultMaxRetries,
-		BaseBackoff: defaultBaseBackoff,
-		MaxBackoff:  defaultMaxBackoff,
+		MaxRetries:     defaultMaxRetries,
+		BaseBackoff:    defaultBaseBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+		MaxMessageSize: defaultMaxMessageSize,
 	}
 }
 
@@ -45,7 +50,8 @@ func NewPublisher(client *hiero.Client, config PublishConfig) *Publisher {
 }
 
 // Publish serializes the envelope to JSON and submits it to an HCS topic.
-// Retries transient failures with exponential backoff.
+// Envelopes larger than MaxMessageSize are submitted as a group of chunk
+// frames in order. Retries transient failures with exponential backoff.
 func (p *Publisher) Publish(ctx context.Context, topicID hiero.TopicID, msg Envelope) error {
 	if err := ctx.Err(); err != nil {
 		return fmt.Errorf("publish to topic %s: %w", topicID, err)
@@ -56,10 +62,29 @@ func (p *Publisher) Publish(ctx context.Context, topicID hiero.TopicID, msg Enve
 		return fmt.Errorf("publish to topic %s: marshal type %s: %w", topicID, msg.Type, err)
 	}
 
+	frames, err := splitMessage(data, p.config.MaxMessageSize)
+	if err != nil {
+		return fmt.Errorf("publish to topic %s type %s: %w", topicID, msg.Type, err)
+	}
+
+	for i, frame := range frames {
+		if err := p.submitWithRetry(ctx, topicID, frame); err != nil {
+			if len(frames) > 1 {
+				return fmt.Errorf("publish to topic %s type %s chunk %d of %d: %w",
+					topicID, msg.Type, i+1, len(frames), err)
+			}
+			return fmt.Errorf("publish to topic %s type %s: %w", topicID, msg.Type, err)
+		}
+	}
+	return nil
+}
+
+// submitWithRetry submits one message, retrying with exponential backoff.
+func (p *Publisher) submitWithRetry(ctx context.Context, topicID hiero.TopicID, data []byte) error {
 	var lastErr error
 	for attempt := 0; attempt <= p.config.MaxRetries; attempt++ {
 		if err := ctx.Err(); err != nil {
-			return fmt.Errorf("publish to topic %s attempt %d: %w", topicID, attempt+1, err)
+			return fmt.Errorf("attempt %d: %w", attempt+1, err)
 		}
 
 		lastErr = p.submitMessage(topicID, data)
@@ -71,14 +96,13 @@ func (p *Publisher) Publish(ctx context.Context, topicID hiero.TopicID, msg Enve
 			backoff := p.calculateBackoff(attempt)
 			select {
 			case <-ctx.Done():
-				return fmt.Errorf("publish to topic %s: cancelled during backoff: %w", topicID, ctx.Err())
+				return fmt.Errorf("cancelled during backoff: %w", ctx.Err())
 			case <-time.After(backoff):
 			}
 		}
 	}
 
-	return fmt.Errorf("publish to topic %s type %s: exhausted %d attempts: %w",
-		topicID, msg.Type, p.config.MaxRetries+1, lastErr)
+	return fmt.Errorf("exhausted %d attempts: %w", p.config.MaxRetries+1, lastErr)
 }
 
 func (p *Publisher) submitMessage(topicID hiero.TopicID, data []byte) error {
@@ -148,6 +148,9 @@ func TestPublishConfig_Defaults(t *testing.T) {
 	if cfg.MaxBackoff != 5*time.Second {
 		t.Errorf("MaxBackoff = %v, want 5s", cfg.MaxBackoff)
 	}
+	if cfg.MaxMessageSize != 1024 {
+		t.Errorf("MaxMessageSize = %d, want 1024", cfg.MaxMessageSize)
+	}
 }
 
 func TestPublisher_CalculateBackoff(t *testing.T) {
@@ -0,0 +1,392 @@
+package hcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+const defaultRouteBuffer = 100
+
+// ErrBackpressure is wrapped by the errors a Router reports when a route's
+// buffer is full and delivery on its topic is stalled.
+var ErrBackpressure = errors.New("route buffer full")
+
+// BackpressureError reports that a route fell behind: its buffer filled up
+// and the topic's subscription is blocked until the route catches up.
+type BackpressureError struct {
+	TopicID hiero.TopicID
+	Route   string
+	Buffer  int
+}
+
+func (e *BackpressureError) Error() string {
+	return fmt.Sprintf("route %s on topic %s: %d messages queued: %v", e.Route, e.TopicID, e.Buffer, ErrBackpressure)
+}
+
+func (e *BackpressureError) Unwrap() error {
+	return ErrBackpressure
+}
+
+// Route selects the envelopes delivered to one consumer of a topic.
+type Route struct {
+	// Name identifies the route in stats and backpressure errors.
+	Name string
+
+	// Types lists the message types delivered. Empty means every type.
+	Types []MessageType
+
+	// Recipient, when set, delivers only envelopes addressed to it or
+	// broadcast with an empty recipient.
+	Recipient string
+}
+
+// matches reports whether an envelope is selected by the route.
+func (r Route) matches(env Envelope) bool {
+	if r.Recipient != "" && env.Recipient != "" && env.Recipient != r.Recipient {
+		return false
+	}
+	if len(r.Types) == 0 {
+		return true
+	}
+	for _, t := range r.Types {
+		if env.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// RouterConfig holds configuration for a Router.
+type RouterConfig struct {
+	// RouteBuffer is how many envelopes each route queues before delivery
+	// on its topic blocks.
+	RouteBuffer int
+}
+
+// DefaultRouterConfig returns sensible defaults.
+func DefaultRouterConfig() RouterConfig {
+	return RouterConfig{RouteBuffer: defaultRouteBuffer}
+}
+
+// RouteStats reports delivery counters for one route.
+type RouteStats struct {
+	Name      string
+	TopicID   hiero.TopicID
+	Delivered uint64
+	Stalls    uint64 // times the route's buffer was full
+	Queued    int
+}
+
+// Router holds one subscription per topic and fans each envelope out to the
+// routes registered for it, so any number of consumers share a single stream
+// and every message is decoded once. Each route has its own buffer; a route
+// that falls behind reports a BackpressureError on its error channel and
+// stalls its topic until it catches up.
+type Router struct {
+	subscriber MessageSubscriber
+	config     RouterConfig
+
+	mu      sync.Mutex
+	ctx     context.Context // set by Start
+	topics  map[hiero.TopicID]*routedTopic
+	handles []*handledRoute
+}
+
+// routedTopic is the shared subscription for one topic.
+type routedTopic struct {
+	id     hiero.TopicID
+	routes []*route
+	open   bool
+	ended  bool
+}
+
+// route is one registered consumer of a topic.
+type route struct {
+	Route
+	topicID hiero.TopicID
+	msgCh   chan Envelope
+	errCh   chan error
+	left    chan struct{} // closed when the consumer stops reading
+
+	mu        sync.Mutex
+	taken     bool
+	delivered uint64
+	stalls    uint64
+}
+
+// handledRoute is a route drained by a handler function.
+type handledRoute struct {
+	route   *route
+	handler func(context.Context, Envelope)
+	running bool
+}
+
+// NewRouter creates a router over the given subscriber.
+func NewRouter(subscriber MessageSubscriber, config RouterConfig) *Router {
+	if config.RouteBuffer <= 0 {
+		config.RouteBuffer = defaultRouteBuffer
+	}
+	return &Router{
+		subscriber: subscriber,
+		config:     config,
+		topics:     make(map[hiero.TopicID]*routedTopic),
+	}
+}
+
+// Route registers a route on a topic and returns a MessageSubscriber that
+// delivers its envelopes. Envelopes are queued from the moment the router
+// starts, so a consumer that subscribes later misses nothing up to the
+// buffer size. The returned subscriber serves one Subscribe call for topicID.
+func (r *Router) Route(topicID hiero.TopicID, rt Route) MessageSubscriber {
+	return r.addRoute(topicID, rt)
+}
+
+// Handle registers a route on a topic whose envelopes are passed, in order,
+// to handler on a goroutine of its own while the router runs.
+func (r *Router) Handle(topicID hiero.TopicID, rt Route, handler func(context.Context, Envelope)) {
+	h := &handledRoute{route: r.addRoute(topicID, rt), handler: handler}
+	h.route.taken = true
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handles = append(r.handles, h)
+	if r.ctx != nil {
+		r.runHandler(r.ctx, h)
+	}
+}
+
+// Start opens one subscription per routed topic and dispatches envelopes
+// until the context is cancelled. Topics first routed after Start are opened
+// when their first route is registered.
+func (r *Router) Start(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("router start: %w", err)
+	}
+
+	r.mu.Lock()
+	if r.ctx != nil {
+		r.mu.Unlock()
+		return fmt.Errorf("router start: already started")
+	}
+	r.ctx = ctx
+	for _, topic := range r.topics {
+		r.openTopic(ctx, topic)
+	}
+	for _, h := range r.handles {
+		r.runHandler(ctx, h)
+	}
+	r.mu.Unlock()
+
+	<-ctx.Done()
+	return nil
+}
+
+// Stats returns delivery counters for every route.
+func (r *Router) Stats() []RouteStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var stats []RouteStats
+	for _, topic := range r.topics {
+		for _, rt := range topic.routes {
+			rt.mu.Lock()
+			stats = append(stats, RouteStats{
+				Name:      rt.Name,
+				TopicID:   rt.topicID,
+				Delivered: rt.delivered,
+				Stalls:    rt.stalls,
+				Queued:    len(rt.msgCh),
+			})
+			rt.mu.Unlock()
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+func (r *Router) addRoute(topicID hiero.TopicID, rt Route) *route {
+	if rt.Name == "" {
+		rt.Name = fmt.Sprintf("%v", rt.Types)
+	}
+	added := &route{
+		Route:   rt,
+		topicID: topicID,
+		msgCh:   make(chan Envelope, r.config.RouteBuffer),
+		errCh:   make(chan error, r.config.RouteBuffer),
+		left:    make(chan struct{}),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	topic, ok := r.topics[topicID]
+	if !ok {
+		topic = &routedTopic{id: topicID}
+		r.topics[topicID] = topic
+	}
+	if topic.ended {
+		close(added.msgCh)
+		close(added.errCh)
+		return added
+	}
+	topic.routes = append(topic.routes, added)
+	if r.ctx != nil {
+		r.openTopic(r.ctx, topic)
+	}
+	return added
+}
+
+// openTopic starts the topic's subscription if it is not running. r.mu must
+// be held.
+func (r *Router) openTopic(ctx context.Context, topic *routedTopic) {
+	if topic.open {
+		return
+	}
+	topic.open = true
+	msgCh, errCh := r.subscriber.Subscribe(ctx, topic.id)
+	go r.dispatch(ctx, topic, msgCh, errCh)
+}
+
+// runHandler starts draining a handled route. r.mu must be held.
+func (r *Router) runHandler(ctx context.Context, h *handledRoute) {
+	if h.running {
+		return
+	}
+	h.running = true
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case env, ok := <-h.route.msgCh:
+				if !ok {
+					return
+				}
+				h.handler(ctx, env)
+			}
+		}
+	}()
+}
+
+// dispatch fans a topic's envelopes and errors out to its routes until the
+// subscription ends, then closes every route's channels.
+func (r *Router) dispatch(ctx context.Context, topic *routedTopic, msgCh <-chan Envelope, errCh <-chan error) {
+	defer func() {
+		r.mu.Lock()
+		topic.ended = true
+		routes := topic.routes
+		r.mu.Unlock()
+		for _, rt := range routes {
+			close(rt.msgCh)
+			close(rt.errCh)
+		}
+	}()
+
+	for msgCh != nil || errCh != nil {
+		select {
+		case <-ctx.Done():
+			return
+		case env, ok := <-msgCh:
+			if !ok {
+				msgCh = nil
+				continue
+			}
+			for _, rt := range r.routesOf(topic) {
+				if rt.matches(env) {
+					rt.deliver(ctx, env)
+				}
+			}
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil // prevent spin on closed channel
+				continue
+			}
+			for _, rt := range r.routesOf(topic) {
+				rt.report(err)
+			}
+		}
+	}
+}
+
+func (r *Router) routesOf(topic *routedTopic) []*route {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return topic.routes
+}
+
+// deliver queues an envelope for the route, reporting backpressure and
+// waiting for room if its buffer is full. Envelopes for a consumer that has
+// stopped reading are dropped.
+func (rt *route) deliver(ctx context.Context, env Envelope) {
+	select {
+	case rt.msgCh <- env:
+		rt.count(false)
+		return
+	case <-rt.left:
+		return
+	default:
+	}
+
+	rt.count(true)
+	rt.report(&BackpressureError{TopicID: rt.topicID, Route: rt.Name, Buffer: cap(rt.msgCh)})
+	select {
+	case rt.msgCh <- env:
+		rt.count(false)
+	case <-rt.left:
+	case <-ctx.Done():
+	}
+}
+
+func (rt *route) count(stalled bool) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if stalled {
+		rt.stalls++
+	} else {
+		rt.delivered++
+	}
+}
+
+// report passes an error to the route without blocking.
+func (rt *route) report(err error) {
+	select {
+	case rt.errCh <- err:
+	case <-rt.left:
+	default:
+	}
+}
+
+// Subscribe implements MessageSubscriber for the route's topic. The route is
+// released when ctx is cancelled.
+func (rt *route) Subscribe(ctx context.Context, topicID hiero.TopicID) (<-chan Envelope, <-chan error) {
+	rt.mu.Lock()
+	taken := rt.taken
+	rt.taken = true
+	rt.mu.Unlock()
+
+	if topicID != rt.topicID || taken {
+		msgCh := make(chan Envelope)
+		errCh := make(chan error, 1)
+		if taken {
+			errCh <- fmt.Errorf("subscribe to route %s: already subscribed", rt.Name)
+		} else {
+			errCh <- fmt.Errorf("subscribe to route %s: routed topic is %s, not %s", rt.Name, rt.topicID, topicID)
+		}
+		close(msgCh)
+		close(errCh)
+		return msgCh, errCh
+	}
+
+	go func() {
+		<-ctx.Done()
+		close(rt.left)
+	}()
+	return rt.msgCh, rt.errCh
+}
+
+// Compile-time interface compliance check.
+var _ MessageSubscriber = (*route)(nil)
@@ -0,0 +1,150 @@
+package hcs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+// feedSubscriber hands out one feed per Subscribe call and counts them.
+type feedSubscriber struct {
+	mu    sync.Mutex
+	calls int
+	msgCh chan Envelope
+	errCh chan error
+}
+
+func newFeedSubscriber() *feedSubscriber {
+	return &feedSubscriber{msgCh: make(chan Envelope), errCh: make(chan error, 1)}
+}
+
+func (s *feedSubscriber) Subscribe(_ context.Context, _ hiero.TopicID) (<-chan Envelope, <-chan error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	return s.msgCh, s.errCh
+}
+
+func (s *feedSubscriber) subscriptions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func receive(t *testing.T, ch <-chan Envelope) Envelope {
+	t.Helper()
+	select {
+	case env := <-ch:
+		return env
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for envelope")
+		return Envelope{}
+	}
+}
+
+func TestRouter_SharesOneSubscriptionPerTopic(t *testing.T) {
+	feed := newFeedSubscriber()
+	router := NewRouter(feed, DefaultRouterConfig())
+	topic := hiero.TopicID{Topic: 7}
+
+	status := router.Route(topic, Route{Name: "monitor", Types: []MessageType{MessageTypeStatusUpdate}})
+	results := router.Route(topic, Route{Name: "results", Types: []MessageType{MessageTypeTaskResult}})
+	mine := router.Route(topic, Route{Name: "agent-1", Recipient: "agent-1"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go router.Start(ctx)
+
+	statusCh, _ := status.Subscribe(ctx, topic)
+	resultCh, _ := results.Subscribe(ctx, topic)
+	mineCh, _ := mine.Subscribe(ctx, topic)
+
+	feed.msgCh <- Envelope{Type: MessageTypeStatusUpdate, TaskID: "task-1"}
+	feed.msgCh <- Envelope{Type: MessageTypeTaskResult, TaskID: "task-1", Recipient: "agent-2"}
+	feed.msgCh <- Envelope{Type: MessageTypeTaskResult, TaskID: "task-2", Recipient: "agent-1"}
+
+	if env := receive(t, statusCh); env.Type != MessageTypeStatusUpdate {
+		t.Errorf("monitor route got %s", env.Type)
+	}
+	if env := receive(t, resultCh); env.TaskID != "task-1" {
+		t.Errorf("results route got %s first, want task-1", env.TaskID)
+	}
+	if env := receive(t, resultCh); env.TaskID != "task-2" {
+		t.Errorf("results route got %s second, want task-2", env.TaskID)
+	}
+	// Broadcasts and envelopes addressed to agent-1 only.
+	if env := receive(t, mineCh); env.Type != MessageTypeStatusUpdate {
+		t.Errorf("recipient route got %+v first, want the broadcast status update", env)
+	}
+	if env := receive(t, mineCh); env.TaskID != "task-2" {
+		t.Errorf("recipient route got %+v second, want task-2", env)
+	}
+
+	if n := feed.subscriptions(); n != 1 {
+		t.Errorf("subscriptions = %d, want 1", n)
+	}
+	if _, errCh := status.Subscribe(ctx, topic); errCh == nil || <-errCh == nil {
+		t.Error("second Subscribe on a route: expected error")
+	}
+}
+
+func TestRouter_ReportsBackpressure(t *testing.T) {
+	feed := newFeedSubscriber()
+	router := NewRouter(feed, RouterConfig{RouteBuffer: 1})
+	topic := hiero.TopicID{Topic: 7}
+	slow := router.Route(topic, Route{Name: "slow"})
+
+	var handled []string
+	var mu sync.Mutex
+	router.Handle(topic, Route{Name: "fast"}, func(_ context.Context, env Envelope) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled = append(handled, env.TaskID)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go router.Start(ctx)
+	msgCh, errCh := slow.Subscribe(ctx, topic)
+
+	feed.msgCh <- Envelope{TaskID: "task-1"} // fills the slow route's buffer
+	feed.msgCh <- Envelope{TaskID: "task-2"} // stalls until the slow route reads
+
+	var bp *BackpressureError
+	select {
+	case err := <-errCh:
+		if !errors.As(err, &bp) || bp.Route != "slow" || !errors.Is(err, ErrBackpressure) {
+			t.Fatalf("error = %v, want backpressure on slow", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no backpressure reported")
+	}
+
+	receive(t, msgCh)
+	receive(t, msgCh)
+
+	// The handled route saw both envelopes despite the slow route.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		n := len(handled)
+		mu.Unlock()
+		if n == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("handled %d envelopes, want 2", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	for _, stat := range router.Stats() {
+		if stat.Name == "slow" && stat.Stalls != 1 {
+			t.Errorf("slow stats = %+v, want 1 stall", stat)
+		}
+	}
+}
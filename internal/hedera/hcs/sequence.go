@@ -0,0 +1,255 @@
+package hcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+var (
+	// ErrSequenceGap is wrapped by a SequenceError when a sender skipped
+	// sequence numbers.
+	ErrSequenceGap = errors.New("sequence gap")
+
+	// ErrSequenceReorder is wrapped by a SequenceError when a sender's
+	// sequence number did not advance.
+	ErrSequenceReorder = errors.New("sequence reorder")
+)
+
+// SequenceError reports an envelope whose per-sender SequenceNum is not the
+// one after the last seen from that sender on the topic. The envelope is
+// still delivered.
+type SequenceError struct {
+	TopicID  hiero.TopicID
+	Sender   string
+	Expected uint64
+	Got      uint64
+}
+
+func (e *SequenceError) Error() string {
+	return fmt.Sprintf("sender %s on topic %s: %v: expected sequence %d, got %d",
+		e.Sender, e.TopicID, e.Unwrap(), e.Expected, e.Got)
+}
+
+// Unwrap returns ErrSequenceGap or ErrSequenceReorder.
+func (e *SequenceError) Unwrap() error {
+	if e.Got > e.Expected {
+		return ErrSequenceGap
+	}
+	return ErrSequenceReorder
+}
+
+// Checkpoint is the position of the last message delivered from a topic.
+type Checkpoint struct {
+	ConsensusTimestamp time.Time
+	SequenceNumber     uint64
+}
+
+// senderKey identifies a sender's sequence on one topic.
+type senderKey struct {
+	topic  hiero.TopicID
+	sender string
+}
+
+// SequencedSubscriber wraps a MessageSubscriber so each topic message is
+// delivered once. It records a checkpoint per topic as messages are
+// delivered; a later Subscribe to the same topic resumes just after the
+// checkpoint when the inner subscriber is a PositionedSubscriber, and
+// messages at or before the checkpoint are dropped as duplicates. Per-sender
+// SequenceNum gaps and reorders are reported as *SequenceError.
+//
+// Because each message is delivered once per topic, not once per
+// subscription, a topic should have one live subscription at a time, as under
+// a Router.
+type SequencedSubscriber struct {
+	inner MessageSubscriber
+
+	mu          sync.Mutex
+	checkpoints map[hiero.TopicID]Checkpoint
+	senders     map[senderKey]uint64 // last SequenceNum seen
+}
+
+// NewSequencedSubscriber creates a subscriber that deduplicates and
+// sequence-checks envelopes from inner.
+func NewSequencedSubscriber(inner MessageSubscriber) *SequencedSubscriber {
+	return &SequencedSubscriber{
+		inner:       inner,
+		checkpoints: make(map[hiero.TopicID]Checkpoint),
+		senders:     make(map[senderKey]uint64),
+	}
+}
+
+// Checkpoint returns the position of the last message delivered from a topic.
+func (s *SequencedSubscriber) Checkpoint(topicID hiero.TopicID) (Checkpoint, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp, ok := s.checkpoints[topicID]
+	return cp, ok
+}
+
+// SetCheckpoint sets the position subscriptions to a topic resume after,
+// such as one saved before a restart.
+func (s *SequencedSubscriber) SetCheckpoint(topicID hiero.TopicID, cp Checkpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[topicID] = cp
+}
+
+// Subscribe starts a subscription on the inner subscriber, resuming after
+// the topic's checkpoint if there is one.
+func (s *SequencedSubscriber) Subscribe(ctx context.Context, topicID hiero.TopicID) (<-chan Envelope, <-chan error) {
+	var in <-chan Envelope
+	var inErr <-chan error
+	cp, ok := s.Checkpoint(topicID)
+	if positioned, canResume := s.inner.(PositionedSubscriber); ok && canResume {
+		in, inErr = positioned.SubscribeFrom(ctx, topicID, ReplayOptions{
+			StartTime:     cp.ConsensusTimestamp.Add(time.Nanosecond),
+			StartSequence: cp.SequenceNumber + 1,
+		})
+	} else {
+		in, inErr = s.inner.Subscribe(ctx, topicID)
+	}
+
+	out := make(chan Envelope, cap(in))
+	outErr := make(chan error, cap(inErr)+1)
+
+	report := func(err error) {
+		select {
+		case outErr <- err:
+		default:
+		}
+	}
+
+	go func() {
+		defer close(out)
+		defer close(outErr)
+
+		for in != nil || inErr != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case err, ok := <-inErr:
+				if !ok {
+					inErr = nil
+					continue
+				}
+				report(err)
+			case env, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				deliver, err := s.check(topicID, env)
+				if err != nil {
+					report(err)
+				}
+				if !deliver {
+					continue
+				}
+				select {
+				case out <- env:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, outErr
+}
+
+// check advances the topic checkpoint and the sender's sequence. It reports
+// false for a message already delivered, and a *SequenceError when the
+// sender's sequence did not advance by one.
+func (s *SequencedSubscriber) check(topicID hiero.TopicID, env Envelope) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if env.TopicSequenceNum != 0 {
+		cp, ok := s.checkpoints[topicID]
+		if ok && env.TopicSequenceNum <= cp.SequenceNumber {
+			return false, nil
+		}
+		s.checkpoints[topicID] = Checkpoint{
+			ConsensusTimestamp: env.ConsensusTimestamp,
+			SequenceNumber:     env.TopicSequenceNum,
+		}
+	}
+
+	if env.Sender == "" || env.SequenceNum == 0 {
+		return true, nil
+	}
+	key := senderKey{topic: topicID, sender: env.Sender}
+	last, seen := s.senders[key]
+	if env.SequenceNum > last {
+		s.senders[key] = env.SequenceNum
+	}
+	if seen && env.SequenceNum != last+1 {
+		return true, &SequenceError{TopicID: topicID, Sender: env.Sender, Expected: last + 1, Got: env.SequenceNum}
+	}
+	return true, nil
+}
+
+// SequencedPublisher wraps a MessagePublisher and numbers each envelope's
+// SequenceNum per sender and topic, overwriting the number set by the
+// caller. Components of one process that publish under the same sender
+// therefore form one gapless sequence. Publishes by one sender to one topic
+// are serialized, so numbers follow submission order, and a failed publish
+// does not use up its number.
+type SequencedPublisher struct {
+	inner MessagePublisher
+
+	mu    sync.Mutex
+	last  map[senderKey]uint64
+	locks map[senderKey]*sync.Mutex
+}
+
+// NewSequencedPublisher creates a publisher that numbers envelopes before
+// handing them to inner. Wrap it around a SigningPublisher so the number is
+// signed.
+func NewSequencedPublisher(inner MessagePublisher) *SequencedPublisher {
+	return &SequencedPublisher{
+		inner: inner,
+		last:  make(map[senderKey]uint64),
+		locks: make(map[senderKey]*sync.Mutex),
+	}
+}
+
+// Publish numbers the envelope and publishes it.
+func (p *SequencedPublisher) Publish(ctx context.Context, topicID hiero.TopicID, msg Envelope) error {
+	key := senderKey{topic: topicID, sender: msg.Sender}
+
+	p.mu.Lock()
+	lock, ok := p.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		p.locks[key] = lock
+	}
+	p.mu.Unlock()
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	p.mu.Lock()
+	msg.SequenceNum = p.last[key] + 1
+	p.mu.Unlock()
+
+	if err := p.inner.Publish(ctx, topicID, msg); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.last[key] = msg.SequenceNum
+	p.mu.Unlock()
+	return nil
+}
+
+// Compile-time interface compliance checks.
+var (
+	_ MessageSubscriber = (*SequencedSubscriber)(nil)
+	_ MessagePublisher  = (*SequencedPublisher)(nil)
+)
@@ -0,0 +1,179 @@
+package hcs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+// positionedFeed replays fixed envelopes, buffering errors like Subscriber,
+// and records where each subscription asked to start.
+type positionedFeed struct {
+	envelopes []Envelope
+	starts    []ReplayOptions
+}
+
+func (f *positionedFeed) Subscribe(ctx context.Context, topicID hiero.TopicID) (<-chan Envelope, <-chan error) {
+	return f.SubscribeFrom(ctx, topicID, ReplayOptions{})
+}
+
+func (f *positionedFeed) SubscribeFrom(_ context.Context, _ hiero.TopicID, opts ReplayOptions) (<-chan Envelope, <-chan error) {
+	f.starts = append(f.starts, opts)
+	msgCh := make(chan Envelope, len(f.envelopes))
+	errCh := make(chan error, len(f.envelopes))
+	for _, env := range f.envelopes {
+		msgCh <- env
+	}
+	close(msgCh)
+	close(errCh)
+	return msgCh, errCh
+}
+
+func drain(msgCh <-chan Envelope, errCh <-chan error) ([]Envelope, []error) {
+	var envs []Envelope
+	var errs []error
+	for msgCh != nil || errCh != nil {
+		select {
+		case env, ok := <-msgCh:
+			if !ok {
+				msgCh = nil
+				continue
+			}
+			envs = append(envs, env)
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+	return envs, errs
+}
+
+func TestSequencedSubscriber_DeliversOnceAndResumes(t *testing.T) {
+	base := time.Date(2026, 2, 18, 14, 0, 0, 0, time.UTC)
+	record := func(topicSeq, senderSeq uint64) Envelope {
+		return Envelope{
+			Type:               MessageTypeStatusUpdate,
+			Sender:             "agent-1",
+			SequenceNum:        senderSeq,
+			TopicSequenceNum:   topicSeq,
+			ConsensusTimestamp: base.Add(time.Duration(topicSeq) * time.Second),
+		}
+	}
+	feed := &positionedFeed{envelopes: []Envelope{record(1, 1), record(2, 2), record(2, 2), record(3, 3)}}
+	sub := NewSequencedSubscriber(feed)
+	topic := hiero.TopicID{Topic: 9}
+
+	envs, errs := drain(sub.Subscribe(context.Background(), topic))
+	if len(envs) != 3 || len(errs) != 0 {
+		t.Fatalf("delivered %d envelopes with errors %v, want 3 without errors", len(envs), errs)
+	}
+	cp, ok := sub.Checkpoint(topic)
+	if !ok || cp.SequenceNumber != 3 || !cp.ConsensusTimestamp.Equal(base.Add(3*time.Second)) {
+		t.Fatalf("checkpoint = %+v, %v, want sequence 3", cp, ok)
+	}
+
+	// A new subscription resumes after the checkpoint; anything the feed
+	// redelivers from before it is dropped.
+	envs, _ = drain(sub.Subscribe(context.Background(), topic))
+	if len(envs) != 0 {
+		t.Errorf("resubscribe delivered %d envelopes again", len(envs))
+	}
+	if len(feed.starts) != 2 {
+		t.Fatalf("subscriptions = %d, want 2", len(feed.starts))
+	}
+	resume := feed.starts[1]
+	if resume.StartSequence != 4 || !resume.StartTime.Equal(base.Add(3*time.Second+time.Nanosecond)) {
+		t.Errorf("resume = %+v, want just after sequence 3", resume)
+	}
+}
+
+func TestSequencedSubscriber_ReportsSenderGapsAndReorders(t *testing.T) {
+	feed := &positionedFeed{envelopes: []Envelope{
+		{Sender: "agent-1", SequenceNum: 1, TopicSequenceNum: 1},
+		{Sender: "agent-2", SequenceNum: 7, TopicSequenceNum: 2}, // first from agent-2: no expectation yet
+		{Sender: "agent-1", SequenceNum: 4, TopicSequenceNum: 3}, // skipped 2 and 3
+		{Sender: "agent-1", SequenceNum: 3, TopicSequenceNum: 4}, // arrives late
+		{Sender: "agent-2", SequenceNum: 8, TopicSequenceNum: 5},
+	}}
+	topic := hiero.TopicID{Topic: 9}
+
+	envs, errs := drain(NewSequencedSubscriber(feed).Subscribe(context.Background(), topic))
+	if len(envs) != 5 {
+		t.Errorf("delivered %d envelopes, want all 5", len(envs))
+	}
+	if len(errs) != 2 {
+		t.Fatalf("errors = %v, want a gap and a reorder", errs)
+	}
+
+	var gap, reorder *SequenceError
+	if !errors.As(errs[0], &gap) || !errors.Is(errs[0], ErrSequenceGap) {
+		t.Fatalf("first error = %v, want a sequence gap", errs[0])
+	}
+	if gap.Sender != "agent-1" || gap.Expected != 2 || gap.Got != 4 || gap.TopicID != topic {
+		t.Errorf("gap = %+v", gap)
+	}
+	if !errors.As(errs[1], &reorder) || !errors.Is(errs[1], ErrSequenceReorder) {
+		t.Fatalf("second error = %v, want a reorder", errs[1])
+	}
+	if reorder.Expected != 5 || reorder.Got != 3 {
+		t.Errorf("reorder = %+v", reorder)
+	}
+}
+
+// failingPublisher records envelopes and fails the publishes listed in fail,
+// counted from 1.
+type failingPublisher struct {
+	recordingPublisher
+	calls int
+	fail  map[int]bool
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, topicID hiero.TopicID, msg Envelope) error {
+	p.calls++
+	if p.fail[p.calls] {
+		return errors.New("publish failed")
+	}
+	return p.recordingPublisher.Publish(ctx, topicID, msg)
+}
+
+func TestSequencedPublisher_NumbersPerSenderAndTopic(t *testing.T) {
+	ctx := context.Background()
+	inner := &failingPublisher{fail: map[int]bool{3: true}}
+	p := NewSequencedPublisher(inner)
+	tasks, status := hiero.TopicID{Topic: 1}, hiero.TopicID{Topic: 2}
+
+	// Two components publish as the coordinator, each numbering from 1.
+	publish := []struct {
+		topic  hiero.TopicID
+		sender string
+	}{
+		{tasks, "coordinator"}, {tasks, "coordinator"}, {tasks, "coordinator"},
+		{status, "coordinator"}, {tasks, "agent-1"}, {tasks, "coordinator"},
+	}
+	for i, pub := range publish {
+		err := p.Publish(ctx, pub.topic, Envelope{Sender: pub.sender, SequenceNum: 1})
+		if (err != nil) != (i == 2) {
+			t.Fatalf("publish %d error = %v", i+1, err)
+		}
+	}
+
+	var got []uint64
+	for _, env := range inner.published {
+		got = append(got, env.SequenceNum)
+	}
+	want := []uint64{1, 2, 1, 1, 3}
+	if len(got) != len(want) {
+		t.Fatalf("published sequence numbers = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("published sequence numbers = %v, want %v", got, want)
+		}
+	}
+}
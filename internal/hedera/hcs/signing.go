@@ -0,0 +1,265 @@
+package hcs
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+var (
+	// ErrUnsigned is reported for envelopes without a signature.
+	ErrUnsigned = errors.New("envelope is not signed")
+
+	// ErrUnknownSigner is reported when no key is registered for the envelope sender.
+	ErrUnknownSigner = errors.New("no registered key for sender")
+
+	// ErrKeyMismatch is reported when the envelope's public key is not the sender's registered key.
+	ErrKeyMismatch = errors.New("envelope public key does not match registered key")
+
+	// ErrBadSignature is reported when the signature does not verify against the envelope.
+	ErrBadSignature = errors.New("envelope signature is invalid")
+)
+
+// VerificationError describes an envelope that failed signature verification.
+type VerificationError struct {
+	Sender string
+	Type   MessageType
+	TaskID string
+	Err    error
+}
+
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("verify %s envelope from %s (task %q): %v", e.Type, e.Sender, e.TaskID, e.Err)
+}
+
+func (e *VerificationError) Unwrap() error {
+	return e.Err
+}
+
+// SigningBytes returns the canonical bytes covered by the envelope signature:
+// the JSON encoding of the envelope with the Signature field cleared. The
+// signer account and public key are included so they cannot be swapped.
+func (e *Envelope) SigningBytes() ([]byte, error) {
+	unsigned := *e
+	unsigned.Signature = ""
+	return unsigned.Marshal()
+}
+
+// EnvelopeSigner signs envelopes with a Hedera account key.
+type EnvelopeSigner struct {
+	accountID hiero.AccountID
+	key       hiero.PrivateKey
+}
+
+// NewEnvelopeSigner creates a signer for the given account and private key.
+func NewEnvelopeSigner(accountID hiero.AccountID, key hiero.PrivateKey) *EnvelopeSigner {
+	return &EnvelopeSigner{accountID: accountID, key: key}
+}
+
+// Sign sets the envelope's signer fields and signature.
+func (s *EnvelopeSigner) Sign(env *Envelope) error {
+	env.SignerAccount = s.accountID.String()
+	env.PublicKey = s.key.PublicKey().String()
+
+	data, err := env.SigningBytes()
+	if err != nil {
+		return fmt.Errorf("sign %s envelope: %w", env.Type, err)
+	}
+	env.Signature = hex.EncodeToString(s.key.Sign(data))
+	return nil
+}
+
+// SigningPublisher wraps a MessagePublisher and signs every envelope before publishing.
+type SigningPublisher struct {
+	inner  MessagePublisher
+	signer *EnvelopeSigner
+}
+
+// NewSigningPublisher creates a publisher that signs envelopes with signer
+// before handing them to inner.
+func NewSigningPublisher(inner MessagePublisher, signer *EnvelopeSigner) *SigningPublisher {
+	return &SigningPublisher{inner: inner, signer: signer}
+}
+
+// Publish signs the envelope and publishes it to the topic.
+func (p *SigningPublisher) Publish(ctx context.Context, topicID hiero.TopicID, msg Envelope) error {
+	if err := p.signer.Sign(&msg); err != nil {
+		return fmt.Errorf("publish to topic %s: %w", topicID, err)
+	}
+	return p.inner.Publish(ctx, topicID, msg)
+}
+
+// KeyRegistry maps envelope senders to the public keys their messages must be signed with.
+type KeyRegistry struct {
+	mu   sync.RWMutex
+	keys map[string]hiero.PublicKey
+}
+
+// NewKeyRegistry creates an empty key registry.
+func NewKeyRegistry() *KeyRegistry {
+	return &KeyRegistry{keys: make(map[string]hiero.PublicKey)}
+}
+
+// Register sets the public key for a sender, replacing any previous key.
+func (r *KeyRegistry) Register(sender string, key hiero.PublicKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys[sender] = key
+}
+
+// Verify checks that the envelope is signed by the key registered for its sender.
+func (r *KeyRegistry) Verify(env Envelope) error {
+	if env.Signature == "" {
+		return &VerificationError{Sender: env.Sender, Type: env.Type, TaskID: env.TaskID, Err: ErrUnsigned}
+	}
+
+	r.mu.RLock()
+	key, ok := r.keys[env.Sender]
+	r.mu.RUnlock()
+	if !ok {
+		return &VerificationError{Sender: env.Sender, Type: env.Type, TaskID: env.TaskID, Err: ErrUnknownSigner}
+	}
+	if env.PublicKey != key.String() {
+		return &VerificationError{Sender: env.Sender, Type: env.Type, TaskID: env.TaskID, Err: ErrKeyMismatch}
+	}
+
+	sig, err := hex.DecodeString(env.Signature)
+	if err != nil {
+		return &VerificationError{Sender: env.Sender, Type: env.Type, TaskID: env.TaskID, Err: ErrBadSignature}
+	}
+	data, err := env.SigningBytes()
+	if err != nil {
+		return &VerificationError{Sender: env.Sender, Type: env.Type, TaskID: env.TaskID, Err: err}
+	}
+	if !key.VerifySignedMessage(data, sig) {
+		return &VerificationError{Sender: env.Sender, Type: env.Type, TaskID: env.TaskID, Err: ErrBadSignature}
+	}
+	return nil
+}
+
+// VerifyPolicy controls what a VerifyingSubscriber does with envelopes that
+// fail verification.
+type VerifyPolicy int
+
+const (
+	// VerifyFlag delivers unverified envelopes and reports a *VerificationError on the error channel.
+	VerifyFlag VerifyPolicy = iota
+
+	// VerifyDrop discards unverified envelopes and reports a *VerificationError on the error channel.
+	VerifyDrop
+)
+
+// VerifyingSubscriber wraps a MessageSubscriber and checks each envelope's
+// signature against a KeyRegistry.
+type VerifyingSubscriber struct {
+	inner    MessageSubscriber
+	registry *KeyRegistry
+	policy   VerifyPolicy
+}
+
+// NewVerifyingSubscriber creates a subscriber that verifies envelopes from
+// inner against registry, handling failures according to policy.
+func NewVerifyingSubscriber(inner MessageSubscriber, registry *KeyRegistry, policy VerifyPolicy) *VerifyingSubscriber {
+	return &VerifyingSubscriber{inner: inner, registry: registry, policy: policy}
+}
+
+// Subscribe starts a subscription on the inner subscriber and verifies each envelope.
+func (s *VerifyingSubscriber) Subscribe(ctx context.Context, topicID hiero.TopicID) (<-chan Envelope, <-chan error) {
+	msgCh, errCh := s.inner.Subscribe(ctx, topicID)
+	return s.verify(ctx, msgCh, errCh)
+}
+
+// SubscribeFrom starts a positioned subscription on the inner subscriber and
+// verifies each envelope. The inner subscriber must implement
+// PositionedSubscriber.
+func (s *VerifyingSubscriber) SubscribeFrom(ctx context.Context, topicID hiero.TopicID, opts ReplayOptions) (<-chan Envelope, <-chan error) {
+	positioned, ok := s.inner.(PositionedSubscriber)
+	if !ok {
+		return failedSubscription(fmt.Errorf("subscribe to topic %s: subscriber cannot start from a position", topicID))
+	}
+
+	msgCh, errCh := positioned.SubscribeFrom(ctx, topicID, opts)
+	return s.verify(ctx, msgCh, errCh)
+}
+
+// Replay replays topic history from the inner subscriber and verifies each
+// envelope. The inner subscriber must implement MessageReplayer.
+func (s *VerifyingSubscriber) Replay(ctx context.Context, topicID hiero.TopicID, opts ReplayOptions) (<-chan Envelope, <-chan error) {
+	replayer, ok := s.inner.(MessageReplayer)
+	if !ok {
+		return failedSubscription(fmt.Errorf("replay topic %s: subscriber does not support replay", topicID))
+	}
+
+	msgCh, errCh := replayer.Replay(ctx, topicID, opts)
+	return s.verify(ctx, msgCh, errCh)
+}
+
+// failedSubscription returns closed channels carrying only err.
+func failedSubscription(err error) (<-chan Envelope, <-chan error) {
+	msgCh := make(chan Envelope)
+	errCh := make(chan error, 1)
+	errCh <- err
+	close(msgCh)
+	close(errCh)
+	return msgCh, errCh
+}
+
+func (s *VerifyingSubscriber) verify(ctx context.Context, in <-chan Envelope, inErr <-chan error) (<-chan Envelope, <-chan error) {
+	out := make(chan Envelope, cap(in))
+	outErr := make(chan error, cap(inErr)+1)
+
+	report := func(err error) {
+		select {
+		case outErr <- err:
+		default:
+		}
+	}
+
+	go func() {
+		defer close(out)
+		defer close(outErr)
+
+		for in != nil || inErr != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case err, ok := <-inErr:
+				if !ok {
+					inErr = nil
+					continue
+				}
+				report(err)
+			case env, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				if err := s.registry.Verify(env); err != nil {
+					report(err)
+					if s.policy == VerifyDrop {
+						continue
+					}
+				}
+				select {
+				case out <- env:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, outErr
+}
+
+// Compile-time interface compliance checks.
+var (
+	_ MessagePublisher     = (*SigningPublisher)(nil)
+	_ MessageSubscriber    = (*VerifyingSubscriber)(nil)
+	_ MessageReplayer      = (*VerifyingSubscriber)(nil)
+	_ PositionedSubscriber = (*VerifyingSubscriber)(nil)
+)
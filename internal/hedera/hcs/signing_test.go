@@ -0,0 +1,236 @@
+package hcs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+func newTestSigner(t *testing.T, account uint64) (*EnvelopeSigner, hiero.PublicKey) {
+	t.Helper()
+	key, err := hiero.PrivateKeyGenerateEd25519()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return NewEnvelopeSigner(hiero.AccountID{Account: account}, key), key.PublicKey()
+}
+
+func signedEnvelope(t *testing.T, signer *EnvelopeSigner) Envelope {
+	t.Helper()
+	env := Envelope{
+		Type:        MessageTypeTaskResult,
+		Sender:      "agent-1",
+		TaskID:      "task-1",
+		SequenceNum: 3,
+		Timestamp:   time.Date(2026, 2, 18, 14, 0, 0, 0, time.UTC),
+		Payload:     json.RawMessage(`{"status":"completed"}`),
+	}
+	if err := signer.Sign(&env); err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return env
+}
+
+func TestKeyRegistry_Verify(t *testing.T) {
+	signer, pub := newTestSigner(t, 1001)
+	_, otherPub := newTestSigner(t, 1002)
+
+	tests := []struct {
+		name    string
+		mutate  func(*Envelope)
+		keys    map[string]hiero.PublicKey
+		wantErr error
+	}{
+		{
+			name: "valid signature",
+			keys: map[string]hiero.PublicKey{"agent-1": pub},
+		},
+		{
+			name:    "tampered payload",
+			mutate:  func(e *Envelope) { e.Payload = json.RawMessage(`{"status":"failed"}`) },
+			keys:    map[string]hiero.PublicKey{"agent-1": pub},
+			wantErr: ErrBadSignature,
+		},
+		{
+			name:    "tampered sender account",
+			mutate:  func(e *Envelope) { e.SignerAccount = "0.0.9999" },
+			keys:    map[string]hiero.PublicKey{"agent-1": pub},
+			wantErr: ErrBadSignature,
+		},
+		{
+			name:    "unsigned",
+			mutate:  func(e *Envelope) { e.Signature = "" },
+			keys:    map[string]hiero.PublicKey{"agent-1": pub},
+			wantErr: ErrUnsigned,
+		},
+		{
+			name:    "unknown sender",
+			keys:    map[string]hiero.PublicKey{"agent-2": pub},
+			wantErr: ErrUnknownSigner,
+		},
+		{
+			name:    "registered key differs",
+			keys:    map[string]hiero.PublicKey{"agent-1": otherPub},
+			wantErr: ErrKeyMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := signedEnvelope(t, signer)
+			if tt.mutate != nil {
+				tt.mutate(&env)
+			}
+
+			registry := NewKeyRegistry()
+			for sender, key := range tt.keys {
+				registry.Register(sender, key)
+			}
+
+			err := registry.Verify(env)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Verify: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+			}
+			var verr *VerificationError
+			if !errors.As(err, &verr) || verr.Sender != env.Sender {
+				t.Errorf("expected *VerificationError for sender %q, got %v", env.Sender, err)
+			}
+		})
+	}
+}
+
+func TestKeyRegistry_VerifyAfterRoundTrip(t *testing.T) {
+	signer, pub := newTestSigner(t, 1001)
+	env := signedEnvelope(t, signer)
+
+	data, err := env.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	decoded, err := UnmarshalEnvelope(data)
+	if err != nil {
+		t.Fatalf("UnmarshalEnvelope: %v", err)
+	}
+
+	registry := NewKeyRegistry()
+	registry.Register("agent-1", pub)
+	if err := registry.Verify(*decoded); err != nil {
+		t.Fatalf("Verify after round trip: %v", err)
+	}
+	if decoded.SignerAccount != "0.0.1001" {
+		t.Errorf("SignerAccount = %q, want %q", decoded.SignerAccount, "0.0.1001")
+	}
+}
+
+type recordingPublisher struct {
+	published []Envelope
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, _ hiero.TopicID, msg Envelope) error {
+	p.published = append(p.published, msg)
+	return nil
+}
+
+func TestSigningPublisher_SignsEnvelopes(t *testing.T) {
+	signer, pub := newTestSigner(t, 1001)
+	inner := &recordingPublisher{}
+	p := NewSigningPublisher(inner, signer)
+
+	env := Envelope{Type: MessageTypeHeartbeat, Sender: "coordinator", Timestamp: time.Now()}
+	if err := p.Publish(context.Background(), hiero.TopicID{}, env); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if len(inner.published) != 1 {
+		t.Fatalf("published %d envelopes, want 1", len(inner.published))
+	}
+
+	registry := NewKeyRegistry()
+	registry.Register("coordinator", pub)
+	if err := registry.Verify(inner.published[0]); err != nil {
+		t.Errorf("published envelope does not verify: %v", err)
+	}
+}
+
+type staticSubscriber struct {
+	envelopes []Envelope
+}
+
+func (s *staticSubscriber) Subscribe(_ context.Context, _ hiero.TopicID) (<-chan Envelope, <-chan error) {
+	msgCh := make(chan Envelope, len(s.envelopes))
+	errCh := make(chan error)
+	for _, env := range s.envelopes {
+		msgCh <- env
+	}
+	close(msgCh)
+	close(errCh)
+	return msgCh, errCh
+}
+
+func TestVerifyingSubscriber_Policy(t *testing.T) {
+	signer, pub := newTestSigner(t, 1001)
+	good := signedEnvelope(t, signer)
+	forged := signedEnvelope(t, signer)
+	forged.TaskID = "task-2"
+
+	tests := []struct {
+		name      string
+		policy    VerifyPolicy
+		wantTasks []string
+	}{
+		{name: "flag delivers forged envelopes", policy: VerifyFlag, wantTasks: []string{"task-1", "task-2"}},
+		{name: "drop discards forged envelopes", policy: VerifyDrop, wantTasks: []string{"task-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewKeyRegistry()
+			registry.Register("agent-1", pub)
+			sub := NewVerifyingSubscriber(&staticSubscriber{envelopes: []Envelope{good, forged}}, registry, tt.policy)
+
+			msgCh, errCh := sub.Subscribe(context.Background(), hiero.TopicID{})
+
+			var got []string
+			for env := range msgCh {
+				got = append(got, env.TaskID)
+			}
+			var errs []error
+			for err := range errCh {
+				errs = append(errs, err)
+			}
+
+			if len(got) != len(tt.wantTasks) {
+				t.Fatalf("delivered %v, want %v", got, tt.wantTasks)
+			}
+			for i := range got {
+				if got[i] != tt.wantTasks[i] {
+					t.Errorf("delivered[%d] = %q, want %q", i, got[i], tt.wantTasks[i])
+				}
+			}
+			if len(errs) != 1 || !errors.Is(errs[0], ErrBadSignature) {
+				t.Errorf("errors = %v, want one ErrBadSignature", errs)
+			}
+		})
+	}
+}
+
+func TestVerifyingSubscriber_ReplayUnsupported(t *testing.T) {
+	sub := NewVerifyingSubscriber(&staticSubscriber{}, NewKeyRegistry(), VerifyDrop)
+	msgCh, errCh := sub.Replay(context.Background(), hiero.TopicID{}, ReplayOptions{})
+
+	if _, ok := <-msgCh; ok {
+		t.Error("expected closed message channel")
+	}
+	if err := <-errCh; err == nil {
+		t.Error("expected error when inner subscriber cannot replay")
+	}
+}
@@ -2,23 +2,40 @@ package hcs
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	defaultMessageBuffer  = 100
 	defaultReconnectDelay = 2 * time.Second
 	defaultMaxReconnects  = 10
+	defaultLookback       = 30 * time.Second
+	defaultChunkTimeout   = 2 * time.Minute
 )
 
+// errStreamEnded is returned when a live subscription stream completes
+// without the context being cancelled, so the caller reconnects.
+var errStreamEnded = errors.New("subscription stream ended")
+
 // SubscribeConfig holds configuration for the subscriber.
 type SubscribeConfig struct {
 	MessageBuffer  int
 	ReconnectDelay time.Duration
 	MaxReconnects  int
+
+	// Lookback is how far before now a live Subscribe starts, so messages
+	// published while the subscriber was starting up are not missed.
+	Lookback time.Duration
+
+	// ChunkTimeout is how long an incomplete chunk group is buffered before
+	// it is discarded and reported on the error channel.
+	ChunkTimeout time.Duration
 }
 
 // DefaultSubscribeConfig returns sensible defaults for testnet usage.
@@ -27,10 +44,26 @@ func DefaultSubscribeConfig() SubscribeConfig {
 		MessageBuffer:  defaultMessageBuffer,
 		ReconnectDelay: defaultReconnectDelay,
 		MaxReconnects:  defaultMaxReconnects,
+		Lookback:       defaultLookback,
+		ChunkTimeout:   defaultChunkTimeout,
 	}
 }
 
-// Subscriber implements the MessageSubscriber interface using the Hiero (Hedera) SDK.
+// ReplayOptions selects where a subscription starts and, for Replay, where it stops.
+type ReplayOptions struct {
+	// StartTime is the consensus time to start from. Zero means the start of the topic.
+	StartTime time.Time
+
+	// StartSequence skips messages whose topic sequence number is below this value.
+	StartSequence uint64
+
+	// EndTime is the consensus time a Replay stops at. Zero means the time
+	// Replay was called. Ignored by SubscribeFrom.
+	EndTime time.Time
+}
+
+// Subscriber implements the MessageSubscriber and MessageReplayer interfaces
+// using the Hiero (Hedera) SDK.
 type Subscriber struct {
 	client *hiero.Client
 	config SubscribeConfig
@@ -46,32 +79,70 @@ func NewSubscriber(client *hiero.Client, config SubscribeConfig) *Subscriber {
 
 // Subscribe starts a streaming subscription to an HCS topic.
 // Messages are delivered to the returned channel. The subscription
-// runs until the context is cancelled.
+// runs until the context is cancelled. It starts Lookback before now.
 func (s *Subscriber) Subscribe(ctx context.Context, topicID hiero.TopicID) (<-chan Envelope, <-chan error) {
+	return s.SubscribeFrom(ctx, topicID, ReplayOptions{StartTime: time.Now().Add(-s.config.Lookback)})
+}
+
+// SubscribeFrom starts a streaming subscription to an HCS topic that first
+// delivers history from the given starting point and then continues live
+// until the context is cancelled.
+func (s *Subscriber) SubscribeFrom(ctx context.Context, topicID hiero.TopicID, opts ReplayOptions) (<-chan Envelope, <-chan error) {
+	msgCh := make(chan Envelope, s.config.MessageBuffer)
+	errCh := make(chan error, s.config.MessageBuffer)
+
+	pos := newStreamPosition(opts)
+	go s.runSubscription(ctx, topicID, pos, time.Time{}, msgCh, errCh)
+
+	return msgCh, errCh
+}
+
+// Replay delivers the messages on an HCS topic between the given starting
+// point and opts.EndTime, then closes both channels.
+func (s *Subscriber) Replay(ctx context.Context, topicID hiero.TopicID, opts ReplayOptions) (<-chan Envelope, <-chan error) {
 	msgCh := make(chan Envelope, s.config.MessageBuffer)
 	errCh := make(chan error, s.config.MessageBuffer)
 
-	go s.runSubscription(ctx, topicID, msgCh, errCh)
+	end := opts.EndTime
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	pos := newStreamPosition(opts)
+	go s.runSubscription(ctx, topicID, pos, end, msgCh, errCh)
 
 	return msgCh, errCh
 }
 
+// runSubscription drives subscribeOnce with reconnects. A zero end time
+// means the subscription is live; otherwise it stops once end is reached.
+// Reconnects resume just after the last delivered message, and chunk groups
+// in progress carry over to the next connection.
 func (s *Subscriber) runSubscription(
 	ctx context.Context,
 	topicID hiero.TopicID,
+	pos *streamPosition,
+	end time.Time,
 	msgCh chan<- Envelope,
 	errCh chan<- error,
 ) {
 	defer close(msgCh)
 	defer close(errCh)
 
+	chunks := newChunkAssembler(s.config.ChunkTimeout)
+	stopSweep := s.sweepChunks(ctx, topicID, chunks, errCh)
+	defer stopSweep()
+
 	for reconnects := 0; reconnects <= s.config.MaxReconnects; reconnects++ {
 		if ctx.Err() != nil {
 			return
 		}
 
-		err := s.subscribeOnce(ctx, topicID, msgCh, errCh)
+		err := s.subscribeOnce(ctx, topicID, pos, end, chunks, msgCh, errCh)
 		if err == nil || ctx.Err() != nil {
+			if err == nil && !end.IsZero() {
+				reportChunkErrors(topicID, chunks.flush(), errCh)
+			}
 			return
 		}
 
@@ -93,39 +164,193 @@ func (s *Subscriber) runSubscription(
 	}
 }
 
+// sweepChunks periodically discards chunk groups that have not completed
+// within ChunkTimeout. The returned function stops the sweeper and waits for
+// it to exit.
+func (s *Subscriber) sweepChunks(
+	ctx context.Context,
+	topicID hiero.TopicID,
+	chunks *chunkAssembler,
+	errCh chan<- error,
+) func() {
+	if s.config.ChunkTimeout <= 0 {
+		return func() {}
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(s.config.ChunkTimeout / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stop:
+				return
+			case <-ticker.C:
+				reportChunkErrors(topicID, chunks.expire(), errCh)
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		wg.Wait()
+	}
+}
+
+func reportChunkErrors(topicID hiero.TopicID, errs []error, errCh chan<- error) {
+	for _, err := range errs {
+		select {
+		case errCh <- fmt.Errorf("reassemble from topic %s: %w", topicID, err):
+		default:
+		}
+	}
+}
+
 func (s *Subscriber) subscribeOnce(
 	ctx context.Context,
 	topicID hiero.TopicID,
+	pos *streamPosition,
+	end time.Time,
+	chunks *chunkAssembler,
 	msgCh chan<- Envelope,
 	errCh chan<- error,
 ) error {
-	handle, err := hiero.NewTopicMessageQuery().
+	done := make(chan error, 1)
+	signal := func(err error) {
+		select {
+		case done <- err:
+		default:
+		}
+	}
+
+	query := hiero.NewTopicMessageQuery().
 		SetTopicID(topicID).
-		SetStartTime(time.Now().Add(-30 * time.Second)).
-		Subscribe(s.client, func(message hiero.TopicMessage) {
-			env, err := UnmarshalEnvelope(message.Contents)
-			if err != nil {
-				select {
-				case errCh <- fmt.Errorf("deserialize from topic %s seq %d: %w",
-					topicID, message.SequenceNumber, err):
-				default:
-				}
-				return
-			}
+		SetCompletionHandler(func() { signal(nil) }).
+		SetErrorHandler(func(stat status.Status) { signal(stat.Err()) })
+	if start := pos.startTime(); !start.IsZero() {
+		query.SetStartTime(start)
+	}
+	if !end.IsZero() {
+		query.SetEndTime(end)
+	}
 
-			select {
-			case msgCh <- *env:
-			case <-ctx.Done():
-			}
-		})
+	handle, err := query.Subscribe(s.client, func(message hiero.TopicMessage) {
+		deliverMessage(ctx, topicID, pos, chunks, message, msgCh, errCh)
+	})
 	if err != nil {
 		return fmt.Errorf("start subscription: %w", err)
 	}
+	defer handle.Unsubscribe()
 
-	<-ctx.Done()
-	handle.Unsubscribe()
-	return nil
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-done:
+		switch {
+		case err != nil:
+			return fmt.Errorf("stream: %w", err)
+		case end.IsZero():
+			return errStreamEnded
+		default:
+			return nil
+		}
+	}
 }
 
-// Compile-time interface compliance check.
-var _ MessageSubscriber = (*Subscriber)(nil)
+// deliverMessage decodes a topic message and forwards it to msgCh, skipping
+// messages before the stream position and advancing it past delivered ones.
+// Chunk frames are buffered until their group is complete, then the
+// reassembled envelope is delivered.
+func deliverMessage(
+	ctx context.Context,
+	topicID hiero.TopicID,
+	pos *streamPosition,
+	chunks *chunkAssembler,
+	message hiero.TopicMessage,
+	msgCh chan<- Envelope,
+	errCh chan<- error,
+) {
+	if !pos.accept(message) {
+		return
+	}
+	defer pos.advance(message)
+
+	data, complete, err := chunks.add(message.Contents)
+	if err != nil {
+		select {
+		case errCh <- fmt.Errorf("reassemble from topic %s seq %d: %w",
+			topicID, message.SequenceNumber, err):
+		default:
+		}
+		return
+	}
+	if !complete {
+		return
+	}
+
+	env, err := UnmarshalEnvelope(data)
+	if err != nil {
+		select {
+		case errCh <- fmt.Errorf("deserialize from topic %s seq %d: %w",
+			topicID, message.SequenceNumber, err):
+		default:
+		}
+		return
+	}
+	env.ConsensusTimestamp = message.ConsensusTimestamp
+	env.TopicSequenceNum = message.SequenceNumber
+
+	select {
+	case msgCh <- *env:
+	case <-ctx.Done():
+	}
+}
+
+// streamPosition tracks how far a subscription has read so a reconnect can
+// resume just after the last delivered message instead of starting over.
+type streamPosition struct {
+	mu     sync.Mutex
+	start  time.Time
+	minSeq uint64
+}
+
+func newStreamPosition(opts ReplayOptions) *streamPosition {
+	return &streamPosition{start: opts.StartTime, minSeq: opts.StartSequence}
+}
+
+func (p *streamPosition) startTime() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.start
+}
+
+// accept reports whether a message is at or after the position.
+func (p *streamPosition) accept(message hiero.TopicMessage) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return message.SequenceNumber >= p.minSeq
+}
+
+// advance moves the position just past a delivered message.
+func (p *streamPosition) advance(message hiero.TopicMessage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if next := message.ConsensusTimestamp.Add(time.Nanosecond); next.After(p.start) {
+		p.start = next
+	}
+	if message.SequenceNumber >= p.minSeq {
+		p.minSeq = message.SequenceNumber + 1
+	}
+}
+
+// Compile-time interface compliance checks.
+var (
+	_ MessageSubscriber    = (*Subscriber)(nil)
+	_ MessageReplayer      = (*Subscriber)(nil)
+	_ PositionedSubscriber = (*Subscriber)(nil)
+)
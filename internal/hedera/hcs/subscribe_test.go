@@ -44,6 +44,8 @@ func TestSubscribeConfig_Defaults(t *testing.T) {
 		{"MessageBuffer", cfg.MessageBuffer, defaultMessageBuffer},
 		{"ReconnectDelay", cfg.ReconnectDelay, defaultReconnectDelay},
 		{"MaxReconnects", cfg.MaxReconnects, defaultMaxReconnects},
+		{"Lookback", cfg.Lookback, defaultLookback},
+		{"ChunkTimeout", cfg.ChunkTimeout, defaultChunkTimeout},
 	}
 
 	for _, tt := range tests {
@@ -94,3 +96,82 @@ func TestUnmarshalEnvelope_InvalidJSON(t *testing.T) {
 func TestSubscriber_ImplementsInterface(t *testing.T) {
 	var _ MessageSubscriber = (*Subscriber)(nil)
 }
+
+func TestReplay_ContextCancellation(t *testing.T) {
+	sub := NewSubscriber(nil, DefaultSubscribeConfig())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	msgCh, errCh := sub.Replay(ctx, hiero.TopicID{}, ReplayOptions{})
+
+	timeout := time.After(2 * time.Second)
+	select {
+	case _, ok := <-msgCh:
+		if ok {
+			t.Error("expected message channel to be closed")
+		}
+	case <-timeout:
+		t.Error("message channel did not close within timeout")
+	}
+
+	select {
+	case <-errCh:
+	case <-timeout:
+		t.Error("error channel did not close within timeout")
+	}
+}
+
+func TestDeliverMessage_ResumesAfterLastDelivered(t *testing.T) {
+	base := time.Date(2026, 2, 18, 14, 0, 0, 0, time.UTC)
+	pos := newStreamPosition(ReplayOptions{StartTime: base, StartSequence: 2})
+	chunks := newChunkAssembler(time.Minute)
+	msgCh := make(chan Envelope, 10)
+	errCh := make(chan error, 10)
+
+	message := func(seq uint64, sender string) hiero.TopicMessage {
+		env := Envelope{Type: MessageTypeHeartbeat, Sender: sender, SequenceNum: seq}
+		data, err := env.Marshal()
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+		return hiero.TopicMessage{
+			SequenceNumber:     seq,
+			ConsensusTimestamp: base.Add(time.Duration(seq) * time.Second),
+			Contents:           data,
+		}
+	}
+
+	// Sequence 1 is before the requested start and must be skipped.
+	for _, seq := range []uint64{1, 2, 3} {
+		deliverMessage(context.Background(), hiero.TopicID{}, pos, chunks, message(seq, "agent-1"), msgCh, errCh)
+	}
+	// A reconnect redelivering sequence 3 must not produce a duplicate.
+	deliverMessage(context.Background(), hiero.TopicID{}, pos, chunks, message(3, "agent-1"), msgCh, errCh)
+
+	if got := len(msgCh); got != 2 {
+		t.Fatalf("delivered %d messages, want 2", got)
+	}
+	if env := <-msgCh; env.SequenceNum != 2 {
+		t.Errorf("first delivered seq = %d, want 2", env.SequenceNum)
+	}
+	if want := base.Add(3*time.Second + time.Nanosecond); !pos.startTime().Equal(want) {
+		t.Errorf("resume time = %v, want %v", pos.startTime(), want)
+	}
+}
+
+func TestDeliverMessage_MalformedContentAdvances(t *testing.T) {
+	pos := newStreamPosition(ReplayOptions{})
+	msgCh := make(chan Envelope, 1)
+	errCh := make(chan error, 1)
+
+	deliverMessage(context.Background(), hiero.TopicID{}, pos, newChunkAssembler(time.Minute),
+		hiero.TopicMessage{SequenceNumber: 7, Contents: []byte("not json")}, msgCh, errCh)
+
+	if len(errCh) != 1 {
+		t.Fatal("expected deserialize error")
+	}
+	if pos.accept(hiero.TopicMessage{SequenceNumber: 7}) {
+		t.Error("malformed message should not be redelivered after reconnect")
+	}
+}
@@ -0,0 +1,78 @@
+package hts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/mirror"
+)
+
+var (
+	// ErrNotAssociated is returned when an account is not associated with a token.
+	ErrNotAssociated = errors.New("account not associated with token")
+
+	// errNoMirror is returned by balance queries on a TransferService
+	// without a mirror node client.
+	errNoMirror = errors.New("no mirror node client configured")
+)
+
+// SetMirror configures the mirror node client that balance, association and
+// allowance queries are answered from. Consensus nodes no longer report
+// token balances or allowances, so TokenBalances needs one.
+func (s *TransferService) SetMirror(client *mirror.Client) {
+	s.mirror = client
+}
+
+// TokenBalance returns an account's balance of a token.
+func (s *TransferService) TokenBalance(ctx context.Context, tokenID hiero.TokenID, accountID hiero.AccountID) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("balance of token %s for %s: %w", tokenID, accountID, err)
+	}
+	if s.mirror == nil {
+		return 0, fmt.Errorf("balance of token %s for %s: %w", tokenID, accountID, errNoMirror)
+	}
+
+	balance, err := s.mirror.TokenBalance(ctx, accountID, tokenID)
+	if errors.Is(err, mirror.ErrNotFound) {
+		return 0, fmt.Errorf("balance of token %s for %s: %w", tokenID, accountID, ErrNotAssociated)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("balance of token %s for %s: %w", tokenID, accountID, err)
+	}
+	return balance.Balance, nil
+}
+
+// IsAssociated reports whether an account is associated with a token.
+func (s *TransferService) IsAssociated(ctx context.Context, tokenID hiero.TokenID, accountID hiero.AccountID) (bool, error) {
+	_, err := s.TokenBalance(ctx, tokenID, accountID)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, ErrNotAssociated):
+		return false, nil
+	default:
+		return false, fmt.Errorf("check association: %w", err)
+	}
+}
+
+// Allowance returns how many of owner's tokens spender is approved to transfer.
+func (s *TransferService) Allowance(ctx context.Context, tokenID hiero.TokenID, owner, spender hiero.AccountID) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("allowance of token %s from %s to %s: %w", tokenID, owner, spender, err)
+	}
+	if s.mirror == nil {
+		return 0, fmt.Errorf("allowance of token %s from %s to %s: %w", tokenID, owner, spender, errNoMirror)
+	}
+
+	amount, err := s.mirror.TokenAllowance(ctx, owner, spender, tokenID)
+	if err != nil {
+		return 0, fmt.Errorf("allowance of token %s from %s to %s: %w", tokenID, owner, spender, err)
+	}
+	return amount, nil
+}
+
+// Compile-time interface compliance check.
+var _ TokenBalances = (*TransferService)(nil)
@@ -0,0 +1,120 @@
+package hts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/mirror"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/mirror/mirrortest"
+)
+
+func TestTransferService_TokenBalances(t *testing.T) {
+	srv := mirrortest.NewServer()
+	defer srv.Close()
+
+	token := hiero.TokenID{Token: 2001}
+	treasury := hiero.AccountID{Account: 100}
+	agent := hiero.AccountID{Account: 200}
+	srv.SetTokenBalance(treasury, mirror.TokenBalance{TokenID: token, Balance: 5000})
+	srv.SetTokenBalance(agent, mirror.TokenBalance{TokenID: hiero.TokenID{Token: 9}, Balance: 1})
+	srv.SetTokenAllowance(treasury, agent, token, 75)
+
+	client, err := mirror.NewClient(srv.URL, mirror.Config{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	svc := NewTransferService(nil)
+	svc.SetMirror(client)
+	ctx := context.Background()
+
+	if balance, err := svc.TokenBalance(ctx, token, treasury); err != nil || balance != 5000 {
+		t.Errorf("TokenBalance(treasury) = %d, %v, want 5000", balance, err)
+	}
+	if _, err := svc.TokenBalance(ctx, token, agent); !errors.Is(err, ErrNotAssociated) {
+		t.Errorf("TokenBalance(unassociated) error = %v, want ErrNotAssociated", err)
+	}
+	if ok, err := svc.IsAssociated(ctx, token, treasury); err != nil || !ok {
+		t.Errorf("IsAssociated(treasury) = %v, %v, want true", ok, err)
+	}
+	if ok, err := svc.IsAssociated(ctx, token, agent); err != nil || ok {
+		t.Errorf("IsAssociated(agent) = %v, %v, want false", ok, err)
+	}
+	if amount, err := svc.Allowance(ctx, token, treasury, agent); err != nil || amount != 75 {
+		t.Errorf("Allowance() = %d, %v, want 75", amount, err)
+	}
+	if amount, err := svc.Allowance(ctx, token, agent, treasury); err != nil || amount != 0 {
+		t.Errorf("Allowance(none granted) = %d, %v, want 0", amount, err)
+	}
+}
+
+func TestTransferService_TokenBalancesNeedMirror(t *testing.T) {
+	svc := NewTransferService(nil)
+	if _, err := svc.TokenBalance(context.Background(), hiero.TokenID{Token: 1}, hiero.AccountID{Account: 100}); err == nil {
+		t.Error("expected error without a mirror node client")
+	}
+	if _, err := svc.IsAssociated(context.Background(), hiero.TokenID{Token: 1}, hiero.AccountID{Account: 100}); err == nil {
+		t.Error("expected association error without a mirror node client")
+	}
+}
+
+func TestTransferService_TokenTransfers(t *testing.T) {
+	srv := mirrortest.NewServer()
+	defer srv.Close()
+
+	token := hiero.TokenID{Token: 2001}
+	treasury := hiero.AccountID{Account: 100}
+	start := time.Date(2026, 2, 18, 14, 0, 0, 0, time.UTC)
+	add := func(i int, result string, legs ...mirror.TokenTransfer) {
+		ts := start.Add(time.Duration(i) * time.Second)
+		srv.AddTransaction(mirror.Transaction{
+			TransactionID:      mirror.TransactionIDString(hiero.NewTransactionIDWithValidStart(treasury, ts)),
+			ConsensusTimestamp: ts,
+			Name:               "CRYPTOTRANSFER",
+			Result:             result,
+			Memo:               "payment:batch",
+			TokenTransfers:     legs,
+		})
+	}
+	// One debit paying two agents, a failed transfer, and another token.
+	add(0, "SUCCESS",
+		mirror.TokenTransfer{TokenID: token, AccountID: treasury, Amount: -150},
+		mirror.TokenTransfer{TokenID: token, AccountID: hiero.AccountID{Account: 200}, Amount: 100},
+		mirror.TokenTransfer{TokenID: token, AccountID: hiero.AccountID{Account: 201}, Amount: 50})
+	add(1, "INSUFFICIENT_TOKEN_BALANCE",
+		mirror.TokenTransfer{TokenID: token, AccountID: treasury, Amount: -10},
+		mirror.TokenTransfer{TokenID: token, AccountID: hiero.AccountID{Account: 200}, Amount: 10})
+	add(2, "SUCCESS",
+		mirror.TokenTransfer{TokenID: hiero.TokenID{Token: 9}, AccountID: treasury, Amount: -5},
+		mirror.TokenTransfer{TokenID: hiero.TokenID{Token: 9}, AccountID: hiero.AccountID{Account: 200}, Amount: 5})
+
+	client, err := mirror.NewClient(srv.URL, mirror.Config{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	svc := NewTransferService(nil)
+	svc.SetMirror(client)
+
+	records, err := svc.TokenTransfers(context.Background(), token, treasury, start)
+	if err != nil {
+		t.Fatalf("TokenTransfers() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("records = %+v, want one per credited agent", records)
+	}
+	for i, want := range []struct {
+		to     uint64
+		amount int64
+	}{{200, 100}, {201, 50}} {
+		r := records[i]
+		if r.FromAccountID != treasury || r.ToAccountID.Account != want.to || r.Amount != want.amount {
+			t.Errorf("record %d = %+v, want %d from treasury to 0.0.%d", i, r, want.amount, want.to)
+		}
+		if r.TransactionID.String() != records[0].TransactionID.String() || r.Memo != "payment:batch" {
+			t.Errorf("record %d transaction = %s memo %q, want shared batch transaction", i, r.TransactionID, r.Memo)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package hts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/mirror"
+)
+
+// TokenTransfers returns the successful transfers of a token debited from or
+// credited to an account at or after since, read from the mirror node.
+func (s *TransferService) TokenTransfers(ctx context.Context, tokenID hiero.TokenID, accountID hiero.AccountID, since time.Time) ([]TransferRecord, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("list transfers of token %s for %s: %w", tokenID, accountID, err)
+	}
+	if s.mirror == nil {
+		return nil, fmt.Errorf("list transfers of token %s for %s: %w", tokenID, accountID, errNoMirror)
+	}
+
+	txs, err := s.mirror.AccountTransactions(ctx, accountID, mirror.TransactionQuery{
+		StartTime: since,
+		Type:      "CRYPTOTRANSFER",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list transfers of token %s for %s: %w", tokenID, accountID, err)
+	}
+
+	var records []TransferRecord
+	for _, tx := range txs {
+		if !tx.Succeeded() {
+			continue
+		}
+		txID, err := mirror.ParseTransactionID(tx.TransactionID)
+		if err != nil {
+			return nil, fmt.Errorf("list transfers of token %s for %s: %w", tokenID, accountID, err)
+		}
+		txID = txID.SetScheduled(tx.Scheduled)
+		records = append(records, creditsOf(tx, tokenID, txID, accountID)...)
+	}
+	return records, nil
+}
+
+// creditsOf splits a transaction's legs in one token into a record per
+// credited account that involves accountID, as sender or recipient.
+func creditsOf(tx mirror.Transaction, tokenID hiero.TokenID, txID hiero.TransactionID, accountID hiero.AccountID) []TransferRecord {
+	var debits, credits []mirror.TokenTransfer
+	for _, leg := range tx.TokenTransfers {
+		if leg.TokenID != tokenID {
+			continue
+		}
+		if leg.Amount < 0 {
+			debits = append(debits, leg)
+		} else if leg.Amount > 0 {
+			credits = append(credits, leg)
+		}
+	}
+
+	var records []TransferRecord
+	for _, credit := range credits {
+		from := hiero.AccountID{}
+		if len(debits) == 1 {
+			from = debits[0].AccountID
+		}
+		for _, debit := range debits {
+			if debit.AccountID == accountID {
+				from = accountID
+			}
+		}
+		if from != accountID && credit.AccountID != accountID {
+			continue
+		}
+		records = append(records, TransferRecord{
+			TransactionID:      txID,
+			ConsensusTimestamp: tx.ConsensusTimestamp,
+			TokenID:            tokenID,
+			FromAccountID:      from,
+			ToAccountID:        credit.AccountID,
+			Amount:             credit.Amount,
+			Memo:               tx.Memo,
+		})
+	}
+	return records
+}
+
+// Compile-time interface compliance check.
+var _ TransferHistory = (*TransferService)(nil)
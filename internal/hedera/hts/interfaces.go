@@ -2,6 +2,7 @@ package hts
 
 import (
 	"context"
+	"time"
 
 	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
 )
@@ -19,9 +20,46 @@ type TokenCreator interface {
 // TokenTransfer handles HTS token transfer operations between accounts.
 // Used by the coordinator to pay agents for completed tasks.
 type TokenTransfer interface {
-	// Transfer moves tokens from one account to another.
+	// Transfer moves tokens from one account to another. If the
+	// transaction was submitted but its receipt could not be read, the
+	// returned receipt carries its transaction ID, with no status, alongside
+	// the error: the transfer may still have reached consensus.
 	Transfer(ctx context.Context, req TransferRequest) (*TransferReceipt, error)
 
 	// AssociateToken associates a token with an account so it can receive transfers.
 	AssociateToken(ctx context.Context, tokenID hiero.TokenID, accountID hiero.AccountID) error
 }
+
+// BatchTokenTransfer pays several accounts from one account in a single
+// transfer transaction.
+// Used by the coordinator to settle many task payments for one fee.
+type BatchTokenTransfer interface {
+	// TransferBatch debits the sender once and credits every recipient
+	// atomically: either all credits settle or none do. As with Transfer,
+	// a receipt carrying the transaction ID may accompany the error.
+	TransferBatch(ctx context.Context, req BatchTransferRequest) (*BatchTransferReceipt, error)
+}
+
+// TokenBalances answers questions about token holders.
+// Used by the coordinator to check, before paying, that the treasury can
+// cover the payment and that the recipient can receive it.
+type TokenBalances interface {
+	// TokenBalance returns an account's balance of a token. It returns an
+	// error wrapping ErrNotAssociated if the account is not associated with
+	// the token.
+	TokenBalance(ctx context.Context, tokenID hiero.TokenID, accountID hiero.AccountID) (int64, error)
+
+	// IsAssociated reports whether an account is associated with a token.
+	IsAssociated(ctx context.Context, tokenID hiero.TokenID, accountID hiero.AccountID) (bool, error)
+
+	// Allowance returns how many of owner's tokens spender is approved to transfer.
+	Allowance(ctx context.Context, tokenID hiero.TokenID, owner, spender hiero.AccountID) (int64, error)
+}
+
+// TransferHistory lists token transfers that reached consensus.
+// Used by the coordinator to reconcile its payment ledger against the chain.
+type TransferHistory interface {
+	// TokenTransfers returns the successful transfers of a token debited
+	// from or credited to an account at or after since, oldest first.
+	TokenTransfers(ctx context.Context, tokenID hiero.TokenID, accountID hiero.AccountID, since time.Time) ([]TransferRecord, error)
+}
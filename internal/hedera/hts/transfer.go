@@ -5,11 +5,15 @@ import (
 	"fmt"
 
 	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/mirror"
 )
 
-// TransferService implements the TokenTransfer interface using the Hiero (Hedera) SDK.
+// TransferService implements the TokenTransfer interface using the Hiero
+// (Hedera) SDK, and the TokenBalances interface using a mirror node.
 type TransferService struct {
 	client *hiero.Client
+	mirror *mirror.Client
 }
 
 // NewTransferService creates a new TransferService with the given Hiero client.
@@ -51,7 +55,15 @@ func (s *TransferService) Transfer(ctx context.Context, req TransferRequest) (*T
 
 	receipt, err := resp.GetReceipt(s.client)
 	if err != nil {
-		return nil, fmt.Errorf("transfer %d of token %s from %s to %s: receipt: %w",
+		// The transaction was submitted and may still reach consensus, so
+		// its ID is returned for the caller to look for on chain.
+		return &TransferReceipt{
+			TransactionID: resp.TransactionID,
+			TokenID:       req.TokenID,
+			FromAccountID: req.FromAccountID,
+			ToAccountID:   req.ToAccountID,
+			Amount:        req.Amount,
+		}, fmt.Errorf("transfer %d of token %s from %s to %s: receipt: %w",
 			req.Amount, req.TokenID, req.FromAccountID, req.ToAccountID, err)
 	}
 
@@ -65,6 +77,61 @@ func (s *TransferService) Transfer(ctx context.Context, req TransferRequest) (*T
 	}, nil
 }
 
+// TransferBatch pays every credit in req from one account in a single
+// TransferTransaction, so the batch settles or fails as a whole.
+func (s *TransferService) TransferBatch(ctx context.Context, req BatchTransferRequest) (*BatchTransferReceipt, error) {
+	total := req.Total()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("batch transfer %d of token %s from %s to %d accounts: %w",
+			total, req.TokenID, req.FromAccountID, len(req.Credits), err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	tx := hiero.NewTransferTransaction().
+		AddTokenTransfer(req.TokenID, req.FromAccountID, -total)
+	for _, c := range req.Credits {
+		tx.AddTokenTransfer(req.TokenID, c.ToAccountID, c.Amount)
+	}
+
+	if req.Memo != "" {
+		tx.SetTransactionMemo(req.Memo)
+	}
+
+	frozen, err := tx.FreezeWith(s.client)
+	if err != nil {
+		return nil, fmt.Errorf("batch transfer %d of token %s from %s to %d accounts: freeze: %w",
+			total, req.TokenID, req.FromAccountID, len(req.Credits), err)
+	}
+
+	resp, err := frozen.Execute(s.client)
+	if err != nil {
+		return nil, fmt.Errorf("batch transfer %d of token %s from %s to %d accounts: execute: %w",
+			total, req.TokenID, req.FromAccountID, len(req.Credits), err)
+	}
+
+	receipt, err := resp.GetReceipt(s.client)
+	if err != nil {
+		// As in Transfer, the submitted transaction's ID is returned.
+		return &BatchTransferReceipt{
+			TransactionID: resp.TransactionID,
+			TokenID:       req.TokenID,
+			FromAccountID: req.FromAccountID,
+			Credits:       append([]Credit(nil), req.Credits...),
+		}, fmt.Errorf("batch transfer %d of token %s from %s to %d accounts: receipt: %w",
+			total, req.TokenID, req.FromAccountID, len(req.Credits), err)
+	}
+
+	return &BatchTransferReceipt{
+		TransactionID: resp.TransactionID,
+		TokenID:       req.TokenID,
+		FromAccountID: req.FromAccountID,
+		Credits:       append([]Credit(nil), req.Credits...),
+		Status:        receipt.Status.String(),
+	}, nil
+}
+
 // AssociateToken associates a token with an account so it can receive transfers.
 func (s *TransferService) AssociateToken(ctx context.Context, tokenID hiero.TokenID, accountID hiero.AccountID) error {
 	if err := ctx.Err(); err != nil {
@@ -92,5 +159,8 @@ func (s *TransferService) AssociateToken(ctx context.Context, tokenID hiero.Toke
 	return nil
 }
 
-// Compile-time interface compliance check.
-var _ TokenTransfer = (*TransferService)(nil)
+// Compile-time interface compliance checks.
+var (
+	_ TokenTransfer      = (*TransferService)(nil)
+	_ BatchTokenTransfer = (*TransferService)(nil)
+)
@@ -1,6 +1,10 @@
 package hts
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
 )
 
@@ -87,3 +91,106 @@ type TransferReceipt struct {
 	// Status is the transaction status from the receipt.
 	Status string
 }
+
+// MaxTokenTransfers is the most token balance adjustments one transfer
+// transaction may make, counting the sender's debit: the network's
+// ledger.tokenTransfers.maxLen.
+const MaxTokenTransfers = 10
+
+// ErrTooManyTransfers is returned for a batch with more recipients than one
+// transfer transaction may credit.
+var ErrTooManyTransfers = errors.New("too many token transfers for one transaction")
+
+// Credit is one recipient of a batch transfer.
+type Credit struct {
+	ToAccountID hiero.AccountID
+	Amount      int64
+}
+
+// BatchTransferRequest describes a transfer from one account to several.
+type BatchTransferRequest struct {
+	// TokenID is the token to transfer.
+	TokenID hiero.TokenID
+
+	// FromAccountID is the sender, debited the total of all credits.
+	FromAccountID hiero.AccountID
+
+	// Credits lists each recipient once, with a positive amount. At most
+	// MaxTokenTransfers-1 recipients fit in one transaction.
+	Credits []Credit
+
+	// Memo is an optional memo attached to the transfer transaction.
+	Memo string
+}
+
+// Total returns the sum of the credits, which the sender is debited.
+func (r BatchTransferRequest) Total() int64 {
+	var total int64
+	for _, c := range r.Credits {
+		total += c.Amount
+	}
+	return total
+}
+
+// Validate checks the request fits in one transfer transaction.
+func (r BatchTransferRequest) Validate() error {
+	if len(r.Credits) == 0 {
+		return fmt.Errorf("batch transfer of token %s: no credits", r.TokenID)
+	}
+	if len(r.Credits) > MaxTokenTransfers-1 {
+		return fmt.Errorf("batch transfer of token %s: %d recipients, at most %d: %w",
+			r.TokenID, len(r.Credits), MaxTokenTransfers-1, ErrTooManyTransfers)
+	}
+	seen := make(map[hiero.AccountID]bool, len(r.Credits))
+	for _, c := range r.Credits {
+		if c.Amount <= 0 {
+			return fmt.Errorf("batch transfer of token %s: amount to %s must be positive, got %d",
+				r.TokenID, c.ToAccountID, c.Amount)
+		}
+		if c.ToAccountID == r.FromAccountID || seen[c.ToAccountID] {
+			return fmt.Errorf("batch transfer of token %s: account %s credited twice or by itself",
+				r.TokenID, c.ToAccountID)
+		}
+		seen[c.ToAccountID] = true
+	}
+	return nil
+}
+
+// BatchTransferReceipt holds the result of a completed batch transfer.
+type BatchTransferReceipt struct {
+	// TransactionID is the Hedera transaction ID shared by every credit.
+	TransactionID hiero.TransactionID
+
+	TokenID       hiero.TokenID
+	FromAccountID hiero.AccountID
+	Credits       []Credit
+
+	// Status is the transaction status from the receipt.
+	Status string
+}
+
+// TransferRecord is one credit of a token transfer that reached consensus.
+// A transaction paying several accounts yields one record per recipient,
+// all with the same TransactionID and Memo.
+type TransferRecord struct {
+	// TransactionID is the Hedera transaction ID of the transfer.
+	TransactionID hiero.TransactionID
+
+	// ConsensusTimestamp is when the transfer reached consensus.
+	ConsensusTimestamp time.Time
+
+	// TokenID is the token that was transferred.
+	TokenID hiero.TokenID
+
+	// FromAccountID is the debited account.
+	FromAccountID hiero.AccountID
+
+	// ToAccountID is the credited account.
+	ToAccountID hiero.AccountID
+
+	// Amount is the number of tokens credited to ToAccountID.
+	Amount int64
+
+	// Memo is the transaction memo.
+	Memo string
+}
@@ -0,0 +1,106 @@
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+type accountTokensResponse struct {
+	Tokens []tokenBalanceJSON `json:"tokens"`
+	Links  links              `json:"links"`
+}
+
+type tokenBalanceJSON struct {
+	TokenID  string `json:"token_id"`
+	Balance  int64  `json:"balance"`
+	Decimals uint32 `json:"decimals"`
+}
+
+// AccountTokenBalances returns the balance of every token the account is
+// associated with.
+func (c *Client) AccountTokenBalances(ctx context.Context, accountID hiero.AccountID) ([]TokenBalance, error) {
+	balances, err := c.accountTokens(ctx, accountID, url.Values{})
+	if err != nil {
+		return nil, fmt.Errorf("get token balances of account %s: %w", accountID, err)
+	}
+	return balances, nil
+}
+
+// TokenBalance returns the account's balance of one token. It returns an
+// error wrapping ErrNotFound if the account is unknown or not associated
+// with the token.
+func (c *Client) TokenBalance(ctx context.Context, accountID hiero.AccountID, tokenID hiero.TokenID) (TokenBalance, error) {
+	params := url.Values{}
+	params.Set("token.id", tokenID.String())
+	balances, err := c.accountTokens(ctx, accountID, params)
+	if err != nil {
+		return TokenBalance{}, fmt.Errorf("get balance of token %s for account %s: %w", tokenID, accountID, err)
+	}
+	for _, b := range balances {
+		if b.TokenID == tokenID {
+			return b, nil
+		}
+	}
+	return TokenBalance{}, fmt.Errorf("get balance of token %s for account %s: not associated: %w",
+		tokenID, accountID, ErrNotFound)
+}
+
+func (c *Client) accountTokens(ctx context.Context, accountID hiero.AccountID, params url.Values) ([]TokenBalance, error) {
+	params.Set("limit", strconv.Itoa(c.pageSize))
+	path := fmt.Sprintf("/api/v1/accounts/%s/tokens?%s", accountID, params.Encode())
+
+	var balances []TokenBalance
+	for path != "" {
+		var page accountTokensResponse
+		if err := c.get(ctx, path, &page); err != nil {
+			return nil, err
+		}
+		for _, raw := range page.Tokens {
+			tokenID, err := hiero.TokenIDFromString(raw.TokenID)
+			if err != nil {
+				return nil, fmt.Errorf("%w: token id %q: %v", ErrDecodeFailed, raw.TokenID, err)
+			}
+			balances = append(balances, TokenBalance{TokenID: tokenID, Balance: raw.Balance, Decimals: raw.Decimals})
+		}
+
+		path = ""
+		if page.Links.Next != nil && len(page.Tokens) > 0 {
+			path = *page.Links.Next
+		}
+	}
+	return balances, nil
+}
+
+type tokenAllowancesResponse struct {
+	Allowances []tokenAllowanceJSON `json:"allowances"`
+}
+
+type tokenAllowanceJSON struct {
+	TokenID string `json:"token_id"`
+	Spender string `json:"spender"`
+	Amount  int64  `json:"amount"`
+}
+
+// TokenAllowance returns how many of owner's tokens spender may still
+// transfer on its behalf. It is zero when no allowance was granted.
+func (c *Client) TokenAllowance(ctx context.Context, owner, spender hiero.AccountID, tokenID hiero.TokenID) (int64, error) {
+	params := url.Values{}
+	params.Set("spender.id", spender.String())
+	params.Set("token.id", tokenID.String())
+	path := fmt.Sprintf("/api/v1/accounts/%s/allowances/tokens?%s", owner, params.Encode())
+
+	var resp tokenAllowancesResponse
+	if err := c.get(ctx, path, &resp); err != nil {
+		return 0, fmt.Errorf("get allowance of token %s from %s to %s: %w", tokenID, owner, spender, err)
+	}
+	for _, a := range resp.Allowances {
+		if a.TokenID == tokenID.String() && a.Spender == spender.String() {
+			return a.Amount, nil
+		}
+	}
+	return 0, nil
+}
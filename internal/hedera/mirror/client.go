@@ -0,0 +1,165 @@
+// Package mirror is a client for the Hedera Mirror Node REST API. The
+// coordinator's live paths use the gRPC services; this package answers
+// historical questions — topic messages over a time or sequence range,
+// account token balances and transaction outcomes — for replay,
+// reconciliation and audit tools.
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout  = 10 * time.Second
+	defaultPageSize = 100 // the mirror node's maximum page size
+)
+
+var (
+	// ErrNotFound is returned when the mirror node has no record of the
+	// requested entity or transaction.
+	ErrNotFound = errors.New("mirror: not found")
+
+	// ErrRequestFailed indicates an HTTP transport failure.
+	ErrRequestFailed = errors.New("mirror: request failed")
+
+	// ErrUnexpectedStatus indicates a non-200 response other than 404.
+	ErrUnexpectedStatus = errors.New("mirror: unexpected status")
+
+	// ErrDecodeFailed indicates a response body that could not be decoded.
+	ErrDecodeFailed = errors.New("mirror: decode failed")
+)
+
+// networkURLs maps Hedera network names to their public mirror node.
+var networkURLs = map[string]string{
+	"mainnet":    "https://mainnet-public.mirrornode.hedera.com",
+	"testnet":    "https://testnet.mirrornode.hedera.com",
+	"previewnet": "https://previewnet.mirrornode.hedera.com",
+}
+
+// NetworkURL returns the public mirror node URL for a network name, or an
+// empty string if the network is unknown.
+func NetworkURL(network string) string {
+	return networkURLs[strings.ToLower(strings.TrimSpace(network))]
+}
+
+// Config holds configuration for a Client.
+type Config struct {
+	// Timeout bounds each HTTP request.
+	Timeout time.Duration
+
+	// PageSize is the number of records requested per page, at most 100.
+	PageSize int
+}
+
+// DefaultConfig returns sensible defaults.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:  defaultTimeout,
+		PageSize: defaultPageSize,
+	}
+}
+
+// Client queries a mirror node's REST API. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	pageSize   int
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the mirror node at baseURL, such as
+// "https://testnet.mirrornode.hedera.com".
+func NewClient(baseURL string, config Config) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(strings.TrimSpace(baseURL), "/"))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("create mirror client: invalid base URL %q", baseURL)
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.PageSize <= 0 || config.PageSize > defaultPageSize {
+		config.PageSize = defaultPageSize
+	}
+	return &Client{
+		baseURL:    u,
+		pageSize:   config.PageSize,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+// links is the paging section of a list response.
+type links struct {
+	Next *string `json:"next"`
+}
+
+// get fetches path (with query) relative to the base URL and decodes the
+// JSON body into out.
+func (c *Client) get(ctx context.Context, path string, out any) error {
+	ref, err := url.Parse(path)
+	if err != nil {
+		return fmt.Errorf("parse path %q: %w", path, err)
+	}
+	target := c.baseURL.ResolveReference(ref)
+	// Keep any path prefix on the base URL, e.g. a proxy mount point.
+	if !strings.HasPrefix(ref.Path, c.baseURL.Path+"/") {
+		target.Path = c.baseURL.Path + ref.Path
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: GET %s: %v", ErrRequestFailed, target.Path, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return fmt.Errorf("GET %s: %w", target.Path, ErrNotFound)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%w: GET %s returned status %d: %s",
+			ErrUnexpectedStatus, target.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: GET %s: %v", ErrDecodeFailed, target.Path, err)
+	}
+	return nil
+}
+
+// parseTimestamp parses a mirror node "seconds.nanoseconds" timestamp.
+func parseTimestamp(s string) (time.Time, error) {
+	secs, nanos, _ := strings.Cut(s, ".")
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse timestamp %q: %w", s, err)
+	}
+	var nsec int64
+	if nanos != "" {
+		nanos = (nanos + "000000000")[:9]
+		if nsec, err = strconv.ParseInt(nanos, 10, 64); err != nil {
+			return time.Time{}, fmt.Errorf("parse timestamp %q: %w", s, err)
+		}
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
+
+// FormatTimestamp formats a time as a mirror node "seconds.nanoseconds"
+// timestamp.
+func FormatTimestamp(t time.Time) string {
+	return fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond())
+}
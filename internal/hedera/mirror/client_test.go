@@ -0,0 +1,213 @@
+package mirror_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/mirror"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/mirror/mirrortest"
+)
+
+func newClient(t *testing.T, srv *mirrortest.Server, pageSize int) *mirror.Client {
+	t.Helper()
+	client, err := mirror.NewClient(srv.URL, mirror.Config{Timeout: 5 * time.Second, PageSize: pageSize})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestClient_TopicMessagesPagesThroughRange(t *testing.T) {
+	srv := mirrortest.NewServer()
+	defer srv.Close()
+
+	topic := hiero.TopicID{Topic: 7}
+	base := time.Date(2026, 2, 18, 14, 0, 0, 0, time.UTC)
+	for seq := uint64(1); seq <= 10; seq++ {
+		srv.AddTopicMessage(mirror.TopicMessage{
+			TopicID:            topic,
+			ConsensusTimestamp: base.Add(time.Duration(seq) * time.Second),
+			SequenceNumber:     seq,
+			PayerAccountID:     hiero.AccountID{Account: 2},
+			Message:            []byte(fmt.Sprintf(`{"seq":%d}`, seq)),
+		})
+	}
+	client := newClient(t, srv, 3)
+
+	tests := []struct {
+		name      string
+		query     mirror.TopicQuery
+		wantFirst uint64
+		wantLast  uint64
+	}{
+		{"whole topic", mirror.TopicQuery{}, 1, 10},
+		{"sequence range", mirror.TopicQuery{StartSequence: 4, EndSequence: 8}, 4, 8},
+		{"time range", mirror.TopicQuery{StartTime: base.Add(2 * time.Second), EndTime: base.Add(5 * time.Second)}, 2, 5},
+		{"limit", mirror.TopicQuery{StartSequence: 3, Limit: 4}, 3, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msgs, err := client.TopicMessages(context.Background(), topic, tt.query)
+			if err != nil {
+				t.Fatalf("TopicMessages() error = %v", err)
+			}
+			if want := int(tt.wantLast - tt.wantFirst + 1); len(msgs) != want {
+				t.Fatalf("got %d messages, want %d", len(msgs), want)
+			}
+			for i, msg := range msgs {
+				seq := tt.wantFirst + uint64(i)
+				if msg.SequenceNumber != seq || msg.TopicID != topic {
+					t.Errorf("message %d = topic %s seq %d, want seq %d", i, msg.TopicID, msg.SequenceNumber, seq)
+				}
+				if !msg.ConsensusTimestamp.Equal(base.Add(time.Duration(seq) * time.Second)) {
+					t.Errorf("message %d timestamp = %v", i, msg.ConsensusTimestamp)
+				}
+				if string(msg.Message) != fmt.Sprintf(`{"seq":%d}`, seq) {
+					t.Errorf("message %d contents = %s", i, msg.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_TokenBalances(t *testing.T) {
+	srv := mirrortest.NewServer()
+	defer srv.Close()
+
+	account := hiero.AccountID{Account: 1001}
+	token := hiero.TokenID{Token: 2001}
+	srv.SetTokenBalance(account, mirror.TokenBalance{TokenID: token, Balance: 250})
+	srv.SetTokenBalance(account, mirror.TokenBalance{TokenID: hiero.TokenID{Token: 2002}, Balance: 7, Decimals: 2})
+	client := newClient(t, srv, 0)
+	ctx := context.Background()
+
+	balances, err := client.AccountTokenBalances(ctx, account)
+	if err != nil {
+		t.Fatalf("AccountTokenBalances() error = %v", err)
+	}
+	if len(balances) != 2 {
+		t.Errorf("AccountTokenBalances() = %+v, want 2 tokens", balances)
+	}
+
+	balance, err := client.TokenBalance(ctx, account, token)
+	if err != nil || balance.Balance != 250 {
+		t.Errorf("TokenBalance() = %+v, %v, want 250", balance, err)
+	}
+	if _, err := client.TokenBalance(ctx, account, hiero.TokenID{Token: 9999}); !errors.Is(err, mirror.ErrNotFound) {
+		t.Errorf("unassociated token error = %v, want ErrNotFound", err)
+	}
+	if _, err := client.AccountTokenBalances(ctx, hiero.AccountID{Account: 4242}); !errors.Is(err, mirror.ErrNotFound) {
+		t.Errorf("unknown account error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_Transaction(t *testing.T) {
+	srv := mirrortest.NewServer()
+	defer srv.Close()
+
+	payer := hiero.AccountID{Account: 2}
+	validStart := time.Date(2026, 2, 18, 14, 0, 0, 1234, time.UTC)
+	txID := hiero.NewTransactionIDWithValidStart(payer, validStart)
+	id := mirror.TransactionIDString(txID)
+	if want := fmt.Sprintf("0.0.2-%d-000001234", validStart.Unix()); id != want {
+		t.Fatalf("TransactionIDString() = %q, want %q", id, want)
+	}
+
+	token := hiero.TokenID{Token: 2001}
+	srv.AddTransaction(mirror.Transaction{
+		TransactionID:      id,
+		ConsensusTimestamp: validStart.Add(3 * time.Second),
+		Name:               "CRYPTOTRANSFER",
+		Result:             "SUCCESS",
+		Memo:               "payment:task:task-1",
+		TokenTransfers: []mirror.TokenTransfer{
+			{TokenID: token, AccountID: payer, Amount: -100},
+			{TokenID: token, AccountID: hiero.AccountID{Account: 1001}, Amount: 100},
+		},
+	})
+	client := newClient(t, srv, 0)
+
+	tx, err := client.Transaction(context.Background(), txID)
+	if err != nil {
+		t.Fatalf("Transaction() error = %v", err)
+	}
+	if !tx.Succeeded() || tx.Memo != "payment:task:task-1" || len(tx.TokenTransfers) != 2 {
+		t.Errorf("Transaction() = %+v", tx)
+	}
+	if tx.TokenTransfers[1].Amount != 100 || tx.TokenTransfers[1].AccountID.Account != 1001 {
+		t.Errorf("credit = %+v", tx.TokenTransfers[1])
+	}
+
+	_, err = client.Transaction(context.Background(), txID.SetScheduled(true))
+	if !errors.Is(err, mirror.ErrNotFound) {
+		t.Errorf("scheduled lookup error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_AccountTransactionsPagesThroughRange(t *testing.T) {
+	srv := mirrortest.NewServer()
+	defer srv.Close()
+
+	token := hiero.TokenID{Token: 2001}
+	treasury := hiero.AccountID{Account: 100}
+	start := time.Date(2026, 2, 18, 14, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		ts := start.Add(time.Duration(i) * time.Second)
+		from := treasury
+		if i == 3 {
+			from = hiero.AccountID{Account: 300} // not involving the treasury
+		}
+		srv.AddTransaction(mirror.Transaction{
+			TransactionID:      mirror.TransactionIDString(hiero.NewTransactionIDWithValidStart(from, ts)),
+			ConsensusTimestamp: ts,
+			Name:               "CRYPTOTRANSFER",
+			Result:             "SUCCESS",
+			Memo:               fmt.Sprintf("payment:task:task-%d", i),
+			TokenTransfers: []mirror.TokenTransfer{
+				{TokenID: token, AccountID: from, Amount: -10},
+				{TokenID: token, AccountID: hiero.AccountID{Account: 200}, Amount: 10},
+			},
+		})
+	}
+	client := newClient(t, srv, 1)
+
+	txs, err := client.AccountTransactions(context.Background(), treasury, mirror.TransactionQuery{
+		StartTime: start.Add(time.Second),
+		Type:      "CRYPTOTRANSFER",
+	})
+	if err != nil {
+		t.Fatalf("AccountTransactions() error = %v", err)
+	}
+	var memos []string
+	for _, tx := range txs {
+		memos = append(memos, tx.Memo)
+	}
+	if want := []string{"payment:task:task-1", "payment:task:task-2", "payment:task:task-4"}; fmt.Sprint(memos) != fmt.Sprint(want) {
+		t.Errorf("memos = %v, want %v", memos, want)
+	}
+
+	txID, err := mirror.ParseTransactionID(txs[0].TransactionID)
+	if err != nil {
+		t.Fatalf("ParseTransactionID() error = %v", err)
+	}
+	if mirror.TransactionIDString(txID) != txs[0].TransactionID {
+		t.Errorf("ParseTransactionID(%q) round trip = %q", txs[0].TransactionID, mirror.TransactionIDString(txID))
+	}
+	if _, err := mirror.ParseTransactionID("0.0.2@1700000000.1"); err == nil {
+		t.Error("expected error for SDK-form transaction ID")
+	}
+}
+
+func TestNewClient_RejectsInvalidURL(t *testing.T) {
+	if _, err := mirror.NewClient("not a url", mirror.DefaultConfig()); err == nil {
+		t.Error("expected error for invalid base URL")
+	}
+	if got := mirror.NetworkURL("Testnet"); got != "https://testnet.mirrornode.hedera.com" {
+		t.Errorf("NetworkURL(testnet) = %q", got)
+	}
+}
@@ -0,0 +1,435 @@
+// Package mirrortest provides a local fake of the Mirror Node REST API for
+// tests. It serves the endpoints the mirror client uses, with the same JSON
+// shapes, filters and paging links.
+package mirrortest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/mirror"
+)
+
+// Server is a fake mirror node. Create one with NewServer and Close it when
+// done; its URL is the client base URL. All methods are safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	messages     map[string][]mirror.TopicMessage // topic ID -> messages by sequence
+	balances     map[string][]mirror.TokenBalance // account ID -> balances
+	transactions map[string][]mirror.Transaction  // transaction ID -> records
+	allowances   map[allowanceKey]int64
+	requests     int
+}
+
+type allowanceKey struct {
+	owner, spender, token string
+}
+
+// NewServer starts a fake mirror node with no data.
+func NewServer() *Server {
+	s := &Server{
+		messages:     make(map[string][]mirror.TopicMessage),
+		balances:     make(map[string][]mirror.TokenBalance),
+		transactions: make(map[string][]mirror.Transaction),
+		allowances:   make(map[allowanceKey]int64),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/topics/{id}/messages", s.handleTopicMessages)
+	mux.HandleFunc("GET /api/v1/accounts/{id}/tokens", s.handleAccountTokens)
+	mux.HandleFunc("GET /api/v1/accounts/{id}/allowances/tokens", s.handleTokenAllowances)
+	mux.HandleFunc("GET /api/v1/transactions", s.handleTransactions)
+	mux.HandleFunc("GET /api/v1/transactions/{id}", s.handleTransaction)
+	s.Server = httptest.NewServer(s.count(mux))
+	return s
+}
+
+// AddTopicMessage records a topic message.
+func (s *Server) AddTopicMessage(msg mirror.TopicMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := msg.TopicID.String()
+	s.messages[key] = append(s.messages[key], msg)
+	sort.Slice(s.messages[key], func(i, j int) bool {
+		return s.messages[key][i].SequenceNumber < s.messages[key][j].SequenceNumber
+	})
+}
+
+// SetTokenBalance sets an account's balance of a token, associating the
+// account with it.
+func (s *Server) SetTokenBalance(accountID hiero.AccountID, balance mirror.TokenBalance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := accountID.String()
+	for i, b := range s.balances[key] {
+		if b.TokenID == balance.TokenID {
+			s.balances[key][i] = balance
+			return
+		}
+	}
+	s.balances[key] = append(s.balances[key], balance)
+}
+
+// SetTokenAllowance sets how many of owner's tokens spender may transfer.
+func (s *Server) SetTokenAllowance(owner, spender hiero.AccountID, tokenID hiero.TokenID, amount int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.allowances[allowanceKey{owner.String(), spender.String(), tokenID.String()}] = amount
+}
+
+// AddTransaction records a transaction under its TransactionID.
+func (s *Server) AddTransaction(tx mirror.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transactions[tx.TransactionID] = append(s.transactions[tx.TransactionID], tx)
+}
+
+// Requests returns the number of requests served.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *Server) count(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		s.mu.Unlock()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleTopicMessages(w http.ResponseWriter, r *http.Request) {
+	topicID := r.PathValue("id")
+	query := r.URL.Query()
+	limit := pageLimit(query)
+
+	timeBounds, err := timestampBounds(query["timestamp"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	seqBounds, err := sequenceBounds(query["sequencenumber"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	var page []mirror.TopicMessage
+	more := false
+	for _, msg := range s.messages[topicID] {
+		if !timeBounds.contains(msg.ConsensusTimestamp) || !seqBounds.contains(msg.SequenceNumber) {
+			continue
+		}
+		if len(page) == limit {
+			more = true
+			break
+		}
+		page = append(page, msg)
+	}
+	s.mu.Unlock()
+
+	out := struct {
+		Messages []map[string]any `json:"messages"`
+		Links    map[string]any   `json:"links"`
+	}{Messages: []map[string]any{}, Links: map[string]any{"next": nil}}
+	for _, msg := range page {
+		out.Messages = append(out.Messages, map[string]any{
+			"consensus_timestamp": mirror.FormatTimestamp(msg.ConsensusTimestamp),
+			"message":             base64.StdEncoding.EncodeToString(msg.Message),
+			"payer_account_id":    msg.PayerAccountID.String(),
+			"running_hash":        base64.StdEncoding.EncodeToString(msg.RunningHash),
+			"sequence_number":     msg.SequenceNumber,
+			"topic_id":            msg.TopicID.String(),
+		})
+	}
+	if more {
+		next := url.Values{}
+		for key, values := range query {
+			if key != "sequencenumber" {
+				next[key] = values
+			}
+		}
+		for _, v := range query["sequencenumber"] {
+			if strings.HasPrefix(v, "lt") {
+				next.Add("sequencenumber", v)
+			}
+		}
+		next.Add("sequencenumber", "gt:"+strconv.FormatUint(page[len(page)-1].SequenceNumber, 10))
+		out.Links["next"] = r.URL.Path + "?" + next.Encode()
+	}
+	writeJSON(w, out)
+}
+
+func (s *Server) handleAccountTokens(w http.ResponseWriter, r *http.Request) {
+	accountID := r.PathValue("id")
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	balances, ok := s.balances[accountID]
+	balances = append([]mirror.TokenBalance(nil), balances...)
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	tokens := []map[string]any{}
+	for _, b := range balances {
+		if want := query.Get("token.id"); want != "" && b.TokenID.String() != want {
+			continue
+		}
+		tokens = append(tokens, map[string]any{
+			"token_id": b.TokenID.String(),
+			"balance":  b.Balance,
+			"decimals": b.Decimals,
+		})
+	}
+	writeJSON(w, map[string]any{"tokens": tokens, "links": map[string]any{"next": nil}})
+}
+
+func (s *Server) handleTokenAllowances(w http.ResponseWriter, r *http.Request) {
+	owner := r.PathValue("id")
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	allowances := []map[string]any{}
+	for key, amount := range s.allowances {
+		if key.owner != owner {
+			continue
+		}
+		if want := query.Get("spender.id"); want != "" && key.spender != want {
+			continue
+		}
+		if want := query.Get("token.id"); want != "" && key.token != want {
+			continue
+		}
+		allowances = append(allowances, map[string]any{
+			"owner":    key.owner,
+			"spender":  key.spender,
+			"token_id": key.token,
+			"amount":   amount,
+		})
+	}
+	s.mu.Unlock()
+	writeJSON(w, map[string]any{"allowances": allowances, "links": map[string]any{"next": nil}})
+}
+
+func (s *Server) handleTransaction(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	scheduled := r.URL.Query().Get("scheduled")
+
+	s.mu.Lock()
+	records := append([]mirror.Transaction(nil), s.transactions[id]...)
+	s.mu.Unlock()
+
+	txs := []map[string]any{}
+	for _, tx := range records {
+		if scheduled != "" && strconv.FormatBool(tx.Scheduled) != scheduled {
+			continue
+		}
+		txs = append(txs, transactionJSON(tx))
+	}
+	if len(txs) == 0 {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	writeJSON(w, map[string]any{"transactions": txs})
+}
+
+// handleTransactions lists transactions filtered by account.id (any token
+// transfer leg), timestamp and transactiontype, in consensus order. Paging
+// continues from the last consensus timestamp.
+func (s *Server) handleTransactions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit := pageLimit(query)
+	timeBounds, err := timestampBounds(query["timestamp"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	var all []mirror.Transaction
+	for _, records := range s.transactions {
+		all = append(all, records...)
+	}
+	s.mu.Unlock()
+	sort.Slice(all, func(i, j int) bool { return all[i].ConsensusTimestamp.Before(all[j].ConsensusTimestamp) })
+
+	var page []mirror.Transaction
+	more := false
+	for _, tx := range all {
+		if !timeBounds.contains(tx.ConsensusTimestamp) {
+			continue
+		}
+		if want := query.Get("transactiontype"); want != "" && tx.Name != want {
+			continue
+		}
+		if want := query.Get("account.id"); want != "" && !involves(tx, want) {
+			continue
+		}
+		if len(page) == limit {
+			more = true
+			break
+		}
+		page = append(page, tx)
+	}
+
+	txs := []map[string]any{}
+	for _, tx := range page {
+		txs = append(txs, transactionJSON(tx))
+	}
+	links := map[string]any{"next": nil}
+	if more {
+		next := url.Values{}
+		for key, values := range query {
+			if key != "timestamp" {
+				next[key] = values
+			}
+		}
+		for _, v := range query["timestamp"] {
+			if strings.HasPrefix(v, "lt") {
+				next.Add("timestamp", v)
+			}
+		}
+		next.Add("timestamp", "gt:"+mirror.FormatTimestamp(page[len(page)-1].ConsensusTimestamp))
+		links["next"] = r.URL.Path + "?" + next.Encode()
+	}
+	writeJSON(w, map[string]any{"transactions": txs, "links": links})
+}
+
+func involves(tx mirror.Transaction, accountID string) bool {
+	for _, t := range tx.TokenTransfers {
+		if t.AccountID.String() == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+func transactionJSON(tx mirror.Transaction) map[string]any {
+	transfers := []map[string]any{}
+	for _, t := range tx.TokenTransfers {
+		transfers = append(transfers, map[string]any{
+			"token_id": t.TokenID.String(),
+			"account":  t.AccountID.String(),
+			"amount":   t.Amount,
+		})
+	}
+	return map[string]any{
+		"transaction_id":      tx.TransactionID,
+		"consensus_timestamp": mirror.FormatTimestamp(tx.ConsensusTimestamp),
+		"name":                tx.Name,
+		"result":              tx.Result,
+		"memo_base64":         base64.StdEncoding.EncodeToString([]byte(tx.Memo)),
+		"scheduled":           tx.Scheduled,
+		"token_transfers":     transfers,
+	}
+}
+
+func pageLimit(query url.Values) int {
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 || limit > 100 {
+		return 25 // the mirror node's default page size
+	}
+	return limit
+}
+
+// bounds is an inclusive range; a nil end is unbounded.
+type bounds[T any] struct {
+	lo, hi *T
+	less   func(a, b T) bool
+}
+
+func (b bounds[T]) contains(v T) bool {
+	if b.lo != nil && b.less(v, *b.lo) {
+		return false
+	}
+	if b.hi != nil && b.less(*b.hi, v) {
+		return false
+	}
+	return true
+}
+
+func timestampBounds(filters []string) (bounds[time.Time], error) {
+	b := bounds[time.Time]{less: time.Time.Before}
+	for _, f := range filters {
+		op, value, _ := strings.Cut(f, ":")
+		secs, nanos, _ := strings.Cut(value, ".")
+		sec, err := strconv.ParseInt(secs, 10, 64)
+		if err != nil {
+			return b, fmt.Errorf("invalid timestamp filter %q", f)
+		}
+		nsec, _ := strconv.ParseInt((nanos + "000000000")[:9], 10, 64)
+		t := time.Unix(sec, nsec)
+		switch op {
+		case "gte":
+			b.lo = &t
+		case "gt":
+			t = t.Add(time.Nanosecond)
+			b.lo = &t
+		case "lte":
+			b.hi = &t
+		case "lt":
+			t = t.Add(-time.Nanosecond)
+			b.hi = &t
+		default:
+			return b, fmt.Errorf("unsupported timestamp filter %q", f)
+		}
+	}
+	return b, nil
+}
+
+func sequenceBounds(filters []string) (bounds[uint64], error) {
+	b := bounds[uint64]{less: func(a, b uint64) bool { return a < b }}
+	for _, f := range filters {
+		op, value, _ := strings.Cut(f, ":")
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return b, fmt.Errorf("invalid sequence number filter %q", f)
+		}
+		switch op {
+		case "gte":
+			b.lo = &n
+		case "gt":
+			n++
+			b.lo = &n
+		case "lte":
+			b.hi = &n
+		case "lt":
+			n--
+			b.hi = &n
+		default:
+			return b, fmt.Errorf("unsupported sequence number filter %q", f)
+		}
+	}
+	return b, nil
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"_status": map[string]any{"messages": []map[string]string{{"message": message}}},
+	})
+}
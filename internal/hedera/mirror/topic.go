@@ -0,0 +1,129 @@
+package mirror
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+type topicMessagesResponse struct {
+	Messages []topicMessageJSON `json:"messages"`
+	Links    links              `json:"links"`
+}
+
+type topicMessageJSON struct {
+	ConsensusTimestamp string `json:"consensus_timestamp"`
+	Message            string `json:"message"`
+	PayerAccountID     string `json:"payer_account_id"`
+	RunningHash        string `json:"running_hash"`
+	SequenceNumber     uint64 `json:"sequence_number"`
+	TopicID            string `json:"topic_id"`
+}
+
+// TopicMessages returns the topic's messages in q's range in consensus
+// order, following pages until the range or q.Limit is exhausted.
+func (c *Client) TopicMessages(ctx context.Context, topicID hiero.TopicID, q TopicQuery) ([]TopicMessage, error) {
+	var messages []TopicMessage
+	err := c.EachTopicMessage(ctx, topicID, q, func(msg TopicMessage) error {
+		messages = append(messages, msg)
+		return nil
+	})
+	return messages, err
+}
+
+// EachTopicMessage calls fn for each of the topic's messages in q's range in
+// consensus order, fetching one page at a time. An error from fn stops the
+// walk and is returned.
+func (c *Client) EachTopicMessage(ctx context.Context, topicID hiero.TopicID, q TopicQuery, fn func(TopicMessage) error) error {
+	path := fmt.Sprintf("/api/v1/topics/%s/messages?%s", topicID, c.topicParams(q).Encode())
+	seen := 0
+
+	for path != "" {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("list messages on topic %s: %w", topicID, err)
+		}
+
+		var page topicMessagesResponse
+		if err := c.get(ctx, path, &page); err != nil {
+			return fmt.Errorf("list messages on topic %s: %w", topicID, err)
+		}
+		for _, raw := range page.Messages {
+			msg, err := raw.decode()
+			if err != nil {
+				return fmt.Errorf("list messages on topic %s: %w: %v", topicID, ErrDecodeFailed, err)
+			}
+			if err := fn(msg); err != nil {
+				return err
+			}
+			seen++
+			if q.Limit > 0 && seen >= q.Limit {
+				return nil
+			}
+		}
+
+		path = ""
+		if page.Links.Next != nil && len(page.Messages) > 0 {
+			path = *page.Links.Next
+		}
+	}
+	return nil
+}
+
+func (c *Client) topicParams(q TopicQuery) url.Values {
+	params := url.Values{}
+	params.Set("order", "asc")
+	limit := c.pageSize
+	if q.Limit > 0 && q.Limit < limit {
+		limit = q.Limit
+	}
+	params.Set("limit", strconv.Itoa(limit))
+	if !q.StartTime.IsZero() {
+		params.Add("timestamp", "gte:"+FormatTimestamp(q.StartTime))
+	}
+	if !q.EndTime.IsZero() {
+		params.Add("timestamp", "lte:"+FormatTimestamp(q.EndTime))
+	}
+	if q.StartSequence > 0 {
+		params.Add("sequencenumber", "gte:"+strconv.FormatUint(q.StartSequence, 10))
+	}
+	if q.EndSequence > 0 {
+		params.Add("sequencenumber", "lte:"+strconv.FormatUint(q.EndSequence, 10))
+	}
+	return params
+}
+
+func (m topicMessageJSON) decode() (TopicMessage, error) {
+	topicID, err := hiero.TopicIDFromString(m.TopicID)
+	if err != nil {
+		return TopicMessage{}, fmt.Errorf("topic id %q: %w", m.TopicID, err)
+	}
+	ts, err := parseTimestamp(m.ConsensusTimestamp)
+	if err != nil {
+		return TopicMessage{}, err
+	}
+	contents, err := base64.StdEncoding.DecodeString(m.Message)
+	if err != nil {
+		return TopicMessage{}, fmt.Errorf("message %d contents: %w", m.SequenceNumber, err)
+	}
+	msg := TopicMessage{
+		TopicID:            topicID,
+		ConsensusTimestamp: ts,
+		SequenceNumber:     m.SequenceNumber,
+		Message:            contents,
+	}
+	if m.PayerAccountID != "" {
+		if msg.PayerAccountID, err = hiero.AccountIDFromString(m.PayerAccountID); err != nil {
+			return TopicMessage{}, fmt.Errorf("payer %q: %w", m.PayerAccountID, err)
+		}
+	}
+	if m.RunningHash != "" {
+		if msg.RunningHash, err = base64.StdEncoding.DecodeString(m.RunningHash); err != nil {
+			return TopicMessage{}, fmt.Errorf("message %d running hash: %w", m.SequenceNumber, err)
+		}
+	}
+	return msg, nil
+}
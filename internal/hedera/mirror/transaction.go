@@ -0,0 +1,188 @@
+package mirror
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+type transactionsResponse struct {
+	Transactions []transactionJSON `json:"transactions"`
+	Links        links             `json:"links"`
+}
+
+type transactionJSON struct {
+	TransactionID      string              `json:"transaction_id"`
+	ConsensusTimestamp string              `json:"consensus_timestamp"`
+	Name               string              `json:"name"`
+	Result             string              `json:"result"`
+	MemoBase64         string              `json:"memo_base64"`
+	Scheduled          bool                `json:"scheduled"`
+	TokenTransfers     []tokenTransferJSON `json:"token_transfers"`
+}
+
+type tokenTransferJSON struct {
+	TokenID string `json:"token_id"`
+	Account string `json:"account"`
+	Amount  int64  `json:"amount"`
+}
+
+// TransactionIDString formats a transaction ID the way the mirror node
+// does, e.g. "0.0.2-1700000000-000000001".
+func TransactionIDString(txID hiero.TransactionID) string {
+	if txID.AccountID == nil || txID.ValidStart == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s-%d-%09d", txID.AccountID, txID.ValidStart.Unix(), txID.ValidStart.Nanosecond())
+}
+
+// ParseTransactionID parses a transaction ID in mirror node form.
+func ParseTransactionID(s string) (hiero.TransactionID, error) {
+	rest, nanos, ok1 := cutLast(s, "-")
+	account, secs, ok2 := cutLast(rest, "-")
+	if !ok1 || !ok2 {
+		return hiero.TransactionID{}, fmt.Errorf("parse transaction ID %q: want {account}-{seconds}-{nanos}", s)
+	}
+	accountID, err := hiero.AccountIDFromString(account)
+	if err != nil {
+		return hiero.TransactionID{}, fmt.Errorf("parse transaction ID %q: %w", s, err)
+	}
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return hiero.TransactionID{}, fmt.Errorf("parse transaction ID %q: %w", s, err)
+	}
+	nsec, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return hiero.TransactionID{}, fmt.Errorf("parse transaction ID %q: %w", s, err)
+	}
+	return hiero.NewTransactionIDWithValidStart(accountID, time.Unix(sec, nsec)), nil
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// TransactionQuery selects transactions involving an account. Zero fields
+// are unbounded.
+type TransactionQuery struct {
+	// StartTime and EndTime bound the consensus timestamp, inclusive.
+	StartTime time.Time
+	EndTime   time.Time
+
+	// Type restricts the transaction type, e.g. "CRYPTOTRANSFER".
+	Type string
+}
+
+// AccountTransactions returns the transactions involving an account in q's
+// range in consensus order, following pages until the range is exhausted.
+func (c *Client) AccountTransactions(ctx context.Context, accountID hiero.AccountID, q TransactionQuery) ([]Transaction, error) {
+	params := url.Values{}
+	params.Set("account.id", accountID.String())
+	params.Set("order", "asc")
+	params.Set("limit", strconv.Itoa(c.pageSize))
+	if !q.StartTime.IsZero() {
+		params.Add("timestamp", "gte:"+FormatTimestamp(q.StartTime))
+	}
+	if !q.EndTime.IsZero() {
+		params.Add("timestamp", "lte:"+FormatTimestamp(q.EndTime))
+	}
+	if q.Type != "" {
+		params.Set("transactiontype", q.Type)
+	}
+	path := "/api/v1/transactions?" + params.Encode()
+
+	var txs []Transaction
+	for path != "" {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("list transactions of account %s: %w", accountID, err)
+		}
+
+		var page transactionsResponse
+		if err := c.get(ctx, path, &page); err != nil {
+			return nil, fmt.Errorf("list transactions of account %s: %w", accountID, err)
+		}
+		for _, raw := range page.Transactions {
+			tx, err := raw.decode()
+			if err != nil {
+				return nil, fmt.Errorf("list transactions of account %s: %w: %v", accountID, ErrDecodeFailed, err)
+			}
+			txs = append(txs, *tx)
+		}
+
+		path = ""
+		if page.Links.Next != nil && len(page.Transactions) > 0 {
+			path = *page.Links.Next
+		}
+	}
+	return txs, nil
+}
+
+// Transaction looks up a transaction by ID. For a scheduled transaction ID
+// the executed scheduled transaction is returned. It returns an error
+// wrapping ErrNotFound if the mirror node has no record of it yet.
+func (c *Client) Transaction(ctx context.Context, txID hiero.TransactionID) (*Transaction, error) {
+	id := TransactionIDString(txID)
+	if id == "" {
+		return nil, fmt.Errorf("get transaction %s: incomplete transaction ID", txID)
+	}
+	path := "/api/v1/transactions/" + id
+	if txID.GetScheduled() {
+		path += "?scheduled=true"
+	}
+
+	var resp transactionsResponse
+	if err := c.get(ctx, path, &resp); err != nil {
+		return nil, fmt.Errorf("get transaction %s: %w", id, err)
+	}
+	for _, raw := range resp.Transactions {
+		if raw.Scheduled != txID.GetScheduled() {
+			continue
+		}
+		tx, err := raw.decode()
+		if err != nil {
+			return nil, fmt.Errorf("get transaction %s: %w: %v", id, ErrDecodeFailed, err)
+		}
+		return tx, nil
+	}
+	return nil, fmt.Errorf("get transaction %s: %w", id, ErrNotFound)
+}
+
+func (t transactionJSON) decode() (*Transaction, error) {
+	ts, err := parseTimestamp(t.ConsensusTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	memo, err := base64.StdEncoding.DecodeString(t.MemoBase64)
+	if err != nil {
+		return nil, fmt.Errorf("memo: %w", err)
+	}
+	tx := &Transaction{
+		TransactionID:      t.TransactionID,
+		ConsensusTimestamp: ts,
+		Name:               t.Name,
+		Result:             t.Result,
+		Memo:               string(memo),
+		Scheduled:          t.Scheduled,
+	}
+	for _, raw := range t.TokenTransfers {
+		tokenID, err := hiero.TokenIDFromString(raw.TokenID)
+		if err != nil {
+			return nil, fmt.Errorf("token id %q: %w", raw.TokenID, err)
+		}
+		accountID, err := hiero.AccountIDFromString(raw.Account)
+		if err != nil {
+			return nil, fmt.Errorf("account %q: %w", raw.Account, err)
+		}
+		tx.TokenTransfers = append(tx.TokenTransfers, TokenTransfer{TokenID: tokenID, AccountID: accountID, Amount: raw.Amount})
+	}
+	return tx, nil
+}
@@ -0,0 +1,71 @@
+package mirror
+
+import (
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+// TopicQuery selects a range of topic messages. Zero fields are unbounded.
+type TopicQuery struct {
+	// StartTime and EndTime bound the consensus timestamp, inclusive.
+	StartTime time.Time
+	EndTime   time.Time
+
+	// StartSequence and EndSequence bound the topic sequence number, inclusive.
+	StartSequence uint64
+	EndSequence   uint64
+
+	// Limit caps the number of messages returned across all pages.
+	Limit int
+}
+
+// TopicMessage is a message as recorded by the mirror node.
+type TopicMessage struct {
+	TopicID            hiero.TopicID
+	ConsensusTimestamp time.Time
+	SequenceNumber     uint64
+	PayerAccountID     hiero.AccountID
+
+	// Message is the raw message contents, usually a JSON hcs.Envelope or
+	// one chunk frame of one.
+	Message []byte
+
+	RunningHash []byte
+}
+
+// TokenBalance is an account's balance of one token it is associated with.
+type TokenBalance struct {
+	TokenID  hiero.TokenID
+	Balance  int64
+	Decimals uint32
+}
+
+// TokenTransfer is one account's side of a token transfer.
+type TokenTransfer struct {
+	TokenID   hiero.TokenID
+	AccountID hiero.AccountID
+	Amount    int64 // negative for the sender
+}
+
+// Transaction is a transaction as recorded by the mirror node.
+type Transaction struct {
+	// TransactionID is in mirror node form, e.g. "0.0.2-1700000000-000000001".
+	TransactionID      string
+	ConsensusTimestamp time.Time
+
+	// Name is the transaction type, e.g. "CRYPTOTRANSFER".
+	Name string
+
+	// Result is the consensus status, e.g. "SUCCESS".
+	Result string
+
+	Memo           string
+	Scheduled      bool
+	TokenTransfers []TokenTransfer
+}
+
+// Succeeded reports whether the transaction reached consensus successfully.
+func (t Transaction) Succeeded() bool {
+	return t.Result == "SUCCESS"
+}
@@ -16,6 +16,33 @@ type ScheduleCreator interface {
 	ScheduleInfo(ctx context.Context, scheduleID hiero.ScheduleID) (*ScheduleMetadata, error)
 }
 
+// ExpiringScheduleCreator creates scheduled transactions that expire at a
+// chosen time rather than after the network's default lifetime.
+// Used by the coordinator to hold escrowed payments for as long as a task.
+type ExpiringScheduleCreator interface {
+	ScheduleCreator
+
+	// CreateScheduleExpiring creates a scheduled transaction that is removed
+	// unexecuted at expiresAt. A zero expiresAt uses the network default.
+	CreateScheduleExpiring(ctx context.Context, innerTx hiero.TransactionInterface, memo string, expiresAt time.Time) (hiero.ScheduleID, error)
+}
+
+// ScheduleSigner completes or cancels pending scheduled transactions.
+// Used by the coordinator to release or cancel escrowed payments.
+type ScheduleSigner interface {
+	// SignSchedule adds the signer's key to a scheduled transaction. Once
+	// every required signature is present the network executes it; the
+	// executed transaction's ID is returned, or an error if it did not
+	// execute or failed. The error wraps ErrScheduleGone if the schedule
+	// was deleted or has expired.
+	SignSchedule(ctx context.Context, scheduleID hiero.ScheduleID) (hiero.TransactionID, error)
+
+	// DeleteSchedule deletes a scheduled transaction that has not executed.
+	// As with SignSchedule, the error wraps ErrScheduleGone if it no longer
+	// exists.
+	DeleteSchedule(ctx context.Context, scheduleID hiero.ScheduleID) error
+}
+
 // HeartbeatRunner manages a periodic heartbeat using the Hedera Schedule Service.
 // The heartbeat proves agent liveness by submitting scheduled transactions at
 // a configurable interval.
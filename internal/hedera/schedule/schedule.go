@@ -2,14 +2,24 @@ package schedule
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
 )
 
-// ScheduleService implements the ScheduleCreator interface using the Hiero (Hedera) SDK.
+// ErrScheduleGone is wrapped by errors for schedules that were deleted or
+// have expired. The network removes expired schedules, so both are reported
+// as INVALID_SCHEDULE_ID or SCHEDULE_ALREADY_DELETED.
+var ErrScheduleGone = errors.New("schedule deleted or expired")
+
+// ScheduleService implements the ScheduleCreator and ScheduleSigner
+// interfaces using the Hiero (Hedera) SDK. Schedules it creates carry the
+// operator's key as admin key, so they can be deleted.
 type ScheduleService struct {
-	client *hiero.Client
+	client     *hiero.Client
+	signingKey *hiero.PrivateKey
 }
 
 // NewScheduleService creates a new ScheduleService.
@@ -19,6 +29,12 @@ func NewScheduleService(client *hiero.Client) *ScheduleService {
 
 // CreateSchedule creates a new scheduled transaction and returns its ID.
 func (s *ScheduleService) CreateSchedule(ctx context.Context, innerTx hiero.TransactionInterface, memo string) (hiero.ScheduleID, error) {
+	return s.CreateScheduleExpiring(ctx, innerTx, memo, time.Time{})
+}
+
+// CreateScheduleExpiring creates a scheduled transaction that expires at
+// expiresAt, or after the network default if it is zero.
+func (s *ScheduleService) CreateScheduleExpiring(ctx context.Context, innerTx hiero.TransactionInterface, memo string, expiresAt time.Time) (hiero.ScheduleID, error) {
 	if err := ctx.Err(); err != nil {
 		return hiero.ScheduleID{}, fmt.Errorf("create schedule: %w", err)
 	}
@@ -29,9 +45,15 @@ func (s *ScheduleService) CreateSchedule(ctx context.Context, innerTx hiero.Tran
 		return hiero.ScheduleID{}, fmt.Errorf("create schedule with memo %q: set inner tx: %w", memo, err)
 	}
 
-	frozen, err := scheduleTx.
-		SetScheduleMemo(memo).
-		FreezeWith(s.client)
+	scheduleTx.SetScheduleMemo(memo)
+	if !expiresAt.IsZero() {
+		scheduleTx.SetExpirationTime(expiresAt)
+	}
+	if s.client != nil && s.client.GetOperatorAccountID() != (hiero.AccountID{}) {
+		scheduleTx.SetAdminKey(s.client.GetOperatorPublicKey())
+	}
+
+	frozen, err := scheduleTx.FreezeWith(s.client)
 	if err != nil {
 		return hiero.ScheduleID{}, fmt.Errorf("create schedule with memo %q: freeze: %w", memo, err)
 	}
@@ -74,5 +96,106 @@ func (s *ScheduleService) ScheduleInfo(ctx context.Context, scheduleID hiero.Sch
 	}, nil
 }
 
-// Compile-time interface compliance check.
-var _ ScheduleCreator = (*ScheduleService)(nil)
+// SetSigningKey configures the key SignSchedule signs with, in addition to
+// the operator's. It is the key the scheduled transactions wait for, such as
+// the key of the account they debit.
+func (s *ScheduleService) SetSigningKey(key hiero.PrivateKey) {
+	s.signingKey = &key
+}
+
+// SignSchedule signs a scheduled transaction and returns the ID of the
+// transaction it executed.
+func (s *ScheduleService) SignSchedule(ctx context.Context, scheduleID hiero.ScheduleID) (hiero.TransactionID, error) {
+	if err := ctx.Err(); err != nil {
+		return hiero.TransactionID{}, fmt.Errorf("sign schedule %s: %w", scheduleID, err)
+	}
+
+	frozen, err := hiero.NewScheduleSignTransaction().
+		SetScheduleID(scheduleID).
+		FreezeWith(s.client)
+	if err != nil {
+		return hiero.TransactionID{}, fmt.Errorf("sign schedule %s: freeze: %w", scheduleID, err)
+	}
+	if s.signingKey != nil {
+		frozen = frozen.Sign(*s.signingKey)
+	}
+
+	resp, err := frozen.Execute(s.client)
+	if err != nil {
+		return hiero.TransactionID{}, fmt.Errorf("sign schedule %s: execute: %w", scheduleID, scheduleError(err))
+	}
+
+	receipt, err := resp.GetReceipt(s.client)
+	if err != nil {
+		return hiero.TransactionID{}, fmt.Errorf("sign schedule %s: receipt: %w", scheduleID, scheduleError(err))
+	}
+	if receipt.ScheduledTransactionID == nil {
+		return hiero.TransactionID{}, fmt.Errorf("sign schedule %s: receipt contained nil scheduled transaction ID", scheduleID)
+	}
+
+	// The sign receipt only covers the signature; the scheduled
+	// transaction's own receipt says whether it executed and succeeded.
+	executed, err := hiero.NewTransactionReceiptQuery().
+		SetTransactionID(*receipt.ScheduledTransactionID).
+		Execute(s.client)
+	if err != nil {
+		return hiero.TransactionID{}, fmt.Errorf("sign schedule %s: scheduled transaction receipt: %w", scheduleID, err)
+	}
+	if executed.Status != hiero.StatusSuccess {
+		return hiero.TransactionID{}, fmt.Errorf("sign schedule %s: scheduled transaction %s: %s",
+			scheduleID, receipt.ScheduledTransactionID, executed.Status)
+	}
+
+	return *receipt.ScheduledTransactionID, nil
+}
+
+// DeleteSchedule deletes a scheduled transaction that has not executed,
+// signed by the operator as the schedule's admin.
+func (s *ScheduleService) DeleteSchedule(ctx context.Context, scheduleID hiero.ScheduleID) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("delete schedule %s: %w", scheduleID, err)
+	}
+
+	frozen, err := hiero.NewScheduleDeleteTransaction().
+		SetScheduleID(scheduleID).
+		FreezeWith(s.client)
+	if err != nil {
+		return fmt.Errorf("delete schedule %s: freeze: %w", scheduleID, err)
+	}
+
+	resp, err := frozen.Execute(s.client)
+	if err != nil {
+		return fmt.Errorf("delete schedule %s: execute: %w", scheduleID, scheduleError(err))
+	}
+
+	if _, err := resp.GetReceipt(s.client); err != nil {
+		return fmt.Errorf("delete schedule %s: receipt: %w", scheduleID, scheduleError(err))
+	}
+	return nil
+}
+
+// scheduleError adds ErrScheduleGone to errors reporting a schedule that no
+// longer exists.
+func scheduleError(err error) error {
+	var status hiero.Status
+	var precheck hiero.ErrHederaPreCheckStatus
+	var receipt hiero.ErrHederaReceiptStatus
+	switch {
+	case errors.As(err, &precheck):
+		status = precheck.Status
+	case errors.As(err, &receipt):
+		status = receipt.Status
+	default:
+		return err
+	}
+	if status == hiero.StatusInvalidScheduleID || status == hiero.StatusScheduleAlreadyDeleted {
+		return fmt.Errorf("%w: %w", ErrScheduleGone, err)
+	}
+	return err
+}
+
+// Compile-time interface compliance checks.
+var (
+	_ ExpiringScheduleCreator = (*ScheduleService)(nil)
+	_ ScheduleSigner          = (*ScheduleService)(nil)
+)
@@ -0,0 +1,261 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+)
+
+const (
+	// subscriptionBuffer is the channel buffer for simulated subscriptions,
+	// matching the live subscriber's default.
+	subscriptionBuffer = 100
+
+	// subscribeLookback matches the live subscriber's default lookback, so
+	// messages published while a component is starting are still delivered.
+	subscribeLookback = 30 * time.Second
+)
+
+// topic is a simulated HCS topic: an ordered message log plus the channels
+// of subscribers waiting for new messages.
+type topic struct {
+	memo     string
+	deleted  bool
+	messages []hiero.TopicMessage
+	waiters  []chan struct{}
+}
+
+// CreateTopic creates a new topic with the given memo and returns its ID.
+func (n *Network) CreateTopic(ctx context.Context, memo string) (hiero.TopicID, error) {
+	if err := ctx.Err(); err != nil {
+		return hiero.TopicID{}, fmt.Errorf("create topic: %w", err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	id := hiero.TopicID{Topic: n.allocEntityLocked()}
+	n.topics[id.String()] = &topic{memo: memo}
+	return id, nil
+}
+
+// DeleteTopic deletes a topic. Existing subscriptions end.
+func (n *Network) DeleteTopic(ctx context.Context, topicID hiero.TopicID) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("delete topic %s: %w", topicID, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	t, err := n.topicLocked(topicID)
+	if err != nil {
+		return fmt.Errorf("delete topic %s: %w", topicID, err)
+	}
+	t.deleted = true
+	t.notifyLocked()
+	return nil
+}
+
+// TopicInfo returns a topic's memo and current sequence number.
+func (n *Network) TopicInfo(ctx context.Context, topicID hiero.TopicID) (*hcs.TopicMetadata, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("topic info %s: %w", topicID, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	t, err := n.topicLocked(topicID)
+	if err != nil {
+		return nil, fmt.Errorf("topic info %s: %w", topicID, err)
+	}
+	return &hcs.TopicMetadata{
+		TopicID:        topicID,
+		Memo:           t.memo,
+		SequenceNumber: uint64(len(t.messages)),
+	}, nil
+}
+
+// Publish serializes the envelope and appends it to the topic with the next
+// sequence number and consensus timestamp.
+func (n *Network) Publish(ctx context.Context, topicID hiero.TopicID, msg hcs.Envelope) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("publish to topic %s: %w", topicID, err)
+	}
+
+	data, err := msg.Marshal()
+	if err != nil {
+		return fmt.Errorf("publish to topic %s: marshal type %s: %w", topicID, msg.Type, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	t, err := n.topicLocked(topicID)
+	if err != nil {
+		return fmt.Errorf("publish to topic %s type %s: %w", topicID, msg.Type, err)
+	}
+	if t.deleted {
+		return fmt.Errorf("publish to topic %s type %s: %w", topicID, msg.Type, ErrTopicDeleted)
+	}
+
+	t.messages = append(t.messages, hiero.TopicMessage{
+		ConsensusTimestamp: n.consensusLocked(),
+		Contents:           data,
+		SequenceNumber:     uint64(len(t.messages) + 1),
+	})
+	t.notifyLocked()
+	return nil
+}
+
+// Messages returns a copy of every message published to a topic, in
+// consensus order.
+func (n *Network) Messages(topicID hiero.TopicID) ([]hiero.TopicMessage, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	t, err := n.topicLocked(topicID)
+	if err != nil {
+		return nil, fmt.Errorf("messages for topic %s: %w", topicID, err)
+	}
+	return append([]hiero.TopicMessage(nil), t.messages...), nil
+}
+
+// Subscribe delivers messages published to the topic from 30 seconds before
+// the call onwards, until the context is cancelled or the topic is deleted.
+func (n *Network) Subscribe(ctx context.Context, topicID hiero.TopicID) (<-chan hcs.Envelope, <-chan error) {
+	opts := hcs.ReplayOptions{StartTime: n.clock().UTC().Add(-subscribeLookback)}
+	return n.stream(ctx, topicID, opts, false)
+}
+
+// SubscribeFrom delivers the topic's messages from the starting point in opts
+// onwards, until the context is cancelled or the topic is deleted.
+func (n *Network) SubscribeFrom(ctx context.Context, topicID hiero.TopicID, opts hcs.ReplayOptions) (<-chan hcs.Envelope, <-chan error) {
+	opts.EndTime = time.Time{}
+	return n.stream(ctx, topicID, opts, false)
+}
+
+// Replay delivers the topic's messages from the starting point in opts up to
+// opts.EndTime (or the latest message if zero), then closes both channels.
+func (n *Network) Replay(ctx context.Context, topicID hiero.TopicID, opts hcs.ReplayOptions) (<-chan hcs.Envelope, <-chan error) {
+	return n.stream(ctx, topicID, opts, true)
+}
+
+// stream feeds a subscription from the topic log. Bounded streams stop at
+// opts.EndTime, or the end of the log as it was when they started.
+func (n *Network) stream(
+	ctx context.Context,
+	topicID hiero.TopicID,
+	opts hcs.ReplayOptions,
+	bounded bool,
+) (<-chan hcs.Envelope, <-chan error) {
+	msgCh := make(chan hcs.Envelope, subscriptionBuffer)
+	errCh := make(chan error, subscriptionBuffer)
+
+	end := opts.EndTime
+	if bounded && end.IsZero() {
+		n.mu.Lock()
+		end = n.lastConsensus
+		n.mu.Unlock()
+	}
+
+	go func() {
+		defer close(msgCh)
+		defer close(errCh)
+
+		cursor := 0
+		for {
+			n.mu.Lock()
+			t, err := n.topicLocked(topicID)
+			if err != nil {
+				n.mu.Unlock()
+				errCh <- fmt.Errorf("subscribe to topic %s: %w", topicID, err)
+				return
+			}
+			pending := append([]hiero.TopicMessage(nil), t.messages[cursor:]...)
+			deleted := t.deleted
+			var wake chan struct{}
+			if !bounded && !deleted {
+				wake = make(chan struct{})
+				t.waiters = append(t.waiters, wake)
+			}
+			n.mu.Unlock()
+
+			cursor += len(pending)
+			for _, message := range pending {
+				if bounded && message.ConsensusTimestamp.After(end) {
+					return
+				}
+				if !included(message, opts) {
+					continue
+				}
+				env, err := hcs.UnmarshalEnvelope(message.Contents)
+				if err != nil {
+					select {
+					case errCh <- fmt.Errorf("deserialize from topic %s seq %d: %w", topicID, message.SequenceNumber, err):
+					default:
+					}
+					continue
+				}
+				env.ConsensusTimestamp = message.ConsensusTimestamp
+				env.TopicSequenceNum = message.SequenceNumber
+				select {
+				case msgCh <- *env:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if bounded || deleted {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-wake:
+			}
+		}
+	}()
+
+	return msgCh, errCh
+}
+
+// included reports whether a message is at or after the replay start.
+func included(message hiero.TopicMessage, opts hcs.ReplayOptions) bool {
+	if message.SequenceNumber < opts.StartSequence {
+		return false
+	}
+	return opts.StartTime.IsZero() || !message.ConsensusTimestamp.Before(opts.StartTime)
+}
+
+// topicLocked looks up a topic by ID. n.mu must be held.
+func (n *Network) topicLocked(topicID hiero.TopicID) (*topic, error) {
+	t, ok := n.topics[topicID.String()]
+	if !ok {
+		return nil, ErrTopicNotFound
+	}
+	return t, nil
+}
+
+// notifyLocked wakes every subscriber waiting on the topic. n.mu must be held.
+func (t *topic) notifyLocked() {
+	for _, wake := range t.waiters {
+		close(wake)
+	}
+	t.waiters = nil
+}
+
+// Compile-time interface compliance checks.
+var (
+	_ hcs.TopicCreator         = (*Network)(nil)
+	_ hcs.MessagePublisher     = (*Network)(nil)
+	_ hcs.MessageSubscriber    = (*Network)(nil)
+	_ hcs.MessageReplayer      = (*Network)(nil)
+	_ hcs.PositionedSubscriber = (*Network)(nil)
+)
@@ -0,0 +1,165 @@
+package sim
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hcs"
+)
+
+func heartbeatFrom(sender string, seq uint64) hcs.Envelope {
+	return hcs.Envelope{Type: hcs.MessageTypeHeartbeat, Sender: sender, SequenceNum: seq, Timestamp: time.Now()}
+}
+
+func TestPublish_AssignsSequenceAndConsensusOrder(t *testing.T) {
+	ctx := context.Background()
+	n := NewNetwork()
+	fixed := time.Date(2026, 2, 18, 14, 0, 0, 0, time.UTC)
+	n.clock = func() time.Time { return fixed }
+
+	topicID, err := n.CreateTopic(ctx, "festival-tasks")
+	if err != nil {
+		t.Fatalf("CreateTopic: %v", err)
+	}
+	for i := uint64(1); i <= 3; i++ {
+		if err := n.Publish(ctx, topicID, heartbeatFrom("agent-1", i)); err != nil {
+			t.Fatalf("Publish %d: %v", i, err)
+		}
+	}
+
+	messages, err := n.Messages(topicID)
+	if err != nil {
+		t.Fatalf("Messages: %v", err)
+	}
+	for i, m := range messages {
+		if m.SequenceNumber != uint64(i+1) {
+			t.Errorf("message %d: sequence = %d, want %d", i, m.SequenceNumber, i+1)
+		}
+		if i > 0 && !m.ConsensusTimestamp.After(messages[i-1].ConsensusTimestamp) {
+			t.Errorf("message %d: consensus timestamp %v not after %v", i, m.ConsensusTimestamp, messages[i-1].ConsensusTimestamp)
+		}
+	}
+
+	info, err := n.TopicInfo(ctx, topicID)
+	if err != nil {
+		t.Fatalf("TopicInfo: %v", err)
+	}
+	if info.SequenceNumber != 3 || info.Memo != "festival-tasks" {
+		t.Errorf("TopicInfo = %+v, want sequence 3 and memo festival-tasks", info)
+	}
+}
+
+func TestPublish_UnknownOrDeletedTopic(t *testing.T) {
+	ctx := context.Background()
+	n := NewNetwork()
+
+	topicID, err := n.CreateTopic(ctx, "t")
+	if err != nil {
+		t.Fatalf("CreateTopic: %v", err)
+	}
+	if err := n.DeleteTopic(ctx, topicID); err != nil {
+		t.Fatalf("DeleteTopic: %v", err)
+	}
+	if err := n.Publish(ctx, topicID, heartbeatFrom("a", 1)); !errors.Is(err, ErrTopicDeleted) {
+		t.Errorf("publish to deleted topic: err = %v, want ErrTopicDeleted", err)
+	}
+
+	topicID.Topic += 100
+	if err := n.Publish(ctx, topicID, heartbeatFrom("a", 1)); !errors.Is(err, ErrTopicNotFound) {
+		t.Errorf("publish to unknown topic: err = %v, want ErrTopicNotFound", err)
+	}
+}
+
+func TestSubscribe_DeliversLiveMessagesInOrder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	n := NewNetwork()
+
+	topicID, err := n.CreateTopic(ctx, "agent-status")
+	if err != nil {
+		t.Fatalf("CreateTopic: %v", err)
+	}
+	msgCh, _ := n.Subscribe(ctx, topicID)
+
+	for i := uint64(1); i <= 5; i++ {
+		if err := n.Publish(ctx, topicID, heartbeatFrom("agent-1", i)); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+
+	for want := uint64(1); want <= 5; want++ {
+		select {
+		case env := <-msgCh:
+			if env.SequenceNum != want {
+				t.Fatalf("received seq %d, want %d", env.SequenceNum, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for message %d", want)
+		}
+	}
+
+	cancel()
+	for range msgCh {
+	}
+}
+
+func TestSubscribe_IncludesRecentHistory(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	n := NewNetwork()
+	now := time.Date(2026, 2, 18, 14, 0, 0, 0, time.UTC)
+	n.clock = func() time.Time { return now }
+
+	topicID, err := n.CreateTopic(ctx, "t")
+	if err != nil {
+		t.Fatalf("CreateTopic: %v", err)
+	}
+	if err := n.Publish(ctx, topicID, heartbeatFrom("old", 1)); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	now = now.Add(time.Minute)
+	if err := n.Publish(ctx, topicID, heartbeatFrom("recent", 2)); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	now = now.Add(10 * time.Second)
+
+	msgCh, _ := n.Subscribe(ctx, topicID)
+	select {
+	case env := <-msgCh:
+		if env.Sender != "recent" {
+			t.Errorf("first message from %q, want recent (older than the lookback)", env.Sender)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for recent message")
+	}
+}
+
+func TestReplay_BoundedRange(t *testing.T) {
+	ctx := context.Background()
+	n := NewNetwork()
+
+	topicID, err := n.CreateTopic(ctx, "t")
+	if err != nil {
+		t.Fatalf("CreateTopic: %v", err)
+	}
+	for i := uint64(1); i <= 4; i++ {
+		if err := n.Publish(ctx, topicID, heartbeatFrom("agent-1", i)); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+
+	msgCh, errCh := n.Replay(ctx, topicID, hcs.ReplayOptions{StartSequence: 2})
+	var got []uint64
+	for env := range msgCh {
+		got = append(got, env.SequenceNum)
+	}
+	for err := range errCh {
+		t.Errorf("replay error: %v", err)
+	}
+
+	if len(got) != 3 || got[0] != 2 || got[2] != 4 {
+		t.Errorf("replayed %v, want [2 3 4]", got)
+	}
+}
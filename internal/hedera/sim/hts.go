@@ -0,0 +1,339 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+)
+
+// token is a simulated fungible token with per-account balances. Only
+// associated accounts may hold or receive it; the treasury is associated
+// at creation.
+type token struct {
+	meta       hts.TokenMetadata
+	balances   map[string]int64
+	associated map[string]bool
+}
+
+// Transfer is a settled token transfer recorded by the network.
+type Transfer struct {
+	TransactionID      hiero.TransactionID
+	ConsensusTimestamp time.Time
+	TokenID            hiero.TokenID
+	FromAccountID      hiero.AccountID
+	ToAccountID        hiero.AccountID
+	Amount             int64
+	Memo               string
+}
+
+// CreateFungibleToken creates a token and credits the initial supply to the
+// treasury account.
+func (n *Network) CreateFungibleToken(ctx context.Context, config hts.TokenConfig) (hiero.TokenID, error) {
+	if err := ctx.Err(); err != nil {
+		return hiero.TokenID{}, fmt.Errorf("create token %q: %w", config.Name, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	id := hiero.TokenID{Token: n.allocEntityLocked()}
+	treasury := config.TreasuryAccountID.String()
+	n.tokens[id.String()] = &token{
+		meta: hts.TokenMetadata{
+			TokenID:     id,
+			Name:        config.Name,
+			Symbol:      config.Symbol,
+			Decimals:    config.Decimals,
+			TotalSupply: config.InitialSupply,
+			TreasuryID:  config.TreasuryAccountID,
+		},
+		balances:   map[string]int64{treasury: int64(config.InitialSupply)},
+		associated: map[string]bool{treasury: true},
+	}
+	return id, nil
+}
+
+// TokenInfo returns a token's metadata.
+func (n *Network) TokenInfo(ctx context.Context, tokenID hiero.TokenID) (*hts.TokenMetadata, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("token info %s: %w", tokenID, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	tok, err := n.tokenLocked(tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("token info %s: %w", tokenID, err)
+	}
+	meta := tok.meta
+	return &meta, nil
+}
+
+// AssociateToken associates a token with an account so it can receive transfers.
+func (n *Network) AssociateToken(ctx context.Context, tokenID hiero.TokenID, accountID hiero.AccountID) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("associate token %s with account %s: %w", tokenID, accountID, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	tok, err := n.tokenLocked(tokenID)
+	if err != nil {
+		return fmt.Errorf("associate token %s with account %s: %w", tokenID, accountID, err)
+	}
+	if tok.associated[accountID.String()] {
+		return fmt.Errorf("associate token %s with account %s: %w", tokenID, accountID, ErrTokenAlreadyAssociated)
+	}
+	tok.associated[accountID.String()] = true
+	return nil
+}
+
+// Transfer moves tokens between two associated accounts. It fails without
+// changing balances if either account is not associated or the sender's
+// balance is too low.
+func (n *Network) Transfer(ctx context.Context, req hts.TransferRequest) (*hts.TransferReceipt, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("transfer token %s from %s to %s: %w",
+			req.TokenID, req.FromAccountID, req.ToAccountID, err)
+	}
+
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("transfer token %s: amount must be positive, got %d",
+			req.TokenID, req.Amount)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	fail := func(err error) (*hts.TransferReceipt, error) {
+		return nil, fmt.Errorf("transfer %d of token %s from %s to %s: %w",
+			req.Amount, req.TokenID, req.FromAccountID, req.ToAccountID, err)
+	}
+
+	tok, err := n.tokenLocked(req.TokenID)
+	if err != nil {
+		return fail(err)
+	}
+	from, to := req.FromAccountID.String(), req.ToAccountID.String()
+	if !tok.associated[from] {
+		return fail(fmt.Errorf("sender: %w", ErrTokenNotAssociated))
+	}
+	if !tok.associated[to] {
+		return fail(fmt.Errorf("recipient: %w", ErrTokenNotAssociated))
+	}
+	if tok.balances[from] < req.Amount {
+		return fail(ErrInsufficientBalance)
+	}
+
+	tok.balances[from] -= req.Amount
+	tok.balances[to] += req.Amount
+
+	ts := n.consensusLocked()
+	txID := transactionID(req.FromAccountID, ts)
+	n.transfers = append(n.transfers, Transfer{
+		TransactionID:      txID,
+		ConsensusTimestamp: ts,
+		TokenID:            req.TokenID,
+		FromAccountID:      req.FromAccountID,
+		ToAccountID:        req.ToAccountID,
+		Amount:             req.Amount,
+		Memo:               req.Memo,
+	})
+
+	return &hts.TransferReceipt{
+		TransactionID: txID,
+		TokenID:       req.TokenID,
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount,
+		Status:        hiero.StatusSuccess.String(),
+	}, nil
+}
+
+// TransferBatch debits the sender once and credits every recipient in one
+// transaction, recorded as one Transfer per credit sharing a transaction ID.
+// It fails without changing balances if any account is not associated or
+// the sender cannot cover the total.
+func (n *Network) TransferBatch(ctx context.Context, req hts.BatchTransferRequest) (*hts.BatchTransferReceipt, error) {
+	total := req.Total()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("batch transfer %d of token %s from %s to %d accounts: %w",
+			total, req.TokenID, req.FromAccountID, len(req.Credits), err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	txID, err := n.transferBatchLocked(req, false)
+	if err != nil {
+		return nil, fmt.Errorf("batch transfer %d of token %s from %s to %d accounts: %w",
+			total, req.TokenID, req.FromAccountID, len(req.Credits), err)
+	}
+	return &hts.BatchTransferReceipt{
+		TransactionID: txID,
+		TokenID:       req.TokenID,
+		FromAccountID: req.FromAccountID,
+		Credits:       append([]hts.Credit(nil), req.Credits...),
+		Status:        hiero.StatusSuccess.String(),
+	}, nil
+}
+
+// transferBatchLocked applies a validated batch transfer and records one
+// Transfer per credit. n.mu must be held.
+func (n *Network) transferBatchLocked(req hts.BatchTransferRequest, scheduled bool) (hiero.TransactionID, error) {
+	tok, err := n.tokenLocked(req.TokenID)
+	if err != nil {
+		return hiero.TransactionID{}, err
+	}
+	from := req.FromAccountID.String()
+	if !tok.associated[from] {
+		return hiero.TransactionID{}, fmt.Errorf("sender: %w", ErrTokenNotAssociated)
+	}
+	for _, c := range req.Credits {
+		if !tok.associated[c.ToAccountID.String()] {
+			return hiero.TransactionID{}, fmt.Errorf("recipient %s: %w", c.ToAccountID, ErrTokenNotAssociated)
+		}
+	}
+	total := req.Total()
+	if tok.balances[from] < total {
+		return hiero.TransactionID{}, ErrInsufficientBalance
+	}
+
+	ts := n.consensusLocked()
+	txID := transactionID(req.FromAccountID, ts).SetScheduled(scheduled)
+	tok.balances[from] -= total
+	for _, c := range req.Credits {
+		tok.balances[c.ToAccountID.String()] += c.Amount
+		n.transfers = append(n.transfers, Transfer{
+			TransactionID:      txID,
+			ConsensusTimestamp: ts,
+			TokenID:            req.TokenID,
+			FromAccountID:      req.FromAccountID,
+			ToAccountID:        c.ToAccountID,
+			Amount:             c.Amount,
+			Memo:               req.Memo,
+		})
+	}
+	return txID, nil
+}
+
+// Balance returns an account's balance of a token.
+func (n *Network) Balance(tokenID hiero.TokenID, accountID hiero.AccountID) (int64, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	tok, err := n.tokenLocked(tokenID)
+	if err != nil {
+		return 0, fmt.Errorf("balance of token %s for %s: %w", tokenID, accountID, err)
+	}
+	return tok.balances[accountID.String()], nil
+}
+
+// TokenBalance returns an account's balance of a token, failing with
+// hts.ErrNotAssociated if the account is not associated with it.
+func (n *Network) TokenBalance(ctx context.Context, tokenID hiero.TokenID, accountID hiero.AccountID) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("balance of token %s for %s: %w", tokenID, accountID, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	tok, err := n.tokenLocked(tokenID)
+	if err != nil {
+		return 0, fmt.Errorf("balance of token %s for %s: %w", tokenID, accountID, err)
+	}
+	if !tok.associated[accountID.String()] {
+		return 0, fmt.Errorf("balance of token %s for %s: %w", tokenID, accountID, hts.ErrNotAssociated)
+	}
+	return tok.balances[accountID.String()], nil
+}
+
+// IsAssociated reports whether an account is associated with a token.
+func (n *Network) IsAssociated(ctx context.Context, tokenID hiero.TokenID, accountID hiero.AccountID) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, fmt.Errorf("check association of token %s with %s: %w", tokenID, accountID, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	tok, err := n.tokenLocked(tokenID)
+	if err != nil {
+		return false, fmt.Errorf("check association of token %s with %s: %w", tokenID, accountID, err)
+	}
+	return tok.associated[accountID.String()], nil
+}
+
+// Allowance returns how many of owner's tokens spender may transfer. The
+// simulated network has no approvals, so it is always zero.
+func (n *Network) Allowance(ctx context.Context, tokenID hiero.TokenID, owner, spender hiero.AccountID) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("allowance of token %s from %s to %s: %w", tokenID, owner, spender, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, err := n.tokenLocked(tokenID); err != nil {
+		return 0, fmt.Errorf("allowance of token %s from %s to %s: %w", tokenID, owner, spender, err)
+	}
+	return 0, nil
+}
+
+// TokenTransfers returns the settled transfers of a token debited from or
+// credited to an account at or after since, oldest first.
+func (n *Network) TokenTransfers(ctx context.Context, tokenID hiero.TokenID, accountID hiero.AccountID, since time.Time) ([]hts.TransferRecord, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("list transfers of token %s for %s: %w", tokenID, accountID, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var records []hts.TransferRecord
+	for _, t := range n.transfers {
+		if t.TokenID != tokenID || t.ConsensusTimestamp.Before(since) {
+			continue
+		}
+		if t.FromAccountID != accountID && t.ToAccountID != accountID {
+			continue
+		}
+		records = append(records, hts.TransferRecord(t))
+	}
+	return records, nil
+}
+
+// Transfers returns a copy of every settled transfer, in consensus order.
+func (n *Network) Transfers() []Transfer {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]Transfer(nil), n.transfers...)
+}
+
+// tokenLocked looks up a token by ID. n.mu must be held.
+func (n *Network) tokenLocked(tokenID hiero.TokenID) (*token, error) {
+	tok, ok := n.tokens[tokenID.String()]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	return tok, nil
+}
+
+// Compile-time interface compliance checks.
+var (
+	_ hts.TokenCreator       = (*Network)(nil)
+	_ hts.TokenTransfer      = (*Network)(nil)
+	_ hts.BatchTokenTransfer = (*Network)(nil)
+	_ hts.TokenBalances      = (*Network)(nil)
+	_ hts.TransferHistory    = (*Network)(nil)
+)
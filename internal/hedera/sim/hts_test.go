@@ -0,0 +1,170 @@
+package sim
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+)
+
+func newTestToken(t *testing.T, n *Network, supply uint64) (hiero.TokenID, hiero.AccountID) {
+	t.Helper()
+	treasury := n.CreateAccount()
+	cfg := hts.DefaultTokenConfig()
+	cfg.TreasuryAccountID = treasury
+	cfg.InitialSupply = supply
+	tokenID, err := n.CreateFungibleToken(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("CreateFungibleToken: %v", err)
+	}
+	return tokenID, treasury
+}
+
+func TestCreateFungibleToken_CreditsTreasury(t *testing.T) {
+	n := NewNetwork()
+	tokenID, treasury := newTestToken(t, n, 500)
+
+	balance, err := n.Balance(tokenID, treasury)
+	if err != nil {
+		t.Fatalf("Balance: %v", err)
+	}
+	if balance != 500 {
+		t.Errorf("treasury balance = %d, want 500", balance)
+	}
+
+	info, err := n.TokenInfo(context.Background(), tokenID)
+	if err != nil {
+		t.Fatalf("TokenInfo: %v", err)
+	}
+	if info.TotalSupply != 500 || info.TreasuryID.String() != treasury.String() {
+		t.Errorf("TokenInfo = %+v", info)
+	}
+}
+
+func TestTransfer_Rules(t *testing.T) {
+	tests := []struct {
+		name      string
+		associate bool
+		amount    int64
+		wantErr   error
+	}{
+		{name: "settles between associated accounts", associate: true, amount: 40},
+		{name: "recipient not associated", associate: false, amount: 40, wantErr: ErrTokenNotAssociated},
+		{name: "insufficient balance", associate: true, amount: 101, wantErr: ErrInsufficientBalance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			n := NewNetwork()
+			tokenID, treasury := newTestToken(t, n, 100)
+			agent := n.CreateAccount()
+			if tt.associate {
+				if err := n.AssociateToken(ctx, tokenID, agent); err != nil {
+					t.Fatalf("AssociateToken: %v", err)
+				}
+			}
+
+			receipt, err := n.Transfer(ctx, hts.TransferRequest{
+				TokenID:       tokenID,
+				FromAccountID: treasury,
+				ToAccountID:   agent,
+				Amount:        tt.amount,
+				Memo:          "payment:task:t1",
+			})
+
+			treasuryBal, _ := n.Balance(tokenID, treasury)
+			agentBal, _ := n.Balance(tokenID, agent)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Transfer error = %v, want %v", err, tt.wantErr)
+				}
+				if treasuryBal != 100 || agentBal != 0 {
+					t.Errorf("balances changed on failed transfer: treasury=%d agent=%d", treasuryBal, agentBal)
+				}
+				if len(n.Transfers()) != 0 {
+					t.Error("failed transfer was recorded")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Transfer: %v", err)
+			}
+			if receipt.Status != "SUCCESS" {
+				t.Errorf("receipt status = %q, want SUCCESS", receipt.Status)
+			}
+			if treasuryBal != 100-tt.amount || agentBal != tt.amount {
+				t.Errorf("balances: treasury=%d agent=%d", treasuryBal, agentBal)
+			}
+			transfers := n.Transfers()
+			if len(transfers) != 1 || transfers[0].Memo != "payment:task:t1" {
+				t.Errorf("recorded transfers = %+v", transfers)
+			}
+		})
+	}
+}
+
+func TestAssociateToken_Twice(t *testing.T) {
+	ctx := context.Background()
+	n := NewNetwork()
+	tokenID, _ := newTestToken(t, n, 1)
+	agent := n.CreateAccount()
+
+	if err := n.AssociateToken(ctx, tokenID, agent); err != nil {
+		t.Fatalf("AssociateToken: %v", err)
+	}
+	if err := n.AssociateToken(ctx, tokenID, agent); !errors.Is(err, ErrTokenAlreadyAssociated) {
+		t.Errorf("second AssociateToken error = %v, want ErrTokenAlreadyAssociated", err)
+	}
+}
+
+func TestTransferBatch_AllOrNothing(t *testing.T) {
+	ctx := context.Background()
+	n := NewNetwork()
+	tokenID, treasury := newTestToken(t, n, 100)
+	a, b, unassociated := n.CreateAccount(), n.CreateAccount(), n.CreateAccount()
+	for _, account := range []hiero.AccountID{a, b} {
+		if err := n.AssociateToken(ctx, tokenID, account); err != nil {
+			t.Fatalf("AssociateToken: %v", err)
+		}
+	}
+	batch := func(credits ...hts.Credit) hts.BatchTransferRequest {
+		return hts.BatchTransferRequest{TokenID: tokenID, FromAccountID: treasury, Credits: credits, Memo: "payment:batch:2"}
+	}
+
+	if _, err := n.TransferBatch(ctx, batch(hts.Credit{ToAccountID: a, Amount: 10}, hts.Credit{ToAccountID: unassociated, Amount: 10})); !errors.Is(err, ErrTokenNotAssociated) {
+		t.Errorf("batch with unassociated recipient error = %v, want ErrTokenNotAssociated", err)
+	}
+	if _, err := n.TransferBatch(ctx, batch(hts.Credit{ToAccountID: a, Amount: 60}, hts.Credit{ToAccountID: b, Amount: 60})); !errors.Is(err, ErrInsufficientBalance) {
+		t.Errorf("batch over balance error = %v, want ErrInsufficientBalance", err)
+	}
+	if len(n.Transfers()) != 0 {
+		t.Fatalf("failed batches recorded transfers: %+v", n.Transfers())
+	}
+
+	receipt, err := n.TransferBatch(ctx, batch(hts.Credit{ToAccountID: a, Amount: 30}, hts.Credit{ToAccountID: b, Amount: 20}))
+	if err != nil {
+		t.Fatalf("TransferBatch: %v", err)
+	}
+	if bal, _ := n.TokenBalance(ctx, tokenID, treasury); bal != 50 {
+		t.Errorf("treasury balance = %d, want 50", bal)
+	}
+	transfers := n.Transfers()
+	if len(transfers) != 2 || transfers[0].TransactionID.String() != receipt.TransactionID.String() ||
+		transfers[1].TransactionID.String() != receipt.TransactionID.String() {
+		t.Errorf("recorded transfers = %+v, want two credits sharing %s", transfers, receipt.TransactionID)
+	}
+
+	credits := make([]hts.Credit, hts.MaxTokenTransfers)
+	for i := range credits {
+		credits[i] = hts.Credit{ToAccountID: n.CreateAccount(), Amount: 1}
+	}
+	if _, err := n.TransferBatch(ctx, batch(credits...)); !errors.Is(err, hts.ErrTooManyTransfers) {
+		t.Errorf("oversized batch error = %v, want ErrTooManyTransfers", err)
+	}
+}
@@ -0,0 +1,188 @@
+package sim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/hts"
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/schedule"
+)
+
+// scheduleEntry is a simulated scheduled transaction. Keys are not modelled:
+// the inner transaction executes when the schedule is signed, and only token
+// transfers have any effect. Expired schedules are treated as removed, as on
+// the network.
+type scheduleEntry struct {
+	memo      string
+	innerTx   hiero.TransactionInterface
+	createdAt time.Time
+	expiresAt time.Time
+	executed  bool
+	deleted   bool
+}
+
+// CreateSchedule records a scheduled transaction that never expires and
+// returns its ID.
+func (n *Network) CreateSchedule(ctx context.Context, innerTx hiero.TransactionInterface, memo string) (hiero.ScheduleID, error) {
+	return n.CreateScheduleExpiring(ctx, innerTx, memo, time.Time{})
+}
+
+// CreateScheduleExpiring records a scheduled transaction that is removed at
+// expiresAt, or never if it is zero, and returns its ID.
+func (n *Network) CreateScheduleExpiring(ctx context.Context, innerTx hiero.TransactionInterface, memo string, expiresAt time.Time) (hiero.ScheduleID, error) {
+	if err := ctx.Err(); err != nil {
+		return hiero.ScheduleID{}, fmt.Errorf("create schedule: %w", err)
+	}
+	if innerTx == nil {
+		return hiero.ScheduleID{}, fmt.Errorf("create schedule with memo %q: inner transaction is nil", memo)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	id := hiero.ScheduleID{Schedule: n.allocEntityLocked()}
+	n.schedules[id.String()] = &scheduleEntry{
+		memo:      memo,
+		innerTx:   innerTx,
+		createdAt: n.consensusLocked(),
+		expiresAt: expiresAt,
+	}
+	return id, nil
+}
+
+// ScheduleInfo returns a scheduled transaction's metadata.
+func (n *Network) ScheduleInfo(ctx context.Context, scheduleID hiero.ScheduleID) (*schedule.ScheduleMetadata, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("schedule info %s: %w", scheduleID, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	entry, ok := n.schedules[scheduleID.String()]
+	if !ok || n.expiredLocked(entry) {
+		return nil, fmt.Errorf("schedule info %s: %w", scheduleID, ErrScheduleNotFound)
+	}
+	return &schedule.ScheduleMetadata{
+		ScheduleID: scheduleID,
+		Memo:       entry.memo,
+		Executed:   entry.executed,
+		Deleted:    entry.deleted,
+	}, nil
+}
+
+// SignSchedule executes a pending scheduled transaction. As on the network,
+// a schedule whose inner transfer fails still counts as executed.
+func (n *Network) SignSchedule(ctx context.Context, scheduleID hiero.ScheduleID) (hiero.TransactionID, error) {
+	if err := ctx.Err(); err != nil {
+		return hiero.TransactionID{}, fmt.Errorf("sign schedule %s: %w", scheduleID, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	entry, err := n.pendingScheduleLocked(scheduleID)
+	if err != nil {
+		return hiero.TransactionID{}, fmt.Errorf("sign schedule %s: %w", scheduleID, scheduleError(err))
+	}
+	entry.executed = true
+
+	transfer, ok := entry.innerTx.(*hiero.TransferTransaction)
+	if !ok || len(transfer.GetTokenTransfers()) == 0 {
+		return transactionID(hiero.AccountID{}, n.consensusLocked()).SetScheduled(true), nil
+	}
+	req, err := scheduledTransfer(transfer)
+	if err != nil {
+		return hiero.TransactionID{}, fmt.Errorf("sign schedule %s: %w", scheduleID, err)
+	}
+	txID, err := n.transferBatchLocked(req, true)
+	if err != nil {
+		return hiero.TransactionID{}, fmt.Errorf("sign schedule %s: scheduled transfer: %w", scheduleID, err)
+	}
+	return txID, nil
+}
+
+// DeleteSchedule deletes a scheduled transaction that has not executed.
+func (n *Network) DeleteSchedule(ctx context.Context, scheduleID hiero.ScheduleID) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("delete schedule %s: %w", scheduleID, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	entry, err := n.pendingScheduleLocked(scheduleID)
+	if err != nil {
+		return fmt.Errorf("delete schedule %s: %w", scheduleID, scheduleError(err))
+	}
+	entry.deleted = true
+	return nil
+}
+
+// pendingScheduleLocked returns a schedule that has neither executed nor
+// been deleted. n.mu must be held.
+func (n *Network) pendingScheduleLocked(scheduleID hiero.ScheduleID) (*scheduleEntry, error) {
+	entry, ok := n.schedules[scheduleID.String()]
+	switch {
+	case !ok || n.expiredLocked(entry):
+		return nil, ErrScheduleNotFound
+	case entry.deleted:
+		return nil, ErrScheduleDeleted
+	case entry.executed:
+		return nil, ErrScheduleExecuted
+	}
+	return entry, nil
+}
+
+// expiredLocked reports whether an unexecuted schedule has passed its
+// expiration time. n.mu must be held.
+func (n *Network) expiredLocked(entry *scheduleEntry) bool {
+	return !entry.executed && !entry.expiresAt.IsZero() && !n.clock().Before(entry.expiresAt)
+}
+
+// scheduleError adds schedule.ErrScheduleGone to errors for schedules that
+// were deleted or have expired.
+func scheduleError(err error) error {
+	if errors.Is(err, ErrScheduleNotFound) || errors.Is(err, ErrScheduleDeleted) {
+		return fmt.Errorf("%w: %w", schedule.ErrScheduleGone, err)
+	}
+	return err
+}
+
+// scheduledTransfer reads a scheduled token transfer as a batch transfer:
+// one token, one debited account and at least one credit.
+func scheduledTransfer(tx *hiero.TransferTransaction) (hts.BatchTransferRequest, error) {
+	tokens := tx.GetTokenTransfers()
+	if len(tokens) != 1 {
+		return hts.BatchTransferRequest{}, fmt.Errorf("scheduled transfer moves %d tokens, simulated network supports one", len(tokens))
+	}
+
+	var req hts.BatchTransferRequest
+	debits := 0
+	for tokenID, legs := range tokens {
+		req.TokenID = tokenID
+		for _, leg := range legs {
+			if leg.Amount < 0 {
+				req.FromAccountID = leg.AccountID
+				debits++
+			} else if leg.Amount > 0 {
+				req.Credits = append(req.Credits, hts.Credit{ToAccountID: leg.AccountID, Amount: leg.Amount})
+			}
+		}
+	}
+	if debits != 1 {
+		return hts.BatchTransferRequest{}, fmt.Errorf("scheduled transfer debits %d accounts, simulated network supports one", debits)
+	}
+	req.Memo = tx.GetTransactionMemo()
+	return req, req.Validate()
+}
+
+// Compile-time interface compliance checks.
+var (
+	_ schedule.ExpiringScheduleCreator = (*Network)(nil)
+	_ schedule.ScheduleSigner          = (*Network)(nil)
+)
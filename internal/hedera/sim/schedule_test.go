@@ -0,0 +1,120 @@
+package sim
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/lancekrogers/agent-coordinator-ethden-2026/internal/hedera/schedule"
+)
+
+func TestCreateSchedule_Info(t *testing.T) {
+	ctx := context.Background()
+	n := NewNetwork()
+	account := n.CreateAccount()
+
+	innerTx := hiero.NewTransferTransaction().AddHbarTransfer(account, hiero.NewHbar(0))
+	scheduleID, err := n.CreateSchedule(ctx, innerTx, "agent-heartbeat:coordinator:1")
+	if err != nil {
+		t.Fatalf("CreateSchedule: %v", err)
+	}
+
+	info, err := n.ScheduleInfo(ctx, scheduleID)
+	if err != nil {
+		t.Fatalf("ScheduleInfo: %v", err)
+	}
+	if info.Memo != "agent-heartbeat:coordinator:1" {
+		t.Errorf("memo = %q", info.Memo)
+	}
+
+	scheduleID.Schedule += 100
+	if _, err := n.ScheduleInfo(ctx, scheduleID); !errors.Is(err, ErrScheduleNotFound) {
+		t.Errorf("unknown schedule error = %v, want ErrScheduleNotFound", err)
+	}
+}
+
+func TestSignSchedule_ExecutesTransferOnce(t *testing.T) {
+	ctx := context.Background()
+	n := NewNetwork()
+	tokenID, escrow := newTestToken(t, n, 100)
+	agent := n.CreateAccount()
+	if err := n.AssociateToken(ctx, tokenID, agent); err != nil {
+		t.Fatalf("AssociateToken: %v", err)
+	}
+	escrowTransfer := func() hiero.TransactionInterface {
+		tx := hiero.NewTransferTransaction().
+			AddTokenTransfer(tokenID, escrow, -40).
+			AddTokenTransfer(tokenID, agent, 40)
+		tx.SetTransactionMemo("payment:task:t1")
+		return tx
+	}
+
+	released, err := n.CreateSchedule(ctx, escrowTransfer(), "escrow:task:t1")
+	if err != nil {
+		t.Fatalf("CreateSchedule: %v", err)
+	}
+	if bal, _ := n.TokenBalance(ctx, tokenID, agent); bal != 0 {
+		t.Fatalf("agent balance before signing = %d, want 0", bal)
+	}
+	txID, err := n.SignSchedule(ctx, released)
+	if err != nil {
+		t.Fatalf("SignSchedule: %v", err)
+	}
+	if !txID.GetScheduled() {
+		t.Errorf("executed transaction %s not marked scheduled", txID)
+	}
+	if bal, _ := n.TokenBalance(ctx, tokenID, agent); bal != 40 {
+		t.Errorf("agent balance after signing = %d, want 40", bal)
+	}
+	if transfers := n.Transfers(); len(transfers) != 1 || transfers[0].Memo != "payment:task:t1" {
+		t.Errorf("transfers = %+v, want the scheduled payment", transfers)
+	}
+	if _, err := n.SignSchedule(ctx, released); !errors.Is(err, ErrScheduleExecuted) {
+		t.Errorf("second SignSchedule error = %v, want ErrScheduleExecuted", err)
+	}
+	if err := n.DeleteSchedule(ctx, released); !errors.Is(err, ErrScheduleExecuted) {
+		t.Errorf("DeleteSchedule after execution error = %v, want ErrScheduleExecuted", err)
+	}
+
+	cancelled, err := n.CreateSchedule(ctx, escrowTransfer(), "escrow:task:t2")
+	if err != nil {
+		t.Fatalf("CreateSchedule: %v", err)
+	}
+	if err := n.DeleteSchedule(ctx, cancelled); err != nil {
+		t.Fatalf("DeleteSchedule: %v", err)
+	}
+	if _, err := n.SignSchedule(ctx, cancelled); !errors.Is(err, ErrScheduleDeleted) {
+		t.Errorf("SignSchedule after delete error = %v, want ErrScheduleDeleted", err)
+	}
+	if info, _ := n.ScheduleInfo(ctx, cancelled); !info.Deleted || info.Executed {
+		t.Errorf("deleted schedule info = %+v", info)
+	}
+}
+
+func TestCreateScheduleExpiring_RemovedAtExpiry(t *testing.T) {
+	ctx := context.Background()
+	n := NewNetwork()
+	now := time.Now()
+	n.clock = func() time.Time { return now }
+	account := n.CreateAccount()
+
+	innerTx := hiero.NewTransferTransaction().AddHbarTransfer(account, hiero.NewHbar(0))
+	scheduleID, err := n.CreateScheduleExpiring(ctx, innerTx, "escrow:task:t1", now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("CreateScheduleExpiring: %v", err)
+	}
+	if _, err := n.ScheduleInfo(ctx, scheduleID); err != nil {
+		t.Fatalf("ScheduleInfo before expiry: %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := n.SignSchedule(ctx, scheduleID); !errors.Is(err, ErrScheduleNotFound) || !errors.Is(err, schedule.ErrScheduleGone) {
+		t.Errorf("SignSchedule after expiry error = %v, want ErrScheduleNotFound and ErrScheduleGone", err)
+	}
+	if err := n.DeleteSchedule(ctx, scheduleID); !errors.Is(err, schedule.ErrScheduleGone) {
+		t.Errorf("DeleteSchedule after expiry error = %v, want ErrScheduleGone", err)
+	}
+}